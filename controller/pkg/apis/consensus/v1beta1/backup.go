@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConsensusBackupState is a state constant for ConsensusBackup
+type ConsensusBackupState string
+
+const (
+	// ConsensusBackupPending indicates a ConsensusBackup is waiting to be run
+	ConsensusBackupPending ConsensusBackupState = "Pending"
+	// ConsensusBackupRunning indicates a ConsensusBackup is in progress
+	ConsensusBackupRunning ConsensusBackupState = "Running"
+	// ConsensusBackupComplete indicates the last run of a ConsensusBackup completed successfully
+	ConsensusBackupComplete ConsensusBackupState = "Complete"
+	// ConsensusBackupFailed indicates the last run of a ConsensusBackup failed
+	ConsensusBackupFailed ConsensusBackupState = "Failed"
+)
+
+// BackupPartitionState is a state constant for the backup or restore of a single partition
+type BackupPartitionState string
+
+const (
+	// BackupPartitionPending indicates the partition has not yet been processed
+	BackupPartitionPending BackupPartitionState = "Pending"
+	// BackupPartitionComplete indicates the partition was processed successfully
+	BackupPartitionComplete BackupPartitionState = "Complete"
+	// BackupPartitionFailed indicates processing of the partition failed
+	BackupPartitionFailed BackupPartitionState = "Failed"
+)
+
+// ConsensusBackupSpec specifies a ConsensusBackup configuration. A backup is a point-in-time snapshot of the
+// cluster: every partition is cut at the backup's time, reflecting the changes proposed at or before the time
+// and none proposed after it. The index at which each partition was cut is recorded in the backup's manifest.
+type ConsensusBackupSpec struct {
+	// Cluster is the MultiRaftCluster to back up
+	Cluster corev1.LocalObjectReference `json:"cluster"`
+
+	// Schedule is a cron schedule on which to take backups. If no schedule is set, a single backup is taken.
+	Schedule string `json:"schedule,omitempty"`
+
+	// Target is the location to which backups are written
+	Target BackupTarget `json:"target"`
+}
+
+// BackupTarget is a location for backup files. Exactly one of the targets must be set.
+type BackupTarget struct {
+	// Volume is a path on the backup volume mounted in the controller
+	Volume *VolumeBackupTarget `json:"volume,omitempty"`
+
+	// S3 is an S3-compatible object store
+	S3 *S3BackupTarget `json:"s3,omitempty"`
+}
+
+// VolumeBackupTarget is a backup location on the controller's backup volume
+type VolumeBackupTarget struct {
+	// Path is the path relative to the root of the backup volume
+	Path string `json:"path,omitempty"`
+}
+
+// S3BackupTarget is a backup location in an S3-compatible object store
+type S3BackupTarget struct {
+	// Endpoint is the host and optional port of the object store
+	Endpoint string `json:"endpoint"`
+
+	// Region is the region in which the bucket is located
+	Region string `json:"region,omitempty"`
+
+	// Bucket is the name of the bucket to which to write backups
+	Bucket string `json:"bucket"`
+
+	// Prefix is a key prefix for backup objects
+	Prefix string `json:"prefix,omitempty"`
+
+	// Insecure indicates whether to connect to the endpoint over plain HTTP
+	Insecure bool `json:"insecure,omitempty"`
+
+	// CredentialsSecret is a Secret containing the accessKeyID and secretAccessKey keys
+	CredentialsSecret corev1.LocalObjectReference `json:"credentialsSecret"`
+}
+
+// ConsensusBackupStatus defines the status of a ConsensusBackup
+type ConsensusBackupStatus struct {
+	State    ConsensusBackupState `json:"state,omitempty"`
+	Location string               `json:"location,omitempty"`
+	// Time is the point in time at which the partitions are cut
+	Time             *metav1.Time            `json:"time,omitempty"`
+	StartTime        *metav1.Time            `json:"startTime,omitempty"`
+	CompletionTime   *metav1.Time            `json:"completionTime,omitempty"`
+	NextScheduleTime *metav1.Time            `json:"nextScheduleTime,omitempty"`
+	Partitions       []BackupPartitionStatus `json:"partitions,omitempty"`
+}
+
+// BackupPartitionStatus is the status of the backup or restore of a single partition
+type BackupPartitionStatus struct {
+	PartitionID int32                `json:"partitionID"`
+	State       BackupPartitionState `json:"state,omitempty"`
+	Index       uint64               `json:"index,omitempty"`
+	Size        int64                `json:"size,omitempty"`
+	// Time is the point in time at which the partition's snapshot was cut
+	Time *metav1.Time `json:"time,omitempty"`
+	// Members are the IDs of the members on which a restored partition's snapshot has been staged
+	Members []int32 `json:"members,omitempty"`
+	// Attempts is the number of failed attempts to process the partition
+	Attempts int32  `json:"attempts,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsensusBackup is the Schema for the ConsensusBackup API
+// +k8s:openapi-gen=true
+type ConsensusBackup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ConsensusBackupSpec   `json:"spec,omitempty"`
+	Status            ConsensusBackupStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsensusBackupList contains a list of ConsensusBackup
+type ConsensusBackupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the ConsensusBackup of items in the list
+	Items []ConsensusBackup `json:"items"`
+}
@@ -45,6 +45,60 @@ type MultiRaftClusterSpec struct {
 
 	// Config is the consensus store configuration
 	Config MultiRaftClusterConfig `json:"config,omitempty"`
+
+	// Placement is the placement strategy for group members
+	Placement MultiRaftPlacement `json:"placement,omitempty"`
+
+	// LeaderBalancing configures balancing of group leaders across the cluster's pods. If unset, leaders
+	// are not balanced.
+	LeaderBalancing *MultiRaftLeaderBalancing `json:"leaderBalancing,omitempty"`
+
+	// UpgradeStrategy configures the rolling upgrade of the cluster's pods
+	UpgradeStrategy *MultiRaftUpgradeStrategy `json:"upgradeStrategy,omitempty"`
+
+	// Restore is the ConsensusRestore from which the cluster is bootstrapped. The members of each group
+	// are not started until the restore has staged the group's snapshot on the group's initial members.
+	Restore *corev1.LocalObjectReference `json:"restore,omitempty"`
+}
+
+// MultiRaftPlacement configures the placement of group members on the cluster's pods
+type MultiRaftPlacement struct {
+	// TopologyKey is the node label across which the voting members of each group are spread,
+	// e.g. topology.kubernetes.io/zone. If unset, members are placed on pods by ordinal.
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// TopologySpreadConstraints are the topology spread constraints for the cluster's pods. If unset and a
+	// topology key is specified, pods are spread evenly across the topology key.
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
+
+// MultiRaftLeaderBalancing configures the periodic transfer of group leadership between the cluster's pods
+type MultiRaftLeaderBalancing struct {
+	// MaxSkew is the maximum difference between the number of groups led by any two pods. Defaults to 1.
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+
+	// Interval is the interval at which the distribution of leaders is checked. Defaults to 30s.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// PreferredLeaders is the set of pods preferred to lead each group. Groups with a preferred leader are
+	// moved to the preferred pod whenever it hosts a ready voting member of the group.
+	PreferredLeaders []MultiRaftPreferredLeader `json:"preferredLeaders,omitempty"`
+}
+
+// MultiRaftUpgradeStrategy configures the rolling upgrade of the cluster's pods, which are upgraded one at a time
+type MultiRaftUpgradeStrategy struct {
+	// MaxLag is the number of entries by which the members of an upgraded pod may trail the leaders of their
+	// groups and still be considered caught up, allowing the next pod to be upgraded. Defaults to 1000.
+	MaxLag *int64 `json:"maxLag,omitempty"`
+}
+
+// MultiRaftPreferredLeader is the pod preferred to lead a group
+type MultiRaftPreferredLeader struct {
+	// Group is the ID of the group
+	Group int32 `json:"group"`
+
+	// Pod is the name of the pod preferred to lead the group
+	Pod string `json:"pod"`
 }
 
 type MultiRaftClusterConfig struct {
@@ -56,6 +110,102 @@ type MultiRaftClusterConfig struct {
 
 	// Logging is the store logging configuration
 	Logging LoggingConfig `json:"logging,omitempty"`
+
+	// TLS configures mutual TLS for the Raft transport and the node API. If unset, nodes communicate
+	// without TLS.
+	TLS *MultiRaftTLSConfig `json:"tls,omitempty"`
+
+	// Auth configures authentication of the nodes' administrative RPCs. If unset, administrative RPCs
+	// are not authenticated.
+	Auth *MultiRaftAuthConfig `json:"auth,omitempty"`
+
+	// Metrics configures the nodes' Prometheus metrics endpoint. If unset, metrics are not exported.
+	Metrics *MultiRaftMetricsConfig `json:"metrics,omitempty"`
+
+	// Tracing configures the export of the nodes' OpenTelemetry traces. If unset, traces are not exported.
+	Tracing *MultiRaftTracingConfig `json:"tracing,omitempty"`
+}
+
+// MultiRaftTracingConfig configures the export of the nodes' traces to an OTLP collector over gRPC
+type MultiRaftTracingConfig struct {
+	// Endpoint is the host:port of the OTLP collector
+	Endpoint string `json:"endpoint"`
+
+	// Insecure disables TLS for the connection to the collector
+	Insecure bool `json:"insecure,omitempty"`
+
+	// SamplePercent is the percentage of traces sampled by the nodes. Defaults to 100.
+	SamplePercent *int32 `json:"samplePercent,omitempty"`
+}
+
+// MultiRaftMetricsConfig configures the nodes' Prometheus metrics endpoint, which is served on the
+// cluster's "metrics" port at /metrics
+type MultiRaftMetricsConfig struct {
+	// ServiceMonitor configures a Prometheus Operator ServiceMonitor for the cluster. If unset, no
+	// ServiceMonitor is created.
+	ServiceMonitor *MultiRaftServiceMonitor `json:"serviceMonitor,omitempty"`
+}
+
+// MultiRaftServiceMonitor configures the ServiceMonitor scraping the cluster's nodes
+type MultiRaftServiceMonitor struct {
+	// Interval is the interval at which the nodes are scraped. If unset, Prometheus' default is used.
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Labels are additional labels to add to the ServiceMonitor, e.g. to match a Prometheus
+	// serviceMonitorSelector
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// MultiRaftAuthConfig configures authentication of the nodes' administrative RPCs. Callers must present
+// a bearer token, either the token stored in the cluster's auth Secret or the token of one of the listed
+// service accounts. The controller authenticates with the token from the Secret.
+type MultiRaftAuthConfig struct {
+	// SecretName is the name of the Secret containing the token under the token key. If unset, the
+	// controller generates a Secret named <cluster>-auth.
+	SecretName string `json:"secretName,omitempty"`
+
+	// ServiceAccounts are the service accounts, as namespace/name, whose tokens are accepted. Tokens are
+	// verified with the TokenReview API, so the nodes' service account must be permitted to create
+	// TokenReviews, e.g. by binding it to the system:auth-delegator ClusterRole.
+	ServiceAccounts []string `json:"serviceAccounts,omitempty"`
+}
+
+// MultiRaftTLSConfig configures mutual TLS for the cluster's nodes. The certificate is read from a Secret
+// containing the ca.crt, tls.crt and tls.key keys, which is either provided by the user or issued by
+// cert-manager. The certificate must be valid for the pods' DNS names and for both server and client
+// authentication, and is also used by the controller to authenticate with the nodes.
+type MultiRaftTLSConfig struct {
+	// SecretName is the name of the Secret containing the certificate. If unset, an IssuerRef must be
+	// specified and the certificate is stored in a Secret named <cluster>-tls.
+	SecretName string `json:"secretName,omitempty"`
+
+	// IssuerRef is the cert-manager issuer from which to request the certificate. If set, the controller
+	// creates a cert-manager Certificate for the cluster.
+	IssuerRef *MultiRaftCertificateIssuer `json:"issuerRef,omitempty"`
+
+	// Reload indicates whether nodes' API servers reload the certificate when the Secret is updated. Defaults
+	// to true.
+	//
+	// Only the API servers can reload the certificate. The Raft transport loads it when a node starts, so every
+	// change to the Secret, including a routine renewal by cert-manager, restarts all of the cluster's pods.
+	// The pods are restarted through the same one-pod-at-a-time upgrade as an image change: leadership is moved
+	// off each pod before it restarts, and the next pod is not restarted until the previous one has caught up,
+	// so quorum is preserved. Until the rollout completes, restarted pods present the new certificate to pods
+	// still presenting the old one, so a new CA must be added to ca.crt alongside the old CA before any
+	// certificate it issued is used.
+	Reload *bool `json:"reload,omitempty"`
+}
+
+// MultiRaftCertificateIssuer is a reference to a cert-manager issuer
+type MultiRaftCertificateIssuer struct {
+	// Name is the name of the issuer
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, either Issuer or ClusterIssuer. Defaults to Issuer.
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Defaults to cert-manager.io.
+	Group string `json:"group,omitempty"`
 }
 
 type MultiRaftServerConfig struct {
@@ -65,12 +215,36 @@ type MultiRaftServerConfig struct {
 	MaxSendMsgSize       *resource.Quantity `json:"maxSendMsgSize"`
 	NumStreamWorkers     *uint32            `json:"numStreamWorkers"`
 	MaxConcurrentStreams *uint32            `json:"maxConcurrentStreams"`
+	// ForwardProposals indicates whether followers forward proposals to the leader of the group.
+	// When disabled, followers reject proposals with an error identifying the leader.
+	ForwardProposals *bool `json:"forwardProposals,omitempty"`
 }
 
 // MultiRaftClusterStatus defines the status of a MultiRaftCluster
 type MultiRaftClusterStatus struct {
-	State      MultiRaftClusterState `json:"state,omitempty"`
-	Partitions []RaftPartitionStatus `json:"partitions,omitempty"`
+	State      MultiRaftClusterState          `json:"state,omitempty"`
+	Partitions []RaftPartitionStatus          `json:"partitions,omitempty"`
+	Upgrade    *MultiRaftClusterUpgradeStatus `json:"upgrade,omitempty"`
+	// Isolated indicates whether the cluster's nodes use a Raft deployment ID unique to the cluster. Clusters
+	// created before deployment IDs were assigned keep dragonboat's default ID, which they share with every
+	// other such cluster, so their nodes accept Raft messages from the nodes of those clusters.
+	Isolated *bool `json:"isolated,omitempty"`
+}
+
+// MultiRaftClusterUpgradeStatus reports the progress of a rolling upgrade of the cluster's pods
+type MultiRaftClusterUpgradeStatus struct {
+	// Revision is the StatefulSet revision being rolled out
+	Revision string `json:"revision"`
+	// Replicas is the number of pods being upgraded
+	Replicas int32 `json:"replicas"`
+	// UpdatedReplicas is the number of pods that have been released for upgrade
+	UpdatedReplicas int32 `json:"updatedReplicas"`
+	// Pod is the pod currently being upgraded
+	Pod string `json:"pod,omitempty"`
+	// StartTime is the time at which the upgrade started
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time at which the upgrade completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 type RaftPartitionStatus struct {
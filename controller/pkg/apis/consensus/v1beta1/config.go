@@ -16,6 +16,7 @@ type RaftConfig struct {
 	ElectionTimeout         *metav1.Duration `json:"electionTimeout,omitempty"`
 	SnapshotEntryThreshold  *int64           `json:"snapshotEntryThreshold,omitempty"`
 	CompactionRetainEntries *int64           `json:"compactionRetainEntries,omitempty"`
+	ProposalTimeout         *metav1.Duration `json:"proposalTimeout,omitempty"`
 }
 
 // LoggingConfig logging configuration
@@ -30,6 +30,8 @@ type RaftGroupStatus struct {
 	Term      *uint64                       `json:"term,omitempty"`
 	Leader    *corev1.LocalObjectReference  `json:"leader,omitempty"`
 	Followers []corev1.LocalObjectReference `json:"followers,omitempty"`
+	// LastMemberID is the highest member ID allocated in the group. Member IDs are never reused.
+	LastMemberID *int32 `json:"lastMemberID,omitempty"`
 }
 
 // +genclient
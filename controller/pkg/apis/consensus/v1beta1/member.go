@@ -39,9 +39,22 @@ const (
 	RaftFollower RaftMemberRole = "Follower"
 )
 
+// RaftBootstrapPolicy is a constant for RaftMember indicating how the member is started
+type RaftBootstrapPolicy string
+
+const (
+	// RaftBootstrap is a RaftBootstrapPolicy indicating the member is started as one of the initial members of the group
+	RaftBootstrap RaftBootstrapPolicy = "Bootstrap"
+	// RaftJoin is a RaftBootstrapPolicy indicating the member is added to and joins an existing group
+	RaftJoin RaftBootstrapPolicy = "Join"
+)
+
 type RaftMemberSpec struct {
-	Pod  corev1.LocalObjectReference `json:"pod"`
-	Type RaftMemberType              `json:"type"`
+	Pod             corev1.LocalObjectReference `json:"pod"`
+	Type            RaftMemberType              `json:"type"`
+	BootstrapPolicy RaftBootstrapPolicy         `json:"bootstrapPolicy,omitempty"`
+	// Domain is the topology domain of the pod on which the member was placed
+	Domain string `json:"domain,omitempty"`
 }
 
 // RaftMemberStatus defines the status of a RaftMember
@@ -55,6 +68,8 @@ type RaftMemberStatus struct {
 	LastUpdated       *metav1.Time                 `json:"lastUpdated,omitempty"`
 	LastSnapshotIndex *uint64                      `json:"lastSnapshotIndex,omitempty"`
 	LastSnapshotTime  *metav1.Time                 `json:"lastSnapshotTime,omitempty"`
+	// HostID is the identity of the node data directory on which the member was last started
+	HostID string `json:"hostID,omitempty"`
 }
 
 // +genclient
@@ -38,6 +38,8 @@ var (
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion, &ConsensusStore{}, &ConsensusStoreList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ConsensusBackup{}, &ConsensusBackupList{})
+	scheme.AddKnownTypes(SchemeGroupVersion, &ConsensusRestore{}, &ConsensusRestoreList{})
 	scheme.AddKnownTypes(SchemeGroupVersion, &MultiRaftCluster{}, &MultiRaftClusterList{})
 	scheme.AddKnownTypes(SchemeGroupVersion, &RaftGroup{}, &RaftGroupList{})
 	scheme.AddKnownTypes(SchemeGroupVersion, &RaftMember{}, &RaftMemberList{})
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConsensusRestoreState is a state constant for ConsensusRestore
+type ConsensusRestoreState string
+
+const (
+	// ConsensusRestorePending indicates a ConsensusRestore is waiting for the cluster to be created
+	ConsensusRestorePending ConsensusRestoreState = "Pending"
+	// ConsensusRestoreRunning indicates a ConsensusRestore is in progress
+	ConsensusRestoreRunning ConsensusRestoreState = "Running"
+	// ConsensusRestoreComplete indicates a ConsensusRestore completed successfully
+	ConsensusRestoreComplete ConsensusRestoreState = "Complete"
+	// ConsensusRestoreFailed indicates a ConsensusRestore failed
+	ConsensusRestoreFailed ConsensusRestoreState = "Failed"
+)
+
+// ConsensusRestoreSpec specifies a ConsensusRestore configuration. A backup is restored by bootstrapping a
+// new cluster from it: the cluster must reference the restore in its spec, and the members of each group are
+// not started until the group's snapshot has been staged on all of the group's initial members.
+type ConsensusRestoreSpec struct {
+	// Cluster is the new MultiRaftCluster to bootstrap from the backup
+	Cluster corev1.LocalObjectReference `json:"cluster"`
+
+	// Source is the location from which to read the backup
+	Source BackupTarget `json:"source"`
+
+	// Location is the location of the backup within the source, as reported in the ConsensusBackup status
+	Location string `json:"location"`
+}
+
+// ConsensusRestoreStatus defines the status of a ConsensusRestore
+type ConsensusRestoreStatus struct {
+	State          ConsensusRestoreState   `json:"state,omitempty"`
+	StartTime      *metav1.Time            `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time            `json:"completionTime,omitempty"`
+	Partitions     []BackupPartitionStatus `json:"partitions,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsensusRestore is the Schema for the ConsensusRestore API
+// +k8s:openapi-gen=true
+type ConsensusRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ConsensusRestoreSpec   `json:"spec,omitempty"`
+	Status            ConsensusRestoreStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ConsensusRestoreList contains a list of ConsensusRestore
+type ConsensusRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the ConsensusRestore of items in the list
+	Items []ConsensusRestore `json:"items"`
+}
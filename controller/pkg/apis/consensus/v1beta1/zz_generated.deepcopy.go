@@ -15,6 +15,285 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupPartitionStatus) DeepCopyInto(out *BackupPartitionStatus) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupPartitionStatus.
+func (in *BackupPartitionStatus) DeepCopy() *BackupPartitionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupPartitionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTarget) DeepCopyInto(out *BackupTarget) {
+	*out = *in
+	if in.Volume != nil {
+		in, out := &in.Volume, &out.Volume
+		*out = new(VolumeBackupTarget)
+		**out = **in
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupTarget)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTarget.
+func (in *BackupTarget) DeepCopy() *BackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusBackup) DeepCopyInto(out *ConsensusBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusBackup.
+func (in *ConsensusBackup) DeepCopy() *ConsensusBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsensusBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusBackupList) DeepCopyInto(out *ConsensusBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsensusBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusBackupList.
+func (in *ConsensusBackupList) DeepCopy() *ConsensusBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsensusBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusBackupSpec) DeepCopyInto(out *ConsensusBackupSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	in.Target.DeepCopyInto(&out.Target)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusBackupSpec.
+func (in *ConsensusBackupSpec) DeepCopy() *ConsensusBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusBackupStatus) DeepCopyInto(out *ConsensusBackupStatus) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]BackupPartitionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusBackupStatus.
+func (in *ConsensusBackupStatus) DeepCopy() *ConsensusBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusRestore) DeepCopyInto(out *ConsensusRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusRestore.
+func (in *ConsensusRestore) DeepCopy() *ConsensusRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsensusRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusRestoreList) DeepCopyInto(out *ConsensusRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConsensusRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusRestoreList.
+func (in *ConsensusRestoreList) DeepCopy() *ConsensusRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConsensusRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusRestoreSpec) DeepCopyInto(out *ConsensusRestoreSpec) {
+	*out = *in
+	out.Cluster = in.Cluster
+	in.Source.DeepCopyInto(&out.Source)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusRestoreSpec.
+func (in *ConsensusRestoreSpec) DeepCopy() *ConsensusRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsensusRestoreStatus) DeepCopyInto(out *ConsensusRestoreStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]BackupPartitionStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsensusRestoreStatus.
+func (in *ConsensusRestoreStatus) DeepCopy() *ConsensusRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsensusRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsensusStore) DeepCopyInto(out *ConsensusStore) {
 	*out = *in
@@ -183,6 +462,43 @@ func (in *LoggingConfig) DeepCopy() *LoggingConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftAuthConfig) DeepCopyInto(out *MultiRaftAuthConfig) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftAuthConfig.
+func (in *MultiRaftAuthConfig) DeepCopy() *MultiRaftAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftCertificateIssuer) DeepCopyInto(out *MultiRaftCertificateIssuer) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftCertificateIssuer.
+func (in *MultiRaftCertificateIssuer) DeepCopy() *MultiRaftCertificateIssuer {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftCertificateIssuer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiRaftCluster) DeepCopyInto(out *MultiRaftCluster) {
 	*out = *in
@@ -217,6 +533,26 @@ func (in *MultiRaftClusterConfig) DeepCopyInto(out *MultiRaftClusterConfig) {
 	in.Server.DeepCopyInto(&out.Server)
 	in.Raft.DeepCopyInto(&out.Raft)
 	in.Logging.DeepCopyInto(&out.Logging)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MultiRaftTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(MultiRaftAuthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MultiRaftMetricsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(MultiRaftTracingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -282,6 +618,22 @@ func (in *MultiRaftClusterSpec) DeepCopyInto(out *MultiRaftClusterSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Config.DeepCopyInto(&out.Config)
+	in.Placement.DeepCopyInto(&out.Placement)
+	if in.LeaderBalancing != nil {
+		in, out := &in.LeaderBalancing, &out.LeaderBalancing
+		*out = new(MultiRaftLeaderBalancing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(MultiRaftUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	return
 }
 
@@ -305,6 +657,16 @@ func (in *MultiRaftClusterStatus) DeepCopyInto(out *MultiRaftClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(MultiRaftClusterUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Isolated != nil {
+		in, out := &in.Isolated, &out.Isolated
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -318,6 +680,121 @@ func (in *MultiRaftClusterStatus) DeepCopy() *MultiRaftClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftClusterUpgradeStatus) DeepCopyInto(out *MultiRaftClusterUpgradeStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftClusterUpgradeStatus.
+func (in *MultiRaftClusterUpgradeStatus) DeepCopy() *MultiRaftClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftLeaderBalancing) DeepCopyInto(out *MultiRaftLeaderBalancing) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.PreferredLeaders != nil {
+		in, out := &in.PreferredLeaders, &out.PreferredLeaders
+		*out = make([]MultiRaftPreferredLeader, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftLeaderBalancing.
+func (in *MultiRaftLeaderBalancing) DeepCopy() *MultiRaftLeaderBalancing {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftLeaderBalancing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftMetricsConfig) DeepCopyInto(out *MultiRaftMetricsConfig) {
+	*out = *in
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(MultiRaftServiceMonitor)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftMetricsConfig.
+func (in *MultiRaftMetricsConfig) DeepCopy() *MultiRaftMetricsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftMetricsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftPlacement) DeepCopyInto(out *MultiRaftPlacement) {
+	*out = *in
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftPlacement.
+func (in *MultiRaftPlacement) DeepCopy() *MultiRaftPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftPreferredLeader) DeepCopyInto(out *MultiRaftPreferredLeader) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftPreferredLeader.
+func (in *MultiRaftPreferredLeader) DeepCopy() *MultiRaftPreferredLeader {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftPreferredLeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MultiRaftServerConfig) DeepCopyInto(out *MultiRaftServerConfig) {
 	*out = *in
@@ -351,6 +828,11 @@ func (in *MultiRaftServerConfig) DeepCopyInto(out *MultiRaftServerConfig) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.ForwardProposals != nil {
+		in, out := &in.ForwardProposals, &out.ForwardProposals
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -364,6 +846,102 @@ func (in *MultiRaftServerConfig) DeepCopy() *MultiRaftServerConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftServiceMonitor) DeepCopyInto(out *MultiRaftServiceMonitor) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftServiceMonitor.
+func (in *MultiRaftServiceMonitor) DeepCopy() *MultiRaftServiceMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftServiceMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftTLSConfig) DeepCopyInto(out *MultiRaftTLSConfig) {
+	*out = *in
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(MultiRaftCertificateIssuer)
+		**out = **in
+	}
+	if in.Reload != nil {
+		in, out := &in.Reload, &out.Reload
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftTLSConfig.
+func (in *MultiRaftTLSConfig) DeepCopy() *MultiRaftTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftTracingConfig) DeepCopyInto(out *MultiRaftTracingConfig) {
+	*out = *in
+	if in.SamplePercent != nil {
+		in, out := &in.SamplePercent, &out.SamplePercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftTracingConfig.
+func (in *MultiRaftTracingConfig) DeepCopy() *MultiRaftTracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftTracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultiRaftUpgradeStrategy) DeepCopyInto(out *MultiRaftUpgradeStrategy) {
+	*out = *in
+	if in.MaxLag != nil {
+		in, out := &in.MaxLag, &out.MaxLag
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultiRaftUpgradeStrategy.
+func (in *MultiRaftUpgradeStrategy) DeepCopy() *MultiRaftUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(MultiRaftUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutputConfig) DeepCopyInto(out *OutputConfig) {
 	*out = *in
@@ -423,6 +1001,11 @@ func (in *RaftConfig) DeepCopyInto(out *RaftConfig) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ProposalTimeout != nil {
+		in, out := &in.ProposalTimeout, &out.ProposalTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -532,6 +1115,11 @@ func (in *RaftGroupStatus) DeepCopyInto(out *RaftGroupStatus) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastMemberID != nil {
+		in, out := &in.LastMemberID, &out.LastMemberID
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -703,6 +1291,22 @@ func (in *RaftPartitionStatus) DeepCopy() *RaftPartitionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupTarget) DeepCopyInto(out *S3BackupTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupTarget.
+func (in *S3BackupTarget) DeepCopy() *S3BackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SinkConfig) DeepCopyInto(out *SinkConfig) {
 	*out = *in
@@ -770,3 +1374,19 @@ func (in *StdoutSinkConfig) DeepCopy() *StdoutSinkConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeBackupTarget) DeepCopyInto(out *VolumeBackupTarget) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeBackupTarget.
+func (in *VolumeBackupTarget) DeepCopy() *VolumeBackupTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeBackupTarget)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"strings"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	authPath         = "/etc/atomix/auth"
+	authVolume       = "auth"
+	authSecretSuffix = "auth"
+	authTokenKey     = "token"
+	authTokenSize    = 32
+)
+
+// getAuthSecretName returns the name of the Secret containing the token for the given cluster
+func getAuthSecretName(cluster *consensusv1beta1.MultiRaftCluster) string {
+	if cluster.Spec.Config.Auth.SecretName != "" {
+		return cluster.Spec.Config.Auth.SecretName
+	}
+	return fmt.Sprintf("%s-%s", cluster.Name, authSecretSuffix)
+}
+
+// newAuthConfig returns the node authentication configuration for the given cluster
+func newAuthConfig(cluster *consensusv1beta1.MultiRaftCluster) *consensus.AuthConfig {
+	if cluster.Spec.Config.Auth == nil {
+		return nil
+	}
+	return &consensus.AuthConfig{
+		TokenFile:       path.Join(authPath, authTokenKey),
+		ServiceAccounts: cluster.Spec.Config.Auth.ServiceAccounts,
+	}
+}
+
+// reconcileAuthSecret generates the token for the given cluster if a Secret is not provided
+func (r *MultiRaftClusterReconciler) reconcileAuthSecret(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
+	if cluster.Spec.Config.Auth == nil || cluster.Spec.Config.Auth.SecretName != "" {
+		return nil
+	}
+
+	log.Info("Reconcile raft protocol auth secret")
+	secret := &corev1.Secret{}
+	name := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      getAuthSecretName(cluster),
+	}
+	err := r.client.Get(ctx, name, secret)
+	if err != nil && k8serrors.IsNotFound(err) {
+		err = r.addAuthSecret(ctx, cluster, name)
+	}
+	return err
+}
+
+func (r *MultiRaftClusterReconciler) addAuthSecret(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, name types.NamespacedName) error {
+	log.Info("Creating raft auth Secret", "Name", name.Name, "Namespace", name.Namespace)
+	token := make([]byte, authTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return err
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    cluster.Labels,
+		},
+		Data: map[string][]byte{
+			authTokenKey: []byte(hex.EncodeToString(token)),
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, secret, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(ctx, secret)
+}
+
+// getCallCredentials returns the credentials with which the controller authenticates calls to the nodes of the
+// given cluster, or nil if authentication is disabled
+func getCallCredentials(ctx context.Context, c client.Client, cluster *consensusv1beta1.MultiRaftCluster) (credentials.PerRPCCredentials, error) {
+	if cluster.Spec.Config.Auth == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      getAuthSecretName(cluster),
+	}
+	if err := c.Get(ctx, secretName, secret); err != nil {
+		return nil, err
+	}
+	token, ok := secret.Data[authTokenKey]
+	if !ok {
+		return nil, fmt.Errorf("secret %s does not contain a %s", secretName.Name, authTokenKey)
+	}
+	return bearerToken(strings.TrimSpace(string(token))), nil
+}
+
+// bearerToken is a bearer token sent with each call
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + string(t),
+	}, nil
+}
+
+// RequireTransportSecurity returns false to allow the token to be sent to clusters without TLS enabled
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
+
+var _ credentials.PerRPCCredentials = bearerToken("")
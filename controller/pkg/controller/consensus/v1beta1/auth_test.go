@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestAuthSecret(name string, token string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			authTokenKey: []byte(token),
+		},
+	}
+}
+
+func TestReconcileAuthSecret(t *testing.T) {
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Namespace = testNamespace
+	cluster.Spec.Config.Auth = &consensusv1beta1.MultiRaftAuthConfig{}
+	r := newSecretReconciler(t, cluster)
+
+	if err := r.reconcileAuthSecret(context.TODO(), cluster); err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-auth"}
+	if err := r.client.Get(context.TODO(), secretName, secret); err != nil {
+		t.Fatal(err)
+	}
+	token := string(secret.Data[authTokenKey])
+	if len(token) != authTokenSize*2 {
+		t.Fatalf("expected a generated token of %d characters, got %q", authTokenSize*2, token)
+	}
+
+	// The generated token is never replaced
+	if err := r.reconcileAuthSecret(context.TODO(), cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Get(context.TODO(), secretName, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data[authTokenKey]) != token {
+		t.Errorf("expected token %s to be retained, got %s", token, secret.Data[authTokenKey])
+	}
+
+	// No token is generated for a provided Secret
+	cluster.Spec.Config.Auth.SecretName = "provided"
+	if err := r.reconcileAuthSecret(context.TODO(), cluster); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "provided"}, secret); err == nil {
+		t.Error("expected no Secret to be generated")
+	}
+}
+
+func TestGetCallCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		auth          *consensusv1beta1.MultiRaftAuthConfig
+		authorization string
+		err           bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:          "default secret",
+			auth:          &consensusv1beta1.MultiRaftAuthConfig{},
+			authorization: "Bearer default-token",
+		},
+		{
+			name:          "provided secret",
+			auth:          &consensusv1beta1.MultiRaftAuthConfig{SecretName: "provided"},
+			authorization: "Bearer provided-token",
+		},
+		{
+			name: "missing token",
+			auth: &consensusv1beta1.MultiRaftAuthConfig{SecretName: "invalid"},
+			err:  true,
+		},
+		{
+			name: "missing secret",
+			auth: &consensusv1beta1.MultiRaftAuthConfig{SecretName: "missing"},
+			err:  true,
+		},
+	}
+	invalid := newTestAuthSecret("invalid", "")
+	delete(invalid.Data, authTokenKey)
+	r := newSecretReconciler(t,
+		newTestAuthSecret("raft-auth", "default-token"),
+		newTestAuthSecret("provided", "provided-token\n"),
+		invalid)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Namespace = testNamespace
+			cluster.Spec.Config.Auth = test.auth
+			creds, err := getCallCredentials(context.TODO(), r.client, cluster)
+			if test.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.authorization == "" {
+				if creds != nil {
+					t.Fatal("expected no credentials with authentication disabled")
+				}
+				return
+			}
+			md, err := creds.GetRequestMetadata(context.TODO())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if md["authorization"] != test.authorization {
+				t.Errorf("expected authorization %q, got %q", test.authorization, md["authorization"])
+			}
+		})
+	}
+}
+
+func TestAuthenticatedCalls(t *testing.T) {
+	t.Setenv(clusterDomainEnv, "cluster.local")
+	tokenFile := filepath.Join(t.TempDir(), authTokenKey)
+	if err := os.WriteFile(tokenFile, []byte("node-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	authenticator, err := consensus.NewAuthenticator(consensus.AuthConfig{TokenFile: tokenFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := newTestNode(t, "127.0.1.1", grpc.UnaryInterceptor(authenticator.NewUnaryInterceptor()))
+	node.setGroupStatus(consensus.GroupStatus{GroupID: 1, AppliedIndex: 10})
+
+	tests := []struct {
+		name       string
+		auth       *consensusv1beta1.MultiRaftAuthConfig
+		authorized bool
+	}{
+		{
+			name: "no token",
+		},
+		{
+			name: "invalid token",
+			auth: &consensusv1beta1.MultiRaftAuthConfig{SecretName: "invalid"},
+		},
+		{
+			name:       "valid token",
+			auth:       &consensusv1beta1.MultiRaftAuthConfig{SecretName: "valid"},
+			authorized: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Namespace = testNamespace
+			cluster.Spec.Config.Auth = test.auth
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      "raft-0",
+					Annotations: map[string]string{
+						multiRaftClusterKey: cluster.Name,
+					},
+				},
+				Status: corev1.PodStatus{
+					PodIP: "127.0.1.1",
+				},
+			}
+			member := newTestMember(pod.Name, consensusv1beta1.RaftVotingMember)
+			member.Namespace = testNamespace
+			r := newSecretReconciler(t, cluster, pod,
+				newTestAuthSecret("valid", "node-token"),
+				newTestAuthSecret("invalid", "other-token"))
+
+			status, err := r.getMemberStatus(context.TODO(), member, 1)
+			if !test.authorized {
+				if !errors.IsUnauthorized(errors.FromProto(err)) {
+					t.Fatalf("expected Unauthorized error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.AppliedIndex != 10 {
+				t.Errorf("expected applied index 10, got %d", status.AppliedIndex)
+			}
+		})
+	}
+}
@@ -0,0 +1,464 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"google.golang.org/grpc"
+	"io"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sync"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	backupRetryInterval = 10 * time.Second
+	backupMaxAttempts   = 3
+	backupTimeFormat    = "20060102T150405Z"
+	backupManifestFile  = "manifest.json"
+	snapshotChunkSize   = 1024 * 1024
+)
+
+// backupConsistencyPointInTime indicates every partition in a backup is cut at the backup's time. Each
+// partition reflects the changes proposed at or before the time and none proposed after it, so an operation
+// that completed before another began on a different partition is never reflected without the first.
+const backupConsistencyPointInTime = "PointInTime"
+
+// backupManifest describes the contents of a backup
+type backupManifest struct {
+	Cluster     string                    `json:"cluster"`
+	Time        time.Time                 `json:"time"`
+	Consistency string                    `json:"consistency"`
+	Partitions  []backupManifestPartition `json:"partitions"`
+}
+
+// backupManifestPartition describes the backup of a single partition
+type backupManifestPartition struct {
+	PartitionID int32     `json:"partitionID"`
+	Index       uint64    `json:"index"`
+	Time        time.Time `json:"time"`
+	Size        int64     `json:"size"`
+	File        string    `json:"file"`
+}
+
+func addConsensusBackupController(mgr manager.Manager) error {
+	options := controller.Options{
+		Reconciler: &ConsensusBackupReconciler{
+			client: mgr.GetClient(),
+			scheme: mgr.GetScheme(),
+			events: mgr.GetEventRecorderFor("atomix-consensus-storage"),
+		},
+		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond*10, time.Second*5),
+	}
+
+	// Create a new controller
+	controller, err := controller.New("atomix-consensus-backup-v1beta1", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the backup resource
+	err = controller.Watch(&source.Kind{Type: &consensusv1beta1.ConsensusBackup{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConsensusBackupReconciler reconciles a ConsensusBackup object
+type ConsensusBackupReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	events record.EventRecorder
+}
+
+// Reconcile takes a backup of each partition in the referenced cluster from the partition's leader, either
+// once or on the configured schedule
+func (r *ConsensusBackupReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.Info("Reconcile ConsensusBackup")
+	backup := &consensusv1beta1.ConsensusBackup{}
+	err := r.client.Get(ctx, request.NamespacedName, backup)
+	if err != nil {
+		log.Error(err, "Reconcile ConsensusBackup")
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	switch backup.Status.State {
+	case consensusv1beta1.ConsensusBackupRunning:
+		result, err := r.reconcileRunning(ctx, backup)
+		if err != nil {
+			log.Error(err, "Reconcile ConsensusBackup")
+		}
+		return result, err
+	case consensusv1beta1.ConsensusBackupComplete, consensusv1beta1.ConsensusBackupFailed:
+		if backup.Spec.Schedule == "" {
+			return reconcile.Result{}, nil
+		}
+	}
+
+	result, err := r.reconcileSchedule(ctx, backup)
+	if err != nil {
+		log.Error(err, "Reconcile ConsensusBackup")
+	}
+	return result, err
+}
+
+// reconcileSchedule starts a new backup if one is due
+func (r *ConsensusBackupReconciler) reconcileSchedule(ctx context.Context, backup *consensusv1beta1.ConsensusBackup) (reconcile.Result, error) {
+	now := time.Now()
+	var schedule *cronSchedule
+	if backup.Spec.Schedule != "" {
+		s, err := parseCronSchedule(backup.Spec.Schedule)
+		if err != nil {
+			if backup.Status.State != consensusv1beta1.ConsensusBackupFailed {
+				backup.Status.State = consensusv1beta1.ConsensusBackupFailed
+				if err := r.client.Status().Update(ctx, backup); err != nil {
+					return reconcile.Result{}, err
+				}
+				r.events.Event(backup, "Warning", "InvalidSchedule", err.Error())
+			}
+			return reconcile.Result{}, nil
+		}
+		schedule = s
+
+		if backup.Status.NextScheduleTime == nil {
+			backup.Status.NextScheduleTime = &metav1.Time{Time: schedule.Next(now)}
+			if backup.Status.State == "" {
+				backup.Status.State = consensusv1beta1.ConsensusBackupPending
+			}
+			if err := r.client.Status().Update(ctx, backup); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{}, nil
+		}
+
+		if now.Before(backup.Status.NextScheduleTime.Time) {
+			return reconcile.Result{RequeueAfter: backup.Status.NextScheduleTime.Sub(now)}, nil
+		}
+	}
+
+	cluster := &consensusv1beta1.MultiRaftCluster{}
+	clusterName := types.NamespacedName{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.Cluster.Name,
+	}
+	if err := r.client.Get(ctx, clusterName, cluster); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return r.setPending(ctx, backup)
+	}
+
+	if cluster.Status.State != consensusv1beta1.MultiRaftClusterReady || cluster.Status.Partitions == nil {
+		return r.setPending(ctx, backup)
+	}
+
+	backup.Status.State = consensusv1beta1.ConsensusBackupRunning
+	backup.Status.Location = path.Join(backup.Namespace, backup.Name, now.UTC().Format(backupTimeFormat))
+	backup.Status.Time = &metav1.Time{Time: now}
+	backup.Status.StartTime = &metav1.Time{Time: now}
+	backup.Status.CompletionTime = nil
+	if schedule != nil {
+		backup.Status.NextScheduleTime = &metav1.Time{Time: schedule.Next(now)}
+	}
+	backup.Status.Partitions = make([]consensusv1beta1.BackupPartitionStatus, 0, len(cluster.Status.Partitions))
+	for _, partition := range cluster.Status.Partitions {
+		backup.Status.Partitions = append(backup.Status.Partitions, consensusv1beta1.BackupPartitionStatus{
+			PartitionID: partition.PartitionID,
+			State:       consensusv1beta1.BackupPartitionPending,
+		})
+	}
+	if err := r.client.Status().Update(ctx, backup); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.events.Eventf(backup, "Normal", "BackupStarted", "Started backup of cluster %s to %s", cluster.Name, backup.Status.Location)
+	return reconcile.Result{}, nil
+}
+
+func (r *ConsensusBackupReconciler) setPending(ctx context.Context, backup *consensusv1beta1.ConsensusBackup) (reconcile.Result, error) {
+	if backup.Status.State == "" {
+		backup.Status.State = consensusv1beta1.ConsensusBackupPending
+		if err := r.client.Status().Update(ctx, backup); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+}
+
+// reconcileRunning backs up the pending partitions concurrently, cutting each at the backup's time, and completes
+// the backup once all partitions have been processed. Partitions that fail to back up, including partitions with
+// no leader, are retried at the same time until backupMaxAttempts attempts have failed.
+func (r *ConsensusBackupReconciler) reconcileRunning(ctx context.Context, backup *consensusv1beta1.ConsensusBackup) (reconcile.Result, error) {
+	cluster := &consensusv1beta1.MultiRaftCluster{}
+	clusterName := types.NamespacedName{
+		Namespace: backup.Namespace,
+		Name:      backup.Spec.Cluster.Name,
+	}
+	if err := r.client.Get(ctx, clusterName, cluster); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	store, err := newBackupStore(ctx, r.client, backup.Namespace, backup.Spec.Target)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	opts, err := getDialOptions(ctx, r.client, cluster, "")
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Backups started before the cut time was recorded are cut at their start time
+	var cut time.Time
+	if backup.Status.Time != nil {
+		cut = backup.Status.Time.Time
+	} else if backup.Status.StartTime != nil {
+		cut = backup.Status.StartTime.Time
+	}
+
+	type exportResult struct {
+		index consensus.Index
+		size  int64
+		err   error
+	}
+	results := make([]*exportResult, len(backup.Status.Partitions))
+	wg := &sync.WaitGroup{}
+	for i, partition := range backup.Status.Partitions {
+		if partition.State != consensusv1beta1.BackupPartitionPending {
+			continue
+		}
+		result := &exportResult{}
+		results[i] = result
+
+		leader := getPartitionLeader(cluster, partition.PartitionID)
+		if leader == "" {
+			result.err = fmt.Errorf("partition %d has no leader", partition.PartitionID)
+			continue
+		}
+
+		wg.Add(1)
+		go func(partitionID int32) {
+			defer wg.Done()
+			name := path.Join(backup.Status.Location, getPartitionSnapshotFile(partitionID))
+			result.index, result.size, result.err = exportSnapshot(ctx, leader, opts, partitionID, cut, store, name)
+		}(partition.PartitionID)
+	}
+	wg.Wait()
+
+	var pending bool
+	for i, result := range results {
+		if result == nil {
+			continue
+		}
+		partition := backup.Status.Partitions[i]
+		if result.err != nil {
+			partition.Attempts++
+			partition.Message = result.err.Error()
+			if partition.Attempts >= backupMaxAttempts {
+				partition.State = consensusv1beta1.BackupPartitionFailed
+				r.events.Eventf(backup, "Warning", "PartitionBackupFailed", "Failed to back up partition %d: %s", partition.PartitionID, result.err)
+			} else {
+				pending = true
+				r.events.Eventf(backup, "Warning", "PartitionBackupRetrying", "Failed to back up partition %d (attempt %d): %s", partition.PartitionID, partition.Attempts, result.err)
+			}
+		} else {
+			partition.State = consensusv1beta1.BackupPartitionComplete
+			partition.Index = uint64(result.index)
+			partition.Size = result.size
+			partition.Time = &metav1.Time{Time: cut}
+			partition.Message = ""
+		}
+		backup.Status.Partitions[i] = partition
+	}
+	if pending {
+		if err := r.client.Status().Update(ctx, backup); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+	}
+
+	state := consensusv1beta1.ConsensusBackupComplete
+	manifest := backupManifest{
+		Cluster:     cluster.Name,
+		Time:        cut,
+		Consistency: backupConsistencyPointInTime,
+	}
+	for _, partition := range backup.Status.Partitions {
+		if partition.State == consensusv1beta1.BackupPartitionFailed {
+			state = consensusv1beta1.ConsensusBackupFailed
+			continue
+		}
+		manifestPartition := backupManifestPartition{
+			PartitionID: partition.PartitionID,
+			Index:       partition.Index,
+			Size:        partition.Size,
+			File:        getPartitionSnapshotFile(partition.PartitionID),
+		}
+		if partition.Time != nil {
+			manifestPartition.Time = partition.Time.Time
+		}
+		manifest.Partitions = append(manifest.Partitions, manifestPartition)
+	}
+
+	if state == consensusv1beta1.ConsensusBackupComplete {
+		manifestBytes, err := json.Marshal(&manifest)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := store.Write(ctx, path.Join(backup.Status.Location, backupManifestFile), bytes.NewReader(manifestBytes), int64(len(manifestBytes))); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	backup.Status.State = state
+	backup.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	if err := r.client.Status().Update(ctx, backup); err != nil {
+		return reconcile.Result{}, err
+	}
+	if state == consensusv1beta1.ConsensusBackupComplete {
+		r.events.Eventf(backup, "Normal", "BackupComplete", "Completed backup of cluster %s to %s", cluster.Name, backup.Status.Location)
+	} else {
+		r.events.Eventf(backup, "Warning", "BackupFailed", "Backup of cluster %s to %s failed", cluster.Name, backup.Status.Location)
+	}
+	return reconcile.Result{}, nil
+}
+
+var _ reconcile.Reconciler = (*ConsensusBackupReconciler)(nil)
+
+// getPartitionLeader returns the API address of the leader of the given partition
+func getPartitionLeader(cluster *consensusv1beta1.MultiRaftCluster, partitionID int32) string {
+	for _, partition := range cluster.Status.Partitions {
+		if partition.PartitionID == partitionID && partition.Leader != nil {
+			return *partition.Leader
+		}
+	}
+	return ""
+}
+
+func getPartitionSnapshotFile(partitionID int32) string {
+	return fmt.Sprintf("partition-%d.snapshot", partitionID)
+}
+
+// exportSnapshot streams a snapshot of the given partition cut at the given time from the node at the given
+// address to the named file in the given store, returning the index and size of the snapshot
+func exportSnapshot(ctx context.Context, address string, opts []grpc.DialOption, partitionID int32, cut time.Time, store backupStore, name string) (consensus.Index, int64, error) {
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client := consensus.NewNodeClient(conn)
+	request := &consensus.ExportSnapshotRequest{
+		GroupID: consensus.GroupID(partitionID),
+		Time:    &cut,
+	}
+	stream, err := client.ExportSnapshot(ctx, request)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The first chunk carries the index and total size of the snapshot, which is required to stream it to the store
+	response, err := stream.Recv()
+	if err != nil {
+		return 0, 0, err
+	}
+	size := int64(response.TotalSize)
+	reader := &exportSnapshotReader{
+		stream: stream,
+		data:   response.Data,
+	}
+	if err := store.Write(ctx, name, reader, size); err != nil {
+		return 0, 0, err
+	}
+	return response.Index, size, nil
+}
+
+// exportSnapshotReader reads the chunks of an exported snapshot from the export stream
+type exportSnapshotReader struct {
+	stream consensus.Node_ExportSnapshotClient
+	data   []byte
+}
+
+func (r *exportSnapshotReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		response, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.data = response.Data
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+// importSnapshot streams a snapshot of the given partition read from the given reader to the node at the given
+// address, staging the snapshot to seed the given member when it's bootstrapped
+func importSnapshot(ctx context.Context, address string, opts []grpc.DialOption, partitionID int32, memberID int32, reader io.Reader) error {
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	client := consensus.NewNodeClient(conn)
+	stream, err := client.ImportSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	for sent := false; ; sent = true {
+		// Each chunk is read into a new buffer, as the message may be referenced after it's sent
+		buf := make([]byte, snapshotChunkSize)
+		n, err := io.ReadFull(reader, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+		if n == 0 && sent {
+			break
+		}
+		request := &consensus.ImportSnapshotRequest{
+			GroupID:  consensus.GroupID(partitionID),
+			MemberID: consensus.MemberID(memberID),
+			Data:     buf[:n],
+		}
+		if err := stream.Send(request); err != nil {
+			return err
+		}
+		if n < len(buf) {
+			break
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
@@ -0,0 +1,241 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	backupVolumePath      = "/var/lib/atomix/backups"
+	s3AccessKeyIDKey      = "accessKeyID"
+	s3SecretAccessKeyKey  = "secretAccessKey"
+	defaultS3Region       = "us-east-1"
+	s3Service             = "s3"
+	s3Algorithm           = "AWS4-HMAC-SHA256"
+	s3DateFormat          = "20060102"
+	s3TimeFormat          = "20060102T150405Z"
+	s3ContentSHA256Header = "X-Amz-Content-Sha256"
+	s3DateHeader          = "X-Amz-Date"
+	s3UnsignedPayload     = "UNSIGNED-PAYLOAD"
+)
+
+// backupStore reads and writes backup files. Files are streamed to and from the store, so snapshots
+// are never held in the controller's memory.
+type backupStore interface {
+	// Write writes a backup file of the given size read from the reader to the given path
+	Write(ctx context.Context, path string, reader io.Reader, size int64) error
+	// Read opens a backup file at the given path for reading
+	Read(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
+// newBackupStore returns a backupStore for the given target
+func newBackupStore(ctx context.Context, c client.Client, namespace string, target consensusv1beta1.BackupTarget) (backupStore, error) {
+	if target.Volume != nil && target.S3 != nil {
+		return nil, fmt.Errorf("only one backup target may be specified")
+	}
+	if target.Volume != nil {
+		return &volumeBackupStore{
+			root: filepath.Join(backupVolumePath, filepath.Clean("/"+target.Volume.Path)),
+		}, nil
+	}
+	if target.S3 != nil {
+		secret := &corev1.Secret{}
+		secretName := types.NamespacedName{
+			Namespace: namespace,
+			Name:      target.S3.CredentialsSecret.Name,
+		}
+		if err := c.Get(ctx, secretName, secret); err != nil {
+			return nil, err
+		}
+		accessKeyID, ok := secret.Data[s3AccessKeyIDKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %s does not contain %s", secretName, s3AccessKeyIDKey)
+		}
+		secretAccessKey, ok := secret.Data[s3SecretAccessKeyKey]
+		if !ok {
+			return nil, fmt.Errorf("secret %s does not contain %s", secretName, s3SecretAccessKeyKey)
+		}
+		region := target.S3.Region
+		if region == "" {
+			region = defaultS3Region
+		}
+		scheme := "https"
+		if target.S3.Insecure {
+			scheme = "http"
+		}
+		return &s3BackupStore{
+			client:          http.DefaultClient,
+			scheme:          scheme,
+			endpoint:        target.S3.Endpoint,
+			region:          region,
+			bucket:          target.S3.Bucket,
+			prefix:          target.S3.Prefix,
+			accessKeyID:     string(accessKeyID),
+			secretAccessKey: string(secretAccessKey),
+		}, nil
+	}
+	return nil, fmt.Errorf("no backup target specified")
+}
+
+// volumeBackupStore stores backups on the backup volume mounted in the controller
+type volumeBackupStore struct {
+	root string
+}
+
+func (s *volumeBackupStore) Write(ctx context.Context, name string, reader io.Reader, size int64) error {
+	filename := filepath.Join(s.root, filepath.Clean("/"+name))
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	tmp := filename + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(file, reader)
+	if err == nil && n != size {
+		err = fmt.Errorf("failed to write %s: expected %d bytes, wrote %d", name, size, n)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filename)
+}
+
+func (s *volumeBackupStore) Read(ctx context.Context, name string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.root, filepath.Clean("/"+name)))
+}
+
+// s3BackupStore stores backups in an S3-compatible object store using path-style requests
+// signed with AWS Signature Version 4
+type s3BackupStore struct {
+	client          *http.Client
+	scheme          string
+	endpoint        string
+	region          string
+	bucket          string
+	prefix          string
+	accessKeyID     string
+	secretAccessKey string
+}
+
+// Write uploads the file in a single unsigned-payload PUT request, streaming the body from the reader
+func (s *s3BackupStore) Write(ctx context.Context, name string, reader io.Reader, size int64) error {
+	response, err := s.do(ctx, http.MethodPut, name, reader, size, s3UnsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("failed to write %s: %s: %s", name, response.Status, string(body))
+	}
+	return nil
+}
+
+func (s *s3BackupStore) Read(ctx context.Context, name string) (io.ReadCloser, error) {
+	response, err := s.do(ctx, http.MethodGet, name, nil, 0, sha256Hex(nil))
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode/100 != 2 {
+		defer response.Body.Close()
+		body, _ := io.ReadAll(response.Body)
+		return nil, fmt.Errorf("failed to read %s: %s: %s", name, response.Status, string(body))
+	}
+	return response.Body, nil
+}
+
+func (s *s3BackupStore) do(ctx context.Context, method string, name string, body io.Reader, size int64, payloadHash string) (*http.Response, error) {
+	key := strings.TrimPrefix(path.Join(s.prefix, name), "/")
+	u := &url.URL{
+		Scheme: s.scheme,
+		Host:   s.endpoint,
+		Path:   "/" + s.bucket + "/" + key,
+	}
+	request, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		// Wrap the body to prevent the client from closing a reader owned by the caller
+		request.Body = io.NopCloser(body)
+		request.ContentLength = size
+	}
+	s.sign(request, payloadHash, time.Now().UTC())
+	return s.client.Do(request)
+}
+
+// sign adds an AWS Signature Version 4 authorization header to the request for a payload with the given hash
+func (s *s3BackupStore) sign(request *http.Request, payloadHash string, now time.Time) {
+	amzTime := now.Format(s3TimeFormat)
+	amzDate := now.Format(s3DateFormat)
+	request.Header.Set(s3ContentSHA256Header, payloadHash)
+	request.Header.Set(s3DateHeader, amzTime)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := fmt.Sprintf("host:%s\nx-amz-content-sha256:%s\nx-amz-date:%s\n", request.URL.Host, payloadHash, amzTime)
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{amzDate, s.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzTime,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), amzDate)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
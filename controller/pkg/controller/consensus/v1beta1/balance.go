@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	defaultLeaderBalancingInterval = 30 * time.Second
+	defaultLeaderBalancingMaxSkew  = 1
+	leaderBalancingRetryInterval   = 5 * time.Second
+)
+
+// groupLeadership is the leadership of a group at the time leaders are balanced
+type groupLeadership struct {
+	groupID int
+	group   *consensusv1beta1.RaftGroup
+	leader  *consensusv1beta1.RaftMember
+	members map[int]*consensusv1beta1.RaftMember
+}
+
+// reconcileLeaders balances leadership of the cluster's groups across its pods, making at most one leadership
+// transfer per reconcile. Groups with a preferred leader are moved to the preferred pod first. Other groups are
+// then moved from the pods leading the most groups to the pods leading the fewest until the difference is within
+// the configured skew. Returns true if leadership of a group was transferred.
+func (r *MultiRaftClusterReconciler) reconcileLeaders(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (bool, error) {
+	balancing := cluster.Spec.LeaderBalancing
+	if balancing == nil || cluster.Status.State != consensusv1beta1.MultiRaftClusterReady {
+		return false, nil
+	}
+
+	// Leadership is moved by the upgrade itself while an upgrade is in progress
+	if cluster.Status.Upgrade != nil && cluster.Status.Upgrade.CompletionTime == nil {
+		return false, nil
+	}
+
+	preferred := make(map[int]string)
+	for _, preferredLeader := range balancing.PreferredLeaders {
+		preferred[int(preferredLeader.Group)] = preferredLeader.Pod
+	}
+
+	// Count the groups led by each pod able to lead any group
+	counts := make(map[string]int)
+	var groups []groupLeadership
+	for groupID := 1; groupID <= getNumGroups(cluster); groupID++ {
+		groupName := types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      fmt.Sprintf("%s-%d", cluster.Name, groupID),
+		}
+		group := &consensusv1beta1.RaftGroup{}
+		if err := r.client.Get(ctx, groupName, group); err != nil {
+			return false, err
+		}
+
+		members, err := r.getMembers(ctx, group)
+		if err != nil {
+			return false, err
+		}
+
+		var leader *consensusv1beta1.RaftMember
+		for _, member := range members {
+			if _, ok := counts[member.Spec.Pod.Name]; !ok && isLeaderCandidate(member) {
+				counts[member.Spec.Pod.Name] = 0
+			}
+			if group.Status.Leader != nil && group.Status.Leader.Name == member.Name {
+				leader = member
+			}
+		}
+		if leader == nil {
+			continue
+		}
+		counts[leader.Spec.Pod.Name]++
+		groups = append(groups, groupLeadership{
+			groupID: groupID,
+			group:   group,
+			leader:  leader,
+			members: members,
+		})
+	}
+
+	for _, leadership := range groups {
+		pod, ok := preferred[leadership.groupID]
+		if !ok || leadership.leader.Spec.Pod.Name == pod {
+			continue
+		}
+		for _, member := range leadership.members {
+			if member.Spec.Pod.Name == pod && isLeaderCandidate(member) {
+				if err := r.transferLeadership(ctx, leadership, member); err != nil {
+					return false, err
+				}
+				r.events.Eventf(leadership.group, "Normal", "LeadershipTransferred", "Transferring leadership from %s to preferred leader %s", leadership.leader.Name, member.Name)
+				return true, nil
+			}
+		}
+	}
+
+	pods := make([]string, 0, len(counts))
+	for pod := range counts {
+		pods = append(pods, pod)
+	}
+	sort.Strings(pods)
+	if len(pods) < 2 {
+		return false, nil
+	}
+
+	maxCount, minCount := counts[pods[0]], counts[pods[0]]
+	for _, pod := range pods {
+		if counts[pod] > maxCount {
+			maxCount = counts[pod]
+		}
+		if counts[pod] < minCount {
+			minCount = counts[pod]
+		}
+	}
+	if maxCount-minCount <= getLeaderBalancingMaxSkew(cluster) {
+		return false, nil
+	}
+
+	// Move a group from one of the most loaded pods to the least loaded pod hosting a member of the group.
+	// Groups are not moved away from their preferred leader.
+	var source *groupLeadership
+	var target *consensusv1beta1.RaftMember
+	for i, leadership := range groups {
+		pod := leadership.leader.Spec.Pod.Name
+		if counts[pod] != maxCount || preferred[leadership.groupID] == pod {
+			continue
+		}
+		for _, memberID := range getMemberIDs(leadership.members) {
+			member := leadership.members[memberID]
+			if member.Name == leadership.leader.Name || !isLeaderCandidate(member) || counts[member.Spec.Pod.Name] >= maxCount-1 {
+				continue
+			}
+			if target == nil || counts[member.Spec.Pod.Name] < counts[target.Spec.Pod.Name] {
+				source = &groups[i]
+				target = member
+			}
+		}
+	}
+	if target == nil {
+		log.Infof("Unable to balance leaders for cluster %s: no eligible members", cluster.Name)
+		return false, nil
+	}
+
+	if err := r.transferLeadership(ctx, *source, target); err != nil {
+		return false, err
+	}
+	r.events.Eventf(source.group, "Normal", "LeadershipTransferred", "Transferring leadership from %s to %s to balance leaders", source.leader.Name, target.Name)
+	return true, nil
+}
+
+// transferLeadership transfers leadership of the given group to the given member through the current leader
+func (r *MultiRaftClusterReconciler) transferLeadership(ctx context.Context, leadership groupLeadership, target *consensusv1beta1.RaftMember) error {
+	targetID, err := getMemberID(target)
+	if err != nil {
+		return err
+	}
+
+	conn, err := r.connect(ctx, leadership.leader)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	log.Infof("Transferring leadership of group %s from %s to %s", leadership.group.Name, leadership.leader.Name, target.Name)
+	client := consensus.NewNodeClient(conn)
+	request := &consensus.TransferLeadershipRequest{
+		GroupID:        consensus.GroupID(leadership.groupID),
+		TargetMemberID: consensus.MemberID(targetID),
+	}
+	if _, err := client.TransferLeadership(ctx, request); err != nil {
+		return err
+	}
+	return nil
+}
+
+// isLeaderCandidate returns whether the given member is able to take over leadership of its group
+func isLeaderCandidate(member *consensusv1beta1.RaftMember) bool {
+	return member.Spec.Type == consensusv1beta1.RaftVotingMember && member.Status.State == consensusv1beta1.RaftMemberReady
+}
+
+// getLeaderBalancingInterval returns the interval at which leaders are balanced, or zero if leader
+// balancing is disabled for the given cluster
+func getLeaderBalancingInterval(cluster *consensusv1beta1.MultiRaftCluster) time.Duration {
+	if cluster.Spec.LeaderBalancing == nil {
+		return 0
+	}
+	if cluster.Spec.LeaderBalancing.Interval == nil {
+		return defaultLeaderBalancingInterval
+	}
+	return cluster.Spec.LeaderBalancing.Interval.Duration
+}
+
+func getLeaderBalancingMaxSkew(cluster *consensusv1beta1.MultiRaftCluster) int {
+	if cluster.Spec.LeaderBalancing == nil || cluster.Spec.LeaderBalancing.MaxSkew == nil {
+		return defaultLeaderBalancingMaxSkew
+	}
+	return int(*cluster.Spec.LeaderBalancing.MaxSkew)
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"k8s.io/utils/pointer"
+)
+
+func TestReconcileLeaders(t *testing.T) {
+	tests := []struct {
+		name      string
+		balancing *consensusv1beta1.MultiRaftLeaderBalancing
+		state     consensusv1beta1.MultiRaftClusterState
+		upgrade   *consensusv1beta1.MultiRaftClusterUpgradeStatus
+		leaders   []int
+		node      int
+		group     consensus.GroupID
+		target    consensus.MemberID
+	}{
+		{
+			name:    "disabled",
+			leaders: []int{1, 1, 1},
+		},
+		{
+			name:      "not ready",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{},
+			state:     consensusv1beta1.MultiRaftClusterNotReady,
+			leaders:   []int{1, 1, 1},
+		},
+		{
+			name:      "upgrade in progress",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{},
+			upgrade:   &consensusv1beta1.MultiRaftClusterUpgradeStatus{Revision: "2"},
+			leaders:   []int{1, 1, 1},
+		},
+		{
+			name:      "balanced",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{},
+			leaders:   []int{1, 2, 3},
+		},
+		{
+			name:      "within max skew",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{MaxSkew: pointer.Int32Ptr(2)},
+			leaders:   []int{1, 1, 2},
+		},
+		{
+			name:      "skewed",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{},
+			leaders:   []int{1, 1, 1},
+			node:      0,
+			group:     1,
+			target:    2,
+		},
+		{
+			name:      "skewed to least loaded pod",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{},
+			leaders:   []int{1, 1, 2},
+			node:      0,
+			group:     1,
+			target:    3,
+		},
+		{
+			name: "preferred leader",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{
+				PreferredLeaders: []consensusv1beta1.MultiRaftPreferredLeader{
+					{Group: 3, Pod: "raft-0"},
+				},
+			},
+			leaders: []int{1, 2, 3},
+			node:    2,
+			group:   3,
+			target:  1,
+		},
+		{
+			name: "preferred leader retained",
+			balancing: &consensusv1beta1.MultiRaftLeaderBalancing{
+				PreferredLeaders: []consensusv1beta1.MultiRaftPreferredLeader{
+					{Group: 1, Pod: "raft-0"},
+					{Group: 2, Pod: "raft-0"},
+				},
+			},
+			leaders: []int{1, 1, 1},
+			node:    0,
+			group:   3,
+			target:  2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Namespace = testNamespace
+			cluster.Spec.Groups = int32(len(test.leaders))
+			cluster.Spec.LeaderBalancing = test.balancing
+			cluster.Status.State = test.state
+			if cluster.Status.State == "" {
+				cluster.Status.State = consensusv1beta1.MultiRaftClusterReady
+			}
+			cluster.Status.Upgrade = test.upgrade
+			r, nodes := newNodeReconciler(t, cluster, len(test.leaders))
+			// Every group places its members on the pods in order, so member N of each group is on pod N-1
+			for i, memberID := range test.leaders {
+				groupName := fmt.Sprintf("%s-%d", cluster.Name, i+1)
+				setTestLeader(t, r, cluster.Namespace, groupName, fmt.Sprintf("%s-%d", groupName, memberID))
+			}
+
+			transferred, err := r.reconcileLeaders(context.TODO(), getTestCluster(t, r, cluster))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if transferred != (test.target != 0) {
+				t.Fatalf("expected transferred %t, got %t", test.target != 0, transferred)
+			}
+			for i, node := range nodes {
+				requests := node.getRequests()
+				if !transferred || i != test.node {
+					if len(requests) != 0 {
+						t.Errorf("expected no requests to node %d, got %v", i, requests)
+					}
+					continue
+				}
+				if len(requests) != 1 {
+					t.Fatalf("expected 1 request to the leader's node, got %d", len(requests))
+				}
+				request, ok := requests[0].(*consensus.TransferLeadershipRequest)
+				if !ok || request.GroupID != test.group || request.TargetMemberID != test.target {
+					t.Errorf("expected leadership of group %d to be transferred to member %d, got %v", test.group, test.target, requests[0])
+				}
+			}
+		})
+	}
+}
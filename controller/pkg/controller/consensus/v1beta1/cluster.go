@@ -6,13 +6,16 @@ package v1beta1
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"github.com/atomix/consensus-storage/node/pkg/consensus"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -22,11 +25,13 @@ import (
 	"k8s.io/utils/strings/slices"
 	"net"
 	"os"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/source"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -53,6 +58,9 @@ const (
 	raftGroupKey          = "multiraft.atomix.io/group"
 	raftPartitionKey      = "multiraft.atomix.io/partition"
 	raftMemberKey         = "multiraft.atomix.io/member"
+	configHashKey         = "multiraft.atomix.io/config-hash"
+	tlsHashKey            = "multiraft.atomix.io/tls-hash"
+	deploymentIDKey       = "multiraft.atomix.io/deployment-id"
 )
 
 const (
@@ -118,6 +126,46 @@ func addMultiRaftClusterController(mgr manager.Manager) error {
 		return err
 	}
 
+	// Watch for changes to restores to bootstrap clusters once their snapshots have been staged
+	err = controller.Watch(&source.Kind{Type: &consensusv1beta1.ConsensusRestore{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		restore := object.(*consensusv1beta1.ConsensusRestore)
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: restore.Namespace,
+					Name:      restore.Spec.Cluster.Name,
+				},
+			},
+		}
+	}))
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to certificate Secrets to roll the pods of the clusters using them
+	err = controller.Watch(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
+		clusters := &consensusv1beta1.MultiRaftClusterList{}
+		if err := mgr.GetClient().List(context.Background(), clusters, client.InNamespace(object.GetNamespace())); err != nil {
+			log.Error(err)
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cluster := range clusters.Items {
+			if cluster.Spec.Config.TLS != nil && getTLSSecretName(&cluster) == object.GetName() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: cluster.Namespace,
+						Name:      cluster.Name,
+					},
+				})
+			}
+		}
+		return requests
+	}))
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to secondary resource Pod
 	err = controller.Watch(&source.Kind{Type: &corev1.Pod{}}, handler.EnqueueRequestsFromMapFunc(func(object client.Object) []reconcile.Request {
 		clusterName, ok := object.GetAnnotations()[multiRaftClusterKey]
@@ -160,11 +208,28 @@ func (r *MultiRaftClusterReconciler) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, err
 	}
 
+	if ok, err := r.reconcileDeploymentID(ctx, cluster); err != nil {
+		log.Error(err, "Reconcile MultiRaftCluster")
+		return reconcile.Result{}, err
+	} else if ok {
+		return reconcile.Result{}, nil
+	}
+
 	if err := r.reconcileConfigMap(ctx, cluster); err != nil {
 		log.Error(err, "Reconcile MultiRaftCluster")
 		return reconcile.Result{}, err
 	}
 
+	if err := r.reconcileCertificate(ctx, cluster); err != nil {
+		log.Error(err, "Reconcile MultiRaftCluster")
+		return reconcile.Result{}, err
+	}
+
+	if err := r.reconcileAuthSecret(ctx, cluster); err != nil {
+		log.Error(err, "Reconcile MultiRaftCluster")
+		return reconcile.Result{}, err
+	}
+
 	if err := r.reconcileStatefulSet(ctx, cluster); err != nil {
 		log.Error(err, "Reconcile MultiRaftCluster")
 		return reconcile.Result{}, err
@@ -180,6 +245,11 @@ func (r *MultiRaftClusterReconciler) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, err
 	}
 
+	if err := r.reconcileServiceMonitor(ctx, cluster); err != nil {
+		log.Error(err, "Reconcile MultiRaftCluster")
+		return reconcile.Result{}, err
+	}
+
 	if ok, err := r.reconcileGroups(ctx, cluster); err != nil {
 		log.Error(err, "Reconcile MultiRaftCluster")
 		return reconcile.Result{}, err
@@ -187,13 +257,27 @@ func (r *MultiRaftClusterReconciler) Reconcile(ctx context.Context, request reco
 		return reconcile.Result{}, nil
 	}
 
+	if result, err := r.reconcileUpgrade(ctx, cluster); err != nil {
+		log.Error(err, "Reconcile MultiRaftCluster")
+		return reconcile.Result{}, err
+	} else if result.Requeue || result.RequeueAfter > 0 {
+		return result, nil
+	}
+
+	if ok, err := r.reconcileLeaders(ctx, cluster); err != nil {
+		log.Error(err, "Reconcile MultiRaftCluster")
+		return reconcile.Result{}, err
+	} else if ok {
+		return reconcile.Result{RequeueAfter: leaderBalancingRetryInterval}, nil
+	}
+
 	if ok, err := r.reconcileStatus(ctx, cluster); err != nil {
 		log.Error(err, "Reconcile MultiRaftCluster")
 		return reconcile.Result{}, err
 	} else if ok {
 		return reconcile.Result{}, nil
 	}
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: getLeaderBalancingInterval(cluster)}, nil
 }
 
 func (r *MultiRaftClusterReconciler) reconcileConfigMap(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
@@ -204,20 +288,29 @@ func (r *MultiRaftClusterReconciler) reconcileConfigMap(ctx context.Context, clu
 		Name:      cluster.Name,
 	}
 	err := r.client.Get(ctx, name, cm)
-	if err != nil && k8serrors.IsNotFound(err) {
-		err = r.addConfigMap(ctx, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addConfigMap(ctx, cluster)
+		}
+		return err
 	}
-	return err
-}
 
-func (r *MultiRaftClusterReconciler) addConfigMap(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
-	log.Info("Creating raft ConfigMap", "Name", cluster.Name, "Namespace", cluster.Namespace)
-	loggingConfig, err := yaml.Marshal(&cluster.Spec.Config.Logging)
+	data, err := newConfigMapData(cluster)
 	if err != nil {
 		return err
 	}
 
-	raftConfig, err := newNodeConfig(cluster)
+	if !reflect.DeepEqual(cm.Data, data) {
+		log.Info("Updating raft ConfigMap", "Name", cluster.Name, "Namespace", cluster.Namespace)
+		cm.Data = data
+		return r.client.Update(ctx, cm)
+	}
+	return nil
+}
+
+func (r *MultiRaftClusterReconciler) addConfigMap(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
+	log.Info("Creating raft ConfigMap", "Name", cluster.Name, "Namespace", cluster.Namespace)
+	data, err := newConfigMapData(cluster)
 	if err != nil {
 		return err
 	}
@@ -229,10 +322,7 @@ func (r *MultiRaftClusterReconciler) addConfigMap(ctx context.Context, cluster *
 			Labels:      cluster.Labels,
 			Annotations: cluster.Annotations,
 		},
-		Data: map[string]string{
-			raftConfigFile:    string(raftConfig),
-			loggingConfigFile: string(loggingConfig),
-		},
+		Data: data,
 	}
 
 	if err := controllerutil.SetControllerReference(cluster, cm, r.scheme); err != nil {
@@ -241,6 +331,33 @@ func (r *MultiRaftClusterReconciler) addConfigMap(ctx context.Context, cluster *
 	return r.client.Create(ctx, cm)
 }
 
+// newConfigMapData returns the configuration files for the nodes in the given cluster
+func newConfigMapData(cluster *consensusv1beta1.MultiRaftCluster) (map[string]string, error) {
+	loggingConfig, err := yaml.Marshal(&cluster.Spec.Config.Logging)
+	if err != nil {
+		return nil, err
+	}
+
+	raftConfig, err := newNodeConfig(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		raftConfigFile:    string(raftConfig),
+		loggingConfigFile: string(loggingConfig),
+	}, nil
+}
+
+// getConfigHash returns a hash of the given configuration files, used to restart pods when the configuration changes
+func getConfigHash(data map[string]string) string {
+	hash := sha256.New()
+	for _, file := range []string{raftConfigFile, loggingConfigFile} {
+		hash.Write([]byte(file))
+		hash.Write([]byte(data[file]))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func newNodeConfig(cluster *consensusv1beta1.MultiRaftCluster) ([]byte, error) {
 	config := consensus.Config{}
 	config.Server = consensus.ServerConfig{
@@ -248,6 +365,7 @@ func newNodeConfig(cluster *consensusv1beta1.MultiRaftCluster) ([]byte, error) {
 		WriteBufferSize:      cluster.Spec.Config.Server.WriteBufferSize,
 		NumStreamWorkers:     cluster.Spec.Config.Server.NumStreamWorkers,
 		MaxConcurrentStreams: cluster.Spec.Config.Server.MaxConcurrentStreams,
+		ForwardProposals:     cluster.Spec.Config.Server.ForwardProposals,
 	}
 	if cluster.Spec.Config.Server.MaxRecvMsgSize != nil {
 		maxRecvMsgSize := int(cluster.Spec.Config.Server.MaxRecvMsgSize.Value())
@@ -273,9 +391,103 @@ func newNodeConfig(cluster *consensusv1beta1.MultiRaftCluster) ([]byte, error) {
 		compactionRetainEntries := uint64(*cluster.Spec.Config.Raft.CompactionRetainEntries)
 		config.Raft.CompactionRetainEntries = &compactionRetainEntries
 	}
+	proposalTimeout := cluster.Spec.Config.Raft.ProposalTimeout
+	if proposalTimeout != nil {
+		config.Raft.ProposalTimeout = &proposalTimeout.Duration
+	}
+	if deploymentID, ok := cluster.Annotations[deploymentIDKey]; ok {
+		id, err := strconv.ParseUint(deploymentID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deployment ID %s: %v", deploymentID, err)
+		}
+		config.Raft.DeploymentID = &id
+	}
+	config.TLS = newTLSConfig(cluster)
+	config.Auth = newAuthConfig(cluster)
+	config.Metrics = newMetricsConfig(cluster)
+	config.Tracing = newTracingConfig(cluster)
 	return yaml.Marshal(&config)
 }
 
+// reconcileDeploymentID chooses the Raft deployment ID of the cluster and records it in the cluster's annotations,
+// ensuring all the cluster's nodes are started with the same ID. Clusters created before deployment IDs were
+// configured store their data under dragonboat's unmanaged deployment ID, so they keep that ID. Nodes with
+// different deployment IDs drop each other's messages, so restarting the pods of a running cluster with a new ID
+// would partition the restarted pods from the rest of their groups and lose quorum part way through the restart.
+// Such clusters are instead reported as not isolated in the cluster's status.
+func (r *MultiRaftClusterReconciler) reconcileDeploymentID(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (bool, error) {
+	if deploymentID, ok := cluster.Annotations[deploymentIDKey]; ok {
+		isolated := deploymentID != strconv.FormatUint(unmanagedDeploymentID, 10)
+		if cluster.Status.Isolated != nil && *cluster.Status.Isolated == isolated {
+			return false, nil
+		}
+		cluster.Status.Isolated = &isolated
+		if err := r.client.Status().Update(ctx, cluster); err != nil {
+			return false, err
+		}
+		if !isolated {
+			log.Info("Cluster is not isolated", "Name", cluster.Name, "Namespace", cluster.Namespace, "DeploymentID", deploymentID)
+			r.events.Event(cluster, "Warning", "NotIsolated",
+				"Cluster was created before deployment IDs were assigned and shares the default Raft deployment ID with other such clusters; recreate the cluster from a backup to isolate it")
+		}
+		return true, nil
+	}
+
+	deploymentID := getDeploymentID(cluster)
+	statefulSet := &appsv1.StatefulSet{}
+	statefulSetName := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name,
+	}
+	if err := r.client.Get(ctx, statefulSetName, statefulSet); err == nil {
+		deploymentID = unmanagedDeploymentID
+	} else if !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+
+	log.Info("Setting deployment ID", "Name", cluster.Name, "Namespace", cluster.Namespace, "DeploymentID", deploymentID)
+	if cluster.Annotations == nil {
+		cluster.Annotations = make(map[string]string)
+	}
+	cluster.Annotations[deploymentIDKey] = strconv.FormatUint(deploymentID, 10)
+	if err := r.client.Update(ctx, cluster); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// unmanagedDeploymentID is the deployment ID used by dragonboat when no deployment ID is configured
+const unmanagedDeploymentID = uint64(1)
+
+// getDeploymentID returns the Raft deployment ID for a new cluster, derived from the cluster's UID to prevent
+// nodes from exchanging messages with the nodes of another cluster reusing the same DNS names or volumes
+func getDeploymentID(cluster *consensusv1beta1.MultiRaftCluster) uint64 {
+	hash := sha256.Sum256([]byte(cluster.UID))
+	deploymentID := binary.BigEndian.Uint64(hash[:8])
+	// IDs 0 and 1 are reserved by dragonboat for unmanaged deployments
+	if deploymentID <= unmanagedDeploymentID {
+		deploymentID += 2
+	}
+	return deploymentID
+}
+
+// newTracingConfig returns the node tracing configuration for the given cluster
+func newTracingConfig(cluster *consensusv1beta1.MultiRaftCluster) *consensus.TracingConfig {
+	tracing := cluster.Spec.Config.Tracing
+	if tracing == nil {
+		return nil
+	}
+	config := &consensus.TracingConfig{
+		Endpoint: tracing.Endpoint,
+		Insecure: tracing.Insecure,
+	}
+	if tracing.SamplePercent != nil {
+		sampleRatio := float64(*tracing.SamplePercent) / 100
+		config.SampleRatio = &sampleRatio
+	}
+	return config
+}
+
 func (r *MultiRaftClusterReconciler) reconcileStatefulSet(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
 	log.Info("Reconcile raft protocol stateful set")
 	statefulSet := &appsv1.StatefulSet{}
@@ -284,15 +496,160 @@ func (r *MultiRaftClusterReconciler) reconcileStatefulSet(ctx context.Context, c
 		Name:      cluster.Name,
 	}
 	err := r.client.Get(ctx, name, statefulSet)
-	if err != nil && k8serrors.IsNotFound(err) {
-		err = r.addStatefulSet(ctx, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addStatefulSet(ctx, cluster)
+		}
+		return err
 	}
-	return err
+
+	if ok, err := r.reconcileStatefulSetTemplate(ctx, cluster, statefulSet); err != nil || ok {
+		return err
+	}
+
+	replicas := int32(getNumReplicas(cluster))
+	if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas == replicas {
+		return nil
+	}
+
+	// Pods can only be removed once all the members they host have been moved to the remaining pods
+	if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas > replicas {
+		members := &consensusv1beta1.RaftMemberList{}
+		if err := r.client.List(ctx, members, client.InNamespace(cluster.Namespace), client.MatchingLabels{multiRaftClusterKey: cluster.Name}); err != nil {
+			return err
+		}
+		for _, member := range members.Items {
+			ordinal, err := strconv.Atoi(strings.TrimPrefix(member.Spec.Pod.Name, cluster.Name+"-"))
+			if err == nil && int32(ordinal) >= replicas {
+				log.Infof("Waiting for member %s to be removed from pod %s", member.Name, member.Spec.Pod.Name)
+				return nil
+			}
+		}
+	}
+
+	log.Info("Scaling raft replicas", "Name", cluster.Name, "Namespace", cluster.Namespace, "Replicas", replicas)
+	statefulSet.Spec.Replicas = &replicas
+	return r.client.Update(ctx, statefulSet)
+}
+
+// reconcileStatefulSetTemplate propagates image and configuration changes to the StatefulSet. Changes to the pod
+// template are not rolled out by the StatefulSet controller: the update partition is reset to the number of
+// replicas, and pods are released for upgrade one at a time by reconcileUpgrade.
+func (r *MultiRaftClusterReconciler) reconcileStatefulSetTemplate(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, statefulSet *appsv1.StatefulSet) (bool, error) {
+	tlsHash, err := r.getTLSHash(ctx, cluster)
+	if err != nil {
+		return false, err
+	}
+	template, err := newPodTemplate(cluster, tlsHash)
+	if err != nil {
+		return false, err
+	}
+
+	var container *corev1.Container
+	for i, c := range statefulSet.Spec.Template.Spec.Containers {
+		if c.Name == nodeContainerName {
+			container = &statefulSet.Spec.Template.Spec.Containers[i]
+			break
+		}
+	}
+	if container != nil &&
+		container.Image == template.Spec.Containers[0].Image &&
+		container.ImagePullPolicy == template.Spec.Containers[0].ImagePullPolicy &&
+		statefulSet.Spec.Template.Annotations[configHashKey] == template.Annotations[configHashKey] &&
+		statefulSet.Spec.Template.Annotations[tlsHashKey] == template.Annotations[tlsHashKey] &&
+		equality.Semantic.DeepEqual(statefulSet.Spec.Template.Spec.TopologySpreadConstraints, template.Spec.TopologySpreadConstraints) {
+		return false, nil
+	}
+
+	log.Info("Updating raft replicas", "Name", cluster.Name, "Namespace", cluster.Namespace)
+	// The Raft transport cannot reload certificates, so rotating the certificate rolls the pods
+	oldHash, newHash := statefulSet.Spec.Template.Annotations[tlsHashKey], template.Annotations[tlsHashKey]
+	if oldHash != "" && newHash != "" && oldHash != newHash {
+		r.events.Event(cluster, "Normal", "CertificateRotated", "Restarting pods one at a time to load the rotated certificate into the Raft transport")
+	}
+	statefulSet.Spec.Template = template
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: statefulSet.Spec.Replicas,
+		},
+	}
+	if err := r.client.Update(ctx, statefulSet); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (r *MultiRaftClusterReconciler) addStatefulSet(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
 	log.Info("Creating raft replicas", "Name", cluster.Name, "Namespace", cluster.Namespace)
 
+	tlsHash, err := r.getTLSHash(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	template, err := newPodTemplate(cluster, tlsHash)
+	if err != nil {
+		return err
+	}
+
+	var volumeClaimTemplates []corev1.PersistentVolumeClaim
+	if cluster.Spec.VolumeClaimTemplate != nil {
+		pvc := cluster.Spec.VolumeClaimTemplate
+		if pvc.Name == "" {
+			pvc.Name = dataVolume
+		}
+		volumeClaimTemplates = append(volumeClaimTemplates, *pvc)
+	}
+
+	set := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cluster.Name,
+			Namespace:   cluster.Namespace,
+			Labels:      cluster.Labels,
+			Annotations: cluster.Annotations,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			ServiceName: getHeadlessServiceName(cluster.Name),
+			Replicas:    pointer.Int32Ptr(int32(getNumReplicas(cluster))),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: cluster.Labels,
+			},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: pointer.Int32Ptr(0),
+				},
+			},
+			PodManagementPolicy:  appsv1.ParallelPodManagement,
+			Template:             template,
+			VolumeClaimTemplates: volumeClaimTemplates,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cluster, set, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(ctx, set)
+}
+
+// newPodTemplate returns the pod template for the nodes in the given cluster. The hash of the cluster's
+// certificate is recorded in the template to roll the pods when the certificate is rotated.
+func newPodTemplate(cluster *consensusv1beta1.MultiRaftCluster, tlsHash string) (corev1.PodTemplateSpec, error) {
+	data, err := newConfigMapData(cluster)
+	if err != nil {
+		return corev1.PodTemplateSpec{}, err
+	}
+
+	annotations := make(map[string]string)
+	for key, value := range cluster.Annotations {
+		annotations[key] = value
+	}
+	annotations[multiRaftClusterKey] = cluster.Name
+	annotations[configHashKey] = getConfigHash(data)
+	if tlsHash != "" {
+		annotations[tlsHashKey] = tlsHash
+	}
+
 	image := getImage(cluster)
 	volumes := []corev1.Volume{
 		{
@@ -307,17 +664,11 @@ func (r *MultiRaftClusterReconciler) addStatefulSet(ctx context.Context, cluster
 		},
 	}
 
-	var volumeClaimTemplates []corev1.PersistentVolumeClaim
-
 	dataVolumeName := dataVolume
 	if cluster.Spec.VolumeClaimTemplate != nil {
-		pvc := cluster.Spec.VolumeClaimTemplate
-		if pvc.Name == "" {
-			pvc.Name = dataVolume
-		} else {
-			dataVolumeName = pvc.Name
+		if cluster.Spec.VolumeClaimTemplate.Name != "" {
+			dataVolumeName = cluster.Spec.VolumeClaimTemplate.Name
 		}
-		volumeClaimTemplates = append(volumeClaimTemplates, *pvc)
 	} else {
 		volumes = append(volumes, corev1.Volume{
 			Name: dataVolume,
@@ -327,113 +678,131 @@ func (r *MultiRaftClusterReconciler) addStatefulSet(ctx context.Context, cluster
 		})
 	}
 
-	set := &appsv1.StatefulSet{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        cluster.Name,
-			Namespace:   cluster.Namespace,
-			Labels:      cluster.Labels,
-			Annotations: cluster.Annotations,
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      dataVolumeName,
+			MountPath: dataPath,
 		},
-		Spec: appsv1.StatefulSetSpec{
-			ServiceName: getHeadlessServiceName(cluster.Name),
-			Replicas:    pointer.Int32Ptr(int32(getNumReplicas(cluster))),
-			Selector: &metav1.LabelSelector{
-				MatchLabels: cluster.Labels,
-			},
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		{
+			Name:      configVolume,
+			MountPath: configPath,
+		},
+	}
+
+	if cluster.Spec.Config.TLS != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: tlsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: getTLSSecretName(cluster),
+				},
 			},
-			PodManagementPolicy: appsv1.ParallelPodManagement,
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels:      cluster.Labels,
-					Annotations: cluster.Annotations,
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      tlsVolume,
+			MountPath: tlsPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if cluster.Spec.Config.Auth != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: authVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: getAuthSecretName(cluster),
 				},
-				Spec: corev1.PodSpec{
-					Containers: []corev1.Container{
-						{
-							Name:            nodeContainerName,
-							Image:           image,
-							ImagePullPolicy: cluster.Spec.ImagePullPolicy,
-							Ports: []corev1.ContainerPort{
-								{
-									Name:          "api",
-									ContainerPort: apiPort,
-								},
-								{
-									Name:          "protocol",
-									ContainerPort: protocolPort,
-								},
-							},
-							Command: []string{
-								"bash",
-								"-c",
-								fmt.Sprintf(`set -ex
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      authVolume,
+			MountPath: authPath,
+			ReadOnly:  true,
+		})
+	}
+
+	ports := []corev1.ContainerPort{
+		{
+			Name:          "api",
+			ContainerPort: apiPort,
+		},
+		{
+			Name:          "protocol",
+			ContainerPort: protocolPort,
+		},
+	}
+	if cluster.Spec.Config.Metrics != nil {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          metricsPortName,
+			ContainerPort: metricsPort,
+		})
+	}
+
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      cluster.Labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            nodeContainerName,
+					Image:           image,
+					ImagePullPolicy: cluster.Spec.ImagePullPolicy,
+					Ports:           ports,
+					Command: []string{
+						"bash",
+						"-c",
+						fmt.Sprintf(`set -ex
 [[ `+"`hostname`"+` =~ -([0-9]+)$ ]] || exit 1
 ordinal=${BASH_REMATCH[1]}
 atomix-consensus-node --config %s/%s --api-port %d --raft-host %s-$ordinal.%s.%s.svc.%s --raft-port %d`,
-									configPath, raftConfigFile, apiPort, cluster.Name, getHeadlessServiceName(cluster.Name), cluster.Namespace, getClusterDomain(), protocolPort),
-							},
-							ReadinessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									TCPSocket: &corev1.TCPSocketAction{
-										Port: intstr.IntOrString{Type: intstr.Int, IntVal: probePort},
-									},
-								},
-								InitialDelaySeconds: 5,
-								TimeoutSeconds:      10,
-								FailureThreshold:    12,
-							},
-							LivenessProbe: &corev1.Probe{
-								ProbeHandler: corev1.ProbeHandler{
-									TCPSocket: &corev1.TCPSocketAction{
-										Port: intstr.IntOrString{Type: intstr.Int, IntVal: probePort},
-									},
-								},
-								InitialDelaySeconds: 60,
-								TimeoutSeconds:      10,
+							configPath, raftConfigFile, apiPort, cluster.Name, getHeadlessServiceName(cluster.Name), cluster.Namespace, getClusterDomain(), protocolPort),
+					},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{
+								Port: intstr.IntOrString{Type: intstr.Int, IntVal: probePort},
 							},
-							SecurityContext: cluster.Spec.SecurityContext,
-							VolumeMounts: []corev1.VolumeMount{
-								{
-									Name:      dataVolumeName,
-									MountPath: dataPath,
-								},
-								{
-									Name:      configVolume,
-									MountPath: configPath,
-								},
+						},
+						InitialDelaySeconds: 5,
+						TimeoutSeconds:      10,
+						FailureThreshold:    12,
+					},
+					LivenessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							TCPSocket: &corev1.TCPSocketAction{
+								Port: intstr.IntOrString{Type: intstr.Int, IntVal: probePort},
 							},
 						},
+						InitialDelaySeconds: 60,
+						TimeoutSeconds:      10,
 					},
-					Affinity: &corev1.Affinity{
-						PodAntiAffinity: &corev1.PodAntiAffinity{
-							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
-								{
-									Weight: 1,
-									PodAffinityTerm: corev1.PodAffinityTerm{
-										LabelSelector: &metav1.LabelSelector{
-											MatchLabels: cluster.Labels,
-										},
-										Namespaces:  []string{cluster.Namespace},
-										TopologyKey: "kubernetes.io/hostname",
-									},
+					SecurityContext: cluster.Spec.SecurityContext,
+					VolumeMounts:    volumeMounts,
+				},
+			},
+			Affinity: &corev1.Affinity{
+				PodAntiAffinity: &corev1.PodAntiAffinity{
+					PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+						{
+							Weight: 1,
+							PodAffinityTerm: corev1.PodAffinityTerm{
+								LabelSelector: &metav1.LabelSelector{
+									MatchLabels: cluster.Labels,
 								},
+								Namespaces:  []string{cluster.Namespace},
+								TopologyKey: "kubernetes.io/hostname",
 							},
 						},
 					},
-					ImagePullSecrets: cluster.Spec.ImagePullSecrets,
-					Volumes:          volumes,
 				},
 			},
-			VolumeClaimTemplates: volumeClaimTemplates,
+			TopologySpreadConstraints: getTopologySpreadConstraints(cluster),
+			ImagePullSecrets:          cluster.Spec.ImagePullSecrets,
+			Volumes:                   volumes,
 		},
-	}
-
-	if err := controllerutil.SetControllerReference(cluster, set, r.scheme); err != nil {
-		return err
-	}
-	return r.client.Create(ctx, set)
+	}, nil
 }
 
 func (r *MultiRaftClusterReconciler) reconcileService(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
@@ -489,10 +858,20 @@ func (r *MultiRaftClusterReconciler) reconcileHeadlessService(ctx context.Contex
 		Name:      getHeadlessServiceName(cluster.Name),
 	}
 	err := r.client.Get(ctx, name, service)
-	if err != nil && k8serrors.IsNotFound(err) {
-		err = r.addHeadlessService(ctx, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addHeadlessService(ctx, cluster)
+		}
+		return err
 	}
-	return err
+
+	// Expose the metrics port on headless services created before metrics were enabled
+	if cluster.Spec.Config.Metrics != nil && !hasServicePort(service, metricsPortName) {
+		log.Info("Adding metrics port to headless raft service", "Name", name.Name, "Namespace", name.Namespace)
+		service.Spec.Ports = append(service.Spec.Ports, newMetricsServicePort())
+		return r.client.Update(ctx, service)
+	}
+	return nil
 }
 
 func (r *MultiRaftClusterReconciler) addHeadlessService(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
@@ -522,6 +901,10 @@ func (r *MultiRaftClusterReconciler) addHeadlessService(ctx context.Context, clu
 		},
 	}
 
+	if cluster.Spec.Config.Metrics != nil {
+		service.Spec.Ports = append(service.Spec.Ports, newMetricsServicePort())
+	}
+
 	if err := controllerutil.SetControllerReference(cluster, service, r.scheme); err != nil {
 		return err
 	}
@@ -582,6 +965,14 @@ func (r *MultiRaftClusterReconciler) reconcileGroup(ctx context.Context, cluster
 		return group, true, nil
 	}
 
+	if !reflect.DeepEqual(group.Spec.RaftConfig, cluster.Spec.Config.Raft) {
+		group.Spec.RaftConfig = cluster.Spec.Config.Raft
+		if err := r.client.Update(ctx, group); err != nil {
+			return nil, false, err
+		}
+		return group, true, nil
+	}
+
 	if ok, err := r.reconcileMembers(ctx, cluster, group, groupID); err != nil {
 		return group, false, err
 	} else if ok {
@@ -591,9 +982,45 @@ func (r *MultiRaftClusterReconciler) reconcileGroup(ctx context.Context, cluster
 }
 
 func (r *MultiRaftClusterReconciler) reconcileMembers(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, group *consensusv1beta1.RaftGroup, groupID int) (bool, error) {
+	members, err := r.getMembers(ctx, group)
+	if err != nil {
+		return false, err
+	}
+
+	// Create the initial members of the group, which are bootstrapped together
+	if group.Status.LastMemberID == nil {
+		placements, ok, err := r.getPlacements(ctx, cluster, groupID, members)
+		if err != nil || !ok {
+			return false, err
+		}
+		_, missing := matchPlacements(placements, members)
+		for memberID := 1; memberID <= getNumMembers(cluster) && len(missing) > 0; memberID++ {
+			if _, ok := members[memberID]; !ok {
+				placement := missing[0]
+				if err := r.addMember(ctx, cluster, group, memberID, placement, consensusv1beta1.RaftBootstrap); err != nil {
+					return false, err
+				}
+				return true, nil
+			}
+		}
+
+		lastMemberID := int32(getNumMembers(cluster))
+		for memberID := range members {
+			if int32(memberID) > lastMemberID {
+				lastMemberID = int32(memberID)
+			}
+		}
+		group.Status.LastMemberID = &lastMemberID
+		if err := r.client.Status().Update(ctx, group); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
 	state := consensusv1beta1.RaftGroupReady
-	for memberID := 1; memberID <= getNumMembers(cluster); memberID++ {
-		if member, ok, err := r.reconcileMember(ctx, cluster, group, groupID, memberID); err != nil {
+	for _, memberID := range getMemberIDs(members) {
+		member := members[memberID]
+		if ok, err := r.reconcileMember(ctx, cluster, group, groupID, memberID, member, members); err != nil {
 			return false, err
 		} else if ok {
 			return true, nil
@@ -609,62 +1036,374 @@ func (r *MultiRaftClusterReconciler) reconcileMembers(ctx context.Context, clust
 		}
 		return true, nil
 	}
-	return false, nil
+
+	// Membership changes are only made once all members have caught up with the group
+	if state != consensusv1beta1.RaftGroupReady {
+		return false, nil
+	}
+	return r.reconcileMembership(ctx, cluster, group, groupID, members)
 }
 
-func (r *MultiRaftClusterReconciler) reconcileMember(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, group *consensusv1beta1.RaftGroup, groupID int, memberID int) (*consensusv1beta1.RaftMember, bool, error) {
-	memberName := types.NamespacedName{
-		Namespace: group.Namespace,
-		Name:      fmt.Sprintf("%s-%d", group.Name, memberID),
+// reconcileMembership moves the members of the group toward the placement computed for the cluster,
+// making at most one membership change per reconcile. New members are added before departing members
+// are removed to ensure the group never loses its quorum.
+func (r *MultiRaftClusterReconciler) reconcileMembership(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, group *consensusv1beta1.RaftGroup, groupID int, members map[int]*consensusv1beta1.RaftMember) (bool, error) {
+	placements, ok, err := r.getPlacements(ctx, cluster, groupID, members)
+	if err != nil || !ok {
+		return false, err
 	}
-	member := &consensusv1beta1.RaftMember{}
-	if err := r.client.Get(ctx, memberName, member); err != nil {
-		if !k8serrors.IsNotFound(err) {
-			return nil, false, err
+	placed, missing := matchPlacements(placements, members)
+
+	// A pod can host only a single member of each group, so members can only be added to pods
+	// not already hosting a member of the group
+	for _, placement := range missing {
+		var existing *consensusv1beta1.RaftMember
+		for _, member := range members {
+			if member.Spec.Pod.Name == placement.pod {
+				existing = member
+				break
+			}
+		}
+		if existing == nil {
+			memberID := int(*group.Status.LastMemberID) + 1
+			lastMemberID := int32(memberID)
+			group.Status.LastMemberID = &lastMemberID
+			if err := r.client.Status().Update(ctx, group); err != nil {
+				return false, err
+			}
+			if err := r.addMember(ctx, cluster, group, memberID, placement, consensusv1beta1.RaftJoin); err != nil {
+				return false, err
+			}
+			r.events.Eventf(group, "Normal", "MemberAdded", "Added member %d on pod %s", memberID, placement.pod)
+			return true, nil
 		}
 
-		var memberType consensusv1beta1.RaftMemberType
-		if memberID <= getNumVotingMembers(cluster) {
-			memberType = consensusv1beta1.RaftVotingMember
-		} else if memberID <= getNumMembers(cluster) {
-			memberType = consensusv1beta1.RaftObserver
-		} else {
-			memberType = consensusv1beta1.RaftWitness
+		// Observers can be promoted to voting members in place
+		if existing.Spec.Type == consensusv1beta1.RaftObserver && placement.memberType == consensusv1beta1.RaftVotingMember {
+			return r.promoteMember(ctx, group, groupID, existing)
 		}
+	}
 
-		member = &consensusv1beta1.RaftMember{
-			ObjectMeta: metav1.ObjectMeta{
-				Namespace:   memberName.Namespace,
-				Name:        memberName.Name,
-				Labels:      newMemberLabels(group, memberID),
-				Annotations: newMemberAnnotations(group, memberID),
-			},
-			Spec: consensusv1beta1.RaftMemberSpec{
-				Pod: corev1.LocalObjectReference{
-					Name: getPodName(cluster, groupID, memberID),
-				},
-				Type: memberType,
+	// Every placement left is on a pod hosting a member of the wrong type, which must be removed before the
+	// pod can host its replacement. Voting members are only removed once every voting placement is filled, so
+	// the group never has fewer voting members than the placement requires.
+	votingMissing := false
+	for _, placement := range missing {
+		if placement.memberType == consensusv1beta1.RaftVotingMember {
+			votingMissing = true
+		}
+	}
+	for _, memberID := range getMemberIDs(members) {
+		if placed[memberID] {
+			continue
+		}
+		if members[memberID].Spec.Type == consensusv1beta1.RaftVotingMember && votingMissing {
+			continue
+		}
+		return r.removeMember(ctx, group, groupID, memberID, members)
+	}
+	return false, nil
+}
+
+// matchPlacements matches existing members to the given placements, returning the set of member IDs
+// that match a placement and the placements not matched by any member
+func matchPlacements(placements []memberPlacement, members map[int]*consensusv1beta1.RaftMember) (map[int]bool, []memberPlacement) {
+	memberIDs := getMemberIDs(members)
+	placed := make(map[int]bool)
+	var missing []memberPlacement
+	for _, placement := range placements {
+		found := false
+		for _, memberID := range memberIDs {
+			member := members[memberID]
+			if !placed[memberID] && member.Spec.Pod.Name == placement.pod && member.Spec.Type == placement.memberType {
+				placed[memberID] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, placement)
+		}
+	}
+	return placed, missing
+}
+
+// addMember creates a RaftMember for the given member ID on the given pod
+func (r *MultiRaftClusterReconciler) addMember(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, group *consensusv1beta1.RaftGroup, memberID int, placement memberPlacement, policy consensusv1beta1.RaftBootstrapPolicy) error {
+	member := &consensusv1beta1.RaftMember{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   group.Namespace,
+			Name:        fmt.Sprintf("%s-%d", group.Name, memberID),
+			Labels:      newMemberLabels(group, memberID),
+			Annotations: newMemberAnnotations(group, memberID),
+		},
+		Spec: consensusv1beta1.RaftMemberSpec{
+			Pod: corev1.LocalObjectReference{
+				Name: placement.pod,
 			},
+			Type:            placement.memberType,
+			BootstrapPolicy: policy,
+			Domain:          placement.domain,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, member, r.scheme); err != nil {
+		return err
+	}
+	if err := controllerutil.SetOwnerReference(group, member, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(ctx, member)
+}
+
+// promoteMember promotes the given observer to a voting member of the group
+func (r *MultiRaftClusterReconciler) promoteMember(ctx context.Context, group *consensusv1beta1.RaftGroup, groupID int, member *consensusv1beta1.RaftMember) (bool, error) {
+	memberID, err := getMemberID(member)
+	if err != nil {
+		return false, err
+	}
+
+	leader, err := r.getLeader(ctx, group)
+	if err != nil || leader == nil {
+		return false, err
+	}
+
+	conn, err := r.connect(ctx, leader)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	client := consensus.NewNodeClient(conn)
+	request := &consensus.PromoteMemberRequest{
+		GroupID:  consensus.GroupID(groupID),
+		MemberID: consensus.MemberID(memberID),
+	}
+	if _, err := client.PromoteMember(ctx, request); err != nil {
+		return false, err
+	}
+
+	member.Spec.Type = consensusv1beta1.RaftVotingMember
+	if err := r.client.Update(ctx, member); err != nil {
+		return false, err
+	}
+	r.events.Eventf(group, "Normal", "MemberPromoted", "Promoted member %d on pod %s", memberID, member.Spec.Pod.Name)
+	return true, nil
+}
+
+// removeMember removes the given member from the group, transferring leadership away from the member first
+func (r *MultiRaftClusterReconciler) removeMember(ctx context.Context, group *consensusv1beta1.RaftGroup, groupID int, memberID int, members map[int]*consensusv1beta1.RaftMember) (bool, error) {
+	member := members[memberID]
+	leader, err := r.getLeader(ctx, group)
+	if err != nil || leader == nil {
+		return false, err
+	}
+
+	conn, err := r.connect(ctx, leader)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	client := consensus.NewNodeClient(conn)
+
+	if leader.Name == member.Name {
+		for _, targetID := range getMemberIDs(members) {
+			target := members[targetID]
+			if targetID != memberID && target.Spec.Type == consensusv1beta1.RaftVotingMember {
+				request := &consensus.TransferLeadershipRequest{
+					GroupID:        consensus.GroupID(groupID),
+					TargetMemberID: consensus.MemberID(targetID),
+				}
+				if _, err := client.TransferLeadership(ctx, request); err != nil {
+					return false, err
+				}
+				r.events.Eventf(group, "Normal", "LeadershipTransferred", "Transferring leadership from member %d to member %d", memberID, targetID)
+				return true, nil
+			}
 		}
-		if err := controllerutil.SetControllerReference(cluster, member, r.scheme); err != nil {
-			return nil, false, err
+		// The last voting member cannot be removed
+		return false, nil
+	}
+
+	if err := r.deleteMember(ctx, client, group, groupID, memberID, member); err != nil {
+		return false, err
+	}
+	r.events.Eventf(group, "Normal", "MemberRemoved", "Removed member %d from pod %s", memberID, member.Spec.Pod.Name)
+	return true, nil
+}
+
+// replaceMember replaces a member that lost its Raft log with a new member on the same pod. The stale
+// replica is removed from the group, and the new member is added through a membership change and joins the group.
+func (r *MultiRaftClusterReconciler) replaceMember(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, group *consensusv1beta1.RaftGroup, groupID int, memberID int, member *consensusv1beta1.RaftMember) (bool, error) {
+	leader, err := r.getLeader(ctx, group)
+	if err != nil {
+		return false, err
+	} else if leader == nil || leader.Name == member.Name {
+		return false, fmt.Errorf("no leader found for group %s", group.Name)
+	}
+
+	conn, err := r.connect(ctx, leader)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	client := consensus.NewNodeClient(conn)
+	if err := r.deleteMember(ctx, client, group, groupID, memberID, member); err != nil {
+		return false, err
+	}
+
+	newMemberID := int(*group.Status.LastMemberID) + 1
+	lastMemberID := int32(newMemberID)
+	group.Status.LastMemberID = &lastMemberID
+	if err := r.client.Status().Update(ctx, group); err != nil {
+		return false, err
+	}
+	placement := memberPlacement{
+		pod:        member.Spec.Pod.Name,
+		memberType: member.Spec.Type,
+		domain:     member.Spec.Domain,
+	}
+	if err := r.addMember(ctx, cluster, group, newMemberID, placement, consensusv1beta1.RaftJoin); err != nil {
+		return false, err
+	}
+	r.events.Eventf(group, "Warning", "MemberReplaced", "Member %d lost its data on pod %s and was replaced by member %d", memberID, member.Spec.Pod.Name, newMemberID)
+	return true, nil
+}
+
+// deleteMember removes the given member from the group through the given leader and deletes the RaftMember
+func (r *MultiRaftClusterReconciler) deleteMember(ctx context.Context, client consensus.NodeClient, group *consensusv1beta1.RaftGroup, groupID int, memberID int, member *consensusv1beta1.RaftMember) error {
+	request := &consensus.RemoveMemberRequest{
+		GroupID:  consensus.GroupID(groupID),
+		MemberID: consensus.MemberID(memberID),
+	}
+	if _, err := client.RemoveMember(ctx, request); err != nil {
+		return err
+	}
+
+	// Stop the member on its node. The node may already be gone, so failures are ignored.
+	if memberConn, err := r.connect(ctx, member); err == nil {
+		memberClient := consensus.NewNodeClient(memberConn)
+		if _, err := memberClient.Leave(ctx, &consensus.LeaveRequest{GroupID: consensus.GroupID(groupID)}); err != nil {
+			log.Warn(err)
 		}
-		if err := controllerutil.SetOwnerReference(group, member, r.scheme); err != nil {
-			return nil, false, err
+		memberConn.Close()
+	}
+
+	followers := make([]corev1.LocalObjectReference, 0, len(group.Status.Followers))
+	for _, follower := range group.Status.Followers {
+		if follower.Name != member.Name {
+			followers = append(followers, follower)
 		}
-		if err := r.client.Create(ctx, member); err != nil {
-			return nil, false, err
+	}
+	if len(followers) != len(group.Status.Followers) {
+		group.Status.Followers = followers
+		if err := r.client.Status().Update(ctx, group); err != nil {
+			return err
+		}
+	}
+
+	if err := r.client.Delete(ctx, member); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// getMembers returns the members of the given group indexed by member ID
+func (r *MultiRaftClusterReconciler) getMembers(ctx context.Context, group *consensusv1beta1.RaftGroup) (map[int]*consensusv1beta1.RaftMember, error) {
+	return getGroupMembers(ctx, r.client, group)
+}
+
+// getGroupMembers returns the members of the given group indexed by member ID
+func getGroupMembers(ctx context.Context, c client.Client, group *consensusv1beta1.RaftGroup) (map[int]*consensusv1beta1.RaftMember, error) {
+	memberList := &consensusv1beta1.RaftMemberList{}
+	if err := c.List(ctx, memberList, client.InNamespace(group.Namespace), client.MatchingLabels{raftGroupKey: group.Name}); err != nil {
+		return nil, err
+	}
+	members := make(map[int]*consensusv1beta1.RaftMember)
+	for i := range memberList.Items {
+		member := &memberList.Items[i]
+		memberID, err := getMemberID(member)
+		if err != nil {
+			return nil, err
+		}
+		members[memberID] = member
+	}
+	return members, nil
+}
+
+// getLeader returns the current leader of the given group, or nil if the leader is not known
+func (r *MultiRaftClusterReconciler) getLeader(ctx context.Context, group *consensusv1beta1.RaftGroup) (*consensusv1beta1.RaftMember, error) {
+	if group.Status.Leader == nil {
+		return nil, nil
+	}
+	memberName := types.NamespacedName{
+		Namespace: group.Namespace,
+		Name:      group.Status.Leader.Name,
+	}
+	member := &consensusv1beta1.RaftMember{}
+	if err := r.client.Get(ctx, memberName, member); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
 		}
-		return member, true, nil
+		return nil, err
+	}
+	return member, nil
+}
+
+// connect opens a connection to the node hosting the given member
+func (r *MultiRaftClusterReconciler) connect(ctx context.Context, member *consensusv1beta1.RaftMember) (*grpc.ClientConn, error) {
+	podName := types.NamespacedName{
+		Namespace: member.Namespace,
+		Name:      member.Spec.Pod.Name,
+	}
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, podName, pod); err != nil {
+		return nil, err
+	}
+	cluster := &consensusv1beta1.MultiRaftCluster{}
+	clusterName := types.NamespacedName{
+		Namespace: pod.Namespace,
+		Name:      pod.Annotations[multiRaftClusterKey],
+	}
+	if err := r.client.Get(ctx, clusterName, cluster); err != nil {
+		return nil, err
+	}
+	opts, err := getDialOptions(ctx, r.client, cluster, getPodDNSName(cluster.Namespace, cluster.Name, pod.Name))
+	if err != nil {
+		return nil, err
+	}
+	address := fmt.Sprintf("%s:%d", pod.Status.PodIP, apiPort)
+	return grpc.DialContext(ctx, address, opts...)
+}
+
+// getDialOptions returns the options with which the controller connects to the nodes of the given cluster.
+// The server name is the DNS name of the node when connecting by IP, or empty when connecting by DNS name.
+func getDialOptions(ctx context.Context, c client.Client, cluster *consensusv1beta1.MultiRaftCluster, serverName string) ([]grpc.DialOption, error) {
+	transportCreds, err := getTransportCredentials(ctx, c, cluster, serverName)
+	if err != nil {
+		return nil, err
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transportCreds),
 	}
+	callCreds, err := getCallCredentials(ctx, c, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if callCreds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(callCreds))
+	}
+	return opts, nil
+}
 
+func (r *MultiRaftClusterReconciler) reconcileMember(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, group *consensusv1beta1.RaftGroup, groupID int, memberID int, member *consensusv1beta1.RaftMember, members map[int]*consensusv1beta1.RaftMember) (bool, error) {
 	podName := types.NamespacedName{
 		Namespace: member.Namespace,
 		Name:      member.Spec.Pod.Name,
 	}
 	pod := &corev1.Pod{}
 	if err := r.client.Get(ctx, podName, pod); err != nil {
-		return nil, false, err
+		return false, err
 	}
 
 	if member.Status.PodRef == nil || member.Status.PodRef.UID != pod.UID {
@@ -677,9 +1416,9 @@ func (r *MultiRaftClusterReconciler) reconcileMember(ctx context.Context, cluste
 		}
 		member.Status.Version = nil
 		if err := r.client.Status().Update(ctx, member); err != nil {
-			return nil, false, err
+			return false, err
 		}
-		return member, true, nil
+		return true, nil
 	}
 
 	var containerVersion int32
@@ -694,26 +1433,34 @@ func (r *MultiRaftClusterReconciler) reconcileMember(ctx context.Context, cluste
 		if member.Status.State != consensusv1beta1.RaftMemberNotReady {
 			member.Status.State = consensusv1beta1.RaftMemberNotReady
 			if err := r.client.Status().Update(ctx, member); err != nil {
-				return nil, false, err
+				return false, err
 			}
 			r.events.Eventf(member, "Normal", "StateChanged", "State changed to %s", member.Status.State)
-			return member, true, nil
+			return true, nil
 		}
 
+		opts, err := getDialOptions(ctx, r.client, cluster, getPodDNSName(cluster.Namespace, cluster.Name, pod.Name))
+		if err != nil {
+			return false, err
+		}
 		address := fmt.Sprintf("%s:%d", pod.Status.PodIP, apiPort)
-		conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		conn, err := grpc.DialContext(ctx, address, opts...)
 		if err != nil {
-			return nil, false, err
+			return false, err
 		}
 		defer conn.Close()
+		client := consensus.NewNodeClient(conn)
 
-		members := make([]consensus.MemberConfig, getNumMembers(cluster))
-		for i := 0; i < getNumMembers(cluster); i++ {
-			members[i] = consensus.MemberConfig{
-				MemberID: consensus.MemberID(i + 1),
-				Host:     getPodDNSName(cluster.Namespace, cluster.Name, getPodName(cluster, groupID, i+1)),
-				Port:     protocolPort,
-			}
+		nodeInfo, err := client.GetNodeInfo(ctx, &consensus.GetNodeInfoRequest{})
+		if err != nil {
+			return false, err
+		}
+
+		// If the member was previously started but its Raft log is no longer found on the node (e.g. the
+		// pod was restarted with an empty volume), the member cannot safely restart and must be replaced
+		if member.Status.HostID != "" && (member.Status.HostID != nodeInfo.Node.HostID || !hasMemberLog(nodeInfo.Node, groupID, memberID)) {
+			log.Warnf("Member %s lost its data on pod %s", member.Name, pod.Name)
+			return r.replaceMember(ctx, cluster, group, groupID, memberID, member)
 		}
 
 		var role consensus.MemberRole
@@ -726,26 +1473,115 @@ func (r *MultiRaftClusterReconciler) reconcileMember(ctx context.Context, cluste
 			role = consensus.MemberRole_WITNESS
 		}
 
-		client := consensus.NewNodeClient(conn)
-		request := &consensus.BootstrapRequest{
-			Group: consensus.GroupConfig{
-				GroupID:  consensus.GroupID(groupID),
-				MemberID: consensus.MemberID(memberID),
-				Role:     role,
-				Members:  members,
-			},
-		}
-		if _, err := client.Bootstrap(ctx, request); err != nil {
-			return nil, false, err
+		if member.Spec.BootstrapPolicy == consensusv1beta1.RaftJoin {
+			// The member must be added to the group through the leader before it can join
+			if member.Status.Version == nil {
+				leader, err := r.getLeader(ctx, group)
+				if err != nil {
+					return false, err
+				} else if leader == nil {
+					return false, fmt.Errorf("no leader found for group %s", group.Name)
+				}
+
+				leaderConn, err := r.connect(ctx, leader)
+				if err != nil {
+					return false, err
+				}
+				defer leaderConn.Close()
+
+				leaderClient := consensus.NewNodeClient(leaderConn)
+				request := &consensus.AddMemberRequest{
+					GroupID: consensus.GroupID(groupID),
+					Member: consensus.MemberConfig{
+						MemberID: consensus.MemberID(memberID),
+						Host:     getPodDNSName(cluster.Namespace, cluster.Name, member.Spec.Pod.Name),
+						Port:     protocolPort,
+					},
+					Role: role,
+				}
+				if _, err := leaderClient.AddMember(ctx, request); err != nil {
+					return false, err
+				}
+			}
+
+			request := &consensus.JoinRequest{
+				Group: consensus.GroupConfig{
+					GroupID:  consensus.GroupID(groupID),
+					MemberID: consensus.MemberID(memberID),
+					Role:     role,
+				},
+			}
+			if _, err := client.Join(ctx, request); err != nil {
+				return false, err
+			}
+		} else {
+			// When the cluster is restored from a backup, the initial members are not bootstrapped until the
+			// group's snapshot has been staged on all of them to seed their state
+			if member.Status.HostID == "" && cluster.Spec.Restore != nil {
+				if ok, err := r.isRestoreStaged(ctx, cluster, groupID); err != nil || !ok {
+					return false, err
+				}
+			}
+
+			// The initial members of the group are the members that were bootstrapped together
+			var peers []consensus.MemberConfig
+			for _, peerID := range getMemberIDs(members) {
+				peer := members[peerID]
+				if peer.Spec.BootstrapPolicy != consensusv1beta1.RaftJoin {
+					peers = append(peers, consensus.MemberConfig{
+						MemberID: consensus.MemberID(peerID),
+						Host:     getPodDNSName(cluster.Namespace, cluster.Name, peer.Spec.Pod.Name),
+						Port:     protocolPort,
+					})
+				}
+			}
+
+			request := &consensus.BootstrapRequest{
+				Group: consensus.GroupConfig{
+					GroupID:  consensus.GroupID(groupID),
+					MemberID: consensus.MemberID(memberID),
+					Role:     role,
+					Members:  peers,
+				},
+			}
+			if _, err := client.Bootstrap(ctx, request); err != nil {
+				return false, err
+			}
 		}
 
+		member.Status.HostID = nodeInfo.Node.HostID
 		member.Status.Version = &containerVersion
 		if err := r.client.Status().Update(ctx, member); err != nil {
-			return nil, false, err
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// isRestoreStaged returns whether the restore referenced by the cluster has staged the given group's snapshot
+// on all of the group's initial members
+func (r *MultiRaftClusterReconciler) isRestoreStaged(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, groupID int) (bool, error) {
+	restore := &consensusv1beta1.ConsensusRestore{}
+	restoreName := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Spec.Restore.Name,
+	}
+	if err := r.client.Get(ctx, restoreName, restore); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if restore.Spec.Cluster.Name != cluster.Name {
+		return false, nil
+	}
+	for _, partition := range restore.Status.Partitions {
+		if int(partition.PartitionID) == groupID {
+			return partition.State == consensusv1beta1.BackupPartitionComplete, nil
 		}
-		return member, true, nil
 	}
-	return member, false, nil
+	return false, nil
 }
 
 func (r *MultiRaftClusterReconciler) reconcileStatus(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (bool, error) {
@@ -875,6 +1711,45 @@ func getNumVotingMembers(cluster *consensusv1beta1.MultiRaftCluster) int {
 	return int(*cluster.Spec.Config.Raft.QuorumSize)
 }
 
+// getMemberType returns the type of the member at the given position in the group
+func getMemberType(cluster *consensusv1beta1.MultiRaftCluster, memberID int) consensusv1beta1.RaftMemberType {
+	if memberID <= getNumVotingMembers(cluster) {
+		return consensusv1beta1.RaftVotingMember
+	} else if memberID <= getNumMembers(cluster) {
+		return consensusv1beta1.RaftObserver
+	}
+	return consensusv1beta1.RaftWitness
+}
+
+// getMemberID returns the member ID for the given member
+func getMemberID(member *consensusv1beta1.RaftMember) (int, error) {
+	memberID, err := strconv.Atoi(member.Labels[raftMemberKey])
+	if err != nil {
+		return 0, fmt.Errorf("invalid member ID for %s: %v", member.Name, err)
+	}
+	return memberID, nil
+}
+
+// hasMemberLog returns whether the given node stores a Raft log for the given member
+func hasMemberLog(node consensus.NodeInfo, groupID int, memberID int) bool {
+	for _, logInfo := range node.Logs {
+		if logInfo.GroupID == consensus.GroupID(groupID) && logInfo.MemberID == consensus.MemberID(memberID) {
+			return true
+		}
+	}
+	return false
+}
+
+// getMemberIDs returns the sorted member IDs for the given members
+func getMemberIDs(members map[int]*consensusv1beta1.RaftMember) []int {
+	memberIDs := make([]int, 0, len(members))
+	for memberID := range members {
+		memberIDs = append(memberIDs, memberID)
+	}
+	sort.Ints(memberIDs)
+	return memberIDs
+}
+
 func getNumNonVotingMembers(cluster *consensusv1beta1.MultiRaftCluster) int {
 	if cluster.Spec.Config.Raft.ReadReplicas == nil {
 		return 0
@@ -911,6 +1786,9 @@ func getPodDNSName(namespace string, cluster string, name string) string {
 	return fmt.Sprintf("%s.%s.%s.svc.%s", name, getHeadlessServiceName(cluster), namespace, getClusterDomain())
 }
 
+// getPodName returns the pod on which the member at the given position of a new group is placed, offsetting
+// the members of each group to spread groups across the cluster's pods. Existing members keep their pods when
+// the cluster is resized, so this is only the initial placement.
 func getPodName(cluster *consensusv1beta1.MultiRaftCluster, groupID int, memberID int) string {
 	podOrdinal := ((getNumMembers(cluster) * groupID) + (memberID - 1)) % getNumReplicas(cluster)
 	return fmt.Sprintf("%s-%d", cluster.Name, podOrdinal)
@@ -0,0 +1,663 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestCluster(replicas int32, quorumSize *int32, readReplicas *int32) *consensusv1beta1.MultiRaftCluster {
+	return &consensusv1beta1.MultiRaftCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "raft",
+		},
+		Spec: consensusv1beta1.MultiRaftClusterSpec{
+			Replicas: replicas,
+			Config: consensusv1beta1.MultiRaftClusterConfig{
+				Raft: consensusv1beta1.RaftConfig{
+					QuorumSize:   quorumSize,
+					ReadReplicas: readReplicas,
+				},
+			},
+		},
+	}
+}
+
+func TestGetPodName(t *testing.T) {
+	tests := []struct {
+		name         string
+		replicas     int32
+		quorumSize   *int32
+		readReplicas *int32
+		groupID      int
+		memberID     int
+		pod          string
+	}{
+		{
+			name:     "every group on every pod",
+			replicas: 3,
+			groupID:  2,
+			memberID: 1,
+			pod:      "raft-0",
+		},
+		{
+			name:     "every group on every pod last member",
+			replicas: 3,
+			groupID:  2,
+			memberID: 3,
+			pod:      "raft-2",
+		},
+		{
+			name:       "groups offset by quorum",
+			replicas:   5,
+			quorumSize: pointer.Int32Ptr(3),
+			groupID:    1,
+			memberID:   1,
+			pod:        "raft-3",
+		},
+		{
+			name:       "groups wrap around ordinals",
+			replicas:   5,
+			quorumSize: pointer.Int32Ptr(3),
+			groupID:    1,
+			memberID:   3,
+			pod:        "raft-0",
+		},
+		{
+			name:       "larger cluster wraps fewer members",
+			replicas:   7,
+			quorumSize: pointer.Int32Ptr(3),
+			groupID:    1,
+			memberID:   3,
+			pod:        "raft-5",
+		},
+		{
+			name:       "larger cluster unwrapped member",
+			replicas:   7,
+			quorumSize: pointer.Int32Ptr(3),
+			groupID:    1,
+			memberID:   1,
+			pod:        "raft-3",
+		},
+		{
+			name:       "smaller cluster wraps more members",
+			replicas:   4,
+			quorumSize: pointer.Int32Ptr(3),
+			groupID:    1,
+			memberID:   2,
+			pod:        "raft-0",
+		},
+		{
+			name:         "observers placed after voting members",
+			replicas:     5,
+			quorumSize:   pointer.Int32Ptr(3),
+			readReplicas: pointer.Int32Ptr(1),
+			groupID:      1,
+			memberID:     4,
+			pod:          "raft-2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(test.replicas, test.quorumSize, test.readReplicas)
+			if pod := getPodName(cluster, test.groupID, test.memberID); pod != test.pod {
+				t.Errorf("expected %s, got %s", test.pod, pod)
+			}
+		})
+	}
+}
+
+func TestReconcileDeploymentID(t *testing.T) {
+	unmanaged := strconv.FormatUint(unmanagedDeploymentID, 10)
+	tests := []struct {
+		name         string
+		deploymentID string
+		isolated     *bool
+		statefulSet  bool
+		updated      bool
+		expectedID   string
+		expected     *bool
+		event        bool
+	}{
+		{
+			name:    "new cluster",
+			updated: true,
+		},
+		{
+			name:        "existing cluster",
+			statefulSet: true,
+			updated:     true,
+			expectedID:  unmanaged,
+		},
+		{
+			name:         "isolated cluster",
+			deploymentID: "12345",
+			updated:      true,
+			expectedID:   "12345",
+			expected:     pointer.BoolPtr(true),
+		},
+		{
+			name:         "unisolated cluster",
+			deploymentID: unmanaged,
+			updated:      true,
+			expectedID:   unmanaged,
+			expected:     pointer.BoolPtr(false),
+			event:        true,
+		},
+		{
+			name:         "unisolated cluster reported",
+			deploymentID: unmanaged,
+			isolated:     pointer.BoolPtr(false),
+			expectedID:   unmanaged,
+			expected:     pointer.BoolPtr(false),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := consensusv1beta1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Namespace = testNamespace
+			cluster.UID = "test-uid"
+			if test.deploymentID != "" {
+				cluster.Annotations = map[string]string{
+					deploymentIDKey: test.deploymentID,
+				}
+			}
+			cluster.Status.Isolated = test.isolated
+			objects := []client.Object{cluster}
+			if test.statefulSet {
+				objects = append(objects, &appsv1.StatefulSet{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: cluster.Namespace,
+						Name:      cluster.Name,
+					},
+				})
+			}
+			events := record.NewFakeRecorder(10)
+			r := &MultiRaftClusterReconciler{
+				client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+				scheme: scheme,
+				events: events,
+			}
+
+			updated, err := r.reconcileDeploymentID(context.TODO(), cluster)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if updated != test.updated {
+				t.Errorf("expected updated %t, got %t", test.updated, updated)
+			}
+
+			stored := &consensusv1beta1.MultiRaftCluster{}
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, stored); err != nil {
+				t.Fatal(err)
+			}
+			expectedID := test.expectedID
+			if expectedID == "" {
+				expectedID = strconv.FormatUint(getDeploymentID(cluster), 10)
+			}
+			if deploymentID := stored.Annotations[deploymentIDKey]; deploymentID != expectedID {
+				t.Errorf("expected deployment ID %s, got %s", expectedID, deploymentID)
+			}
+			if (stored.Status.Isolated == nil) != (test.expected == nil) ||
+				(test.expected != nil && *stored.Status.Isolated != *test.expected) {
+				t.Errorf("expected isolated %v, got %v", test.expected, stored.Status.Isolated)
+			}
+			if event := len(events.Events) > 0; event != test.event {
+				t.Errorf("expected event %t, got %t", test.event, event)
+			}
+		})
+	}
+}
+
+// testNode serves the Node API of a cluster's pod, reporting the configured node info and group statuses
+// and recording the requests made by the controller
+type testNode struct {
+	consensus.UnimplementedNodeServer
+	info     consensus.NodeInfo
+	groups   map[consensus.GroupID]consensus.GroupStatus
+	requests []interface{}
+	mu       sync.Mutex
+}
+
+// newTestNode starts a Node API server on the given address at the port to which the controller connects
+func newTestNode(t *testing.T, ip string, opts ...grpc.ServerOption) *testNode {
+	lis, err := net.Listen("tcp", net.JoinHostPort(ip, strconv.Itoa(apiPort)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	node := &testNode{
+		groups: make(map[consensus.GroupID]consensus.GroupStatus),
+	}
+	server := grpc.NewServer(opts...)
+	consensus.RegisterNodeServer(server, node)
+	go server.Serve(lis)
+	t.Cleanup(func() {
+		// The listener is closed directly in case the server is stopped before it starts serving
+		server.Stop()
+		lis.Close()
+	})
+	return node
+}
+
+func (n *testNode) setNodeInfo(info consensus.NodeInfo) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.info = info
+}
+
+func (n *testNode) setGroupStatus(status consensus.GroupStatus) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.groups[status.GroupID] = status
+}
+
+func (n *testNode) getRequests() []interface{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.requests
+}
+
+func (n *testNode) record(request interface{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.requests = append(n.requests, request)
+}
+
+func (n *testNode) Bootstrap(ctx context.Context, request *consensus.BootstrapRequest) (*consensus.BootstrapResponse, error) {
+	n.record(request)
+	return &consensus.BootstrapResponse{}, nil
+}
+
+func (n *testNode) Join(ctx context.Context, request *consensus.JoinRequest) (*consensus.JoinResponse, error) {
+	n.record(request)
+	return &consensus.JoinResponse{}, nil
+}
+
+func (n *testNode) Leave(ctx context.Context, request *consensus.LeaveRequest) (*consensus.LeaveResponse, error) {
+	n.record(request)
+	return &consensus.LeaveResponse{}, nil
+}
+
+func (n *testNode) AddMember(ctx context.Context, request *consensus.AddMemberRequest) (*consensus.AddMemberResponse, error) {
+	n.record(request)
+	return &consensus.AddMemberResponse{}, nil
+}
+
+func (n *testNode) RemoveMember(ctx context.Context, request *consensus.RemoveMemberRequest) (*consensus.RemoveMemberResponse, error) {
+	n.record(request)
+	return &consensus.RemoveMemberResponse{}, nil
+}
+
+func (n *testNode) TransferLeadership(ctx context.Context, request *consensus.TransferLeadershipRequest) (*consensus.TransferLeadershipResponse, error) {
+	n.record(request)
+	return &consensus.TransferLeadershipResponse{}, nil
+}
+
+func (n *testNode) GetGroupStatus(ctx context.Context, request *consensus.GetGroupStatusRequest) (*consensus.GetGroupStatusResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	group, ok := n.groups[request.GroupID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "group %d not found", request.GroupID)
+	}
+	return &consensus.GetGroupStatusResponse{Group: group}, nil
+}
+
+func (n *testNode) GetNodeInfo(ctx context.Context, request *consensus.GetNodeInfoRequest) (*consensus.GetNodeInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return &consensus.GetNodeInfoResponse{Node: n.info}, nil
+}
+
+// newNodeReconciler returns a reconciler for a running cluster with the given number of groups, each led by its
+// first member, and the nodes serving the Node API of the cluster's pods by ordinal. The pods are reachable at
+// loopback addresses in 127.0.1.0/24 to keep them apart from the clusters started by the node's tests.
+func newNodeReconciler(t *testing.T, cluster *consensusv1beta1.MultiRaftCluster, numGroups int, objects ...client.Object) (*MultiRaftClusterReconciler, []*testNode) {
+	// Avoid resolving the cluster domain when building the nodes' DNS names
+	t.Setenv(clusterDomainEnv, "cluster.local")
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := consensusv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	objects = append(objects, cluster)
+	var nodes []*testNode
+	pods := make(map[string]*corev1.Pod)
+	for ordinal := 0; ordinal < int(cluster.Spec.Replicas); ordinal++ {
+		ip := fmt.Sprintf("127.0.1.%d", ordinal+1)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      fmt.Sprintf("%s-%d", cluster.Name, ordinal),
+				Annotations: map[string]string{
+					multiRaftClusterKey: cluster.Name,
+				},
+			},
+			Status: corev1.PodStatus{
+				PodIP: ip,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+		pod.UID = types.UID(pod.Name)
+		pods[pod.Name] = pod
+		objects = append(objects, pod)
+		nodes = append(nodes, newTestNode(t, ip))
+	}
+
+	for groupID := 1; groupID <= numGroups; groupID++ {
+		group := &consensusv1beta1.RaftGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      fmt.Sprintf("%s-%d", cluster.Name, groupID),
+				Labels:    newGroupLabels(cluster, groupID),
+			},
+			Status: consensusv1beta1.RaftGroupStatus{
+				State:        consensusv1beta1.RaftGroupReady,
+				Leader:       &corev1.LocalObjectReference{Name: fmt.Sprintf("%s-%d-1", cluster.Name, groupID)},
+				LastMemberID: pointer.Int32Ptr(int32(getNumMembers(cluster))),
+			},
+		}
+		objects = append(objects, group)
+		for memberID := 1; memberID <= getNumMembers(cluster); memberID++ {
+			pod := pods[getPodName(cluster, groupID, memberID)]
+			objects = append(objects, &consensusv1beta1.RaftMember{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cluster.Namespace,
+					Name:      fmt.Sprintf("%s-%d", group.Name, memberID),
+					Labels:    newMemberLabels(group, memberID),
+				},
+				Spec: consensusv1beta1.RaftMemberSpec{
+					Pod: corev1.LocalObjectReference{
+						Name: pod.Name,
+					},
+					Type:            consensusv1beta1.RaftVotingMember,
+					BootstrapPolicy: consensusv1beta1.RaftBootstrap,
+				},
+				Status: consensusv1beta1.RaftMemberStatus{
+					PodRef: &corev1.ObjectReference{
+						Namespace: pod.Namespace,
+						Name:      pod.Name,
+						UID:       pod.UID,
+					},
+					Version: pointer.Int32Ptr(1),
+					State:   consensusv1beta1.RaftMemberReady,
+				},
+			})
+		}
+	}
+	return &MultiRaftClusterReconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		scheme: scheme,
+		events: record.NewFakeRecorder(100),
+	}, nodes
+}
+
+// setTestLeader records the given member as the leader of the given group
+func setTestLeader(t *testing.T, r *MultiRaftClusterReconciler, namespace string, groupName string, memberName string) {
+	group := &consensusv1beta1.RaftGroup{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: groupName}, group); err != nil {
+		t.Fatal(err)
+	}
+	group.Status.Leader = &corev1.LocalObjectReference{Name: memberName}
+	if err := r.client.Status().Update(context.TODO(), group); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// getTestCluster returns the stored state of the given cluster
+func getTestCluster(t *testing.T, r *MultiRaftClusterReconciler, cluster *consensusv1beta1.MultiRaftCluster) *consensusv1beta1.MultiRaftCluster {
+	stored := &consensusv1beta1.MultiRaftCluster{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, stored); err != nil {
+		t.Fatal(err)
+	}
+	return stored
+}
+
+func TestReconcileMemberHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		hostID   string
+		node     consensus.NodeInfo
+		replaced bool
+	}{
+		{
+			name: "new member",
+			node: consensus.NodeInfo{
+				HostID: "host-1",
+			},
+		},
+		{
+			name:   "restarted member",
+			hostID: "host-1",
+			node: consensus.NodeInfo{
+				HostID: "host-1",
+				Logs:   []consensus.MemberLogInfo{{GroupID: 1, MemberID: 2}},
+			},
+		},
+		{
+			name:   "new host",
+			hostID: "host-1",
+			node: consensus.NodeInfo{
+				HostID: "host-2",
+			},
+			replaced: true,
+		},
+		{
+			name:   "missing log",
+			hostID: "host-1",
+			node: consensus.NodeInfo{
+				HostID: "host-1",
+				Logs:   []consensus.MemberLogInfo{{GroupID: 2, MemberID: 2}},
+			},
+			replaced: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Namespace = testNamespace
+			cluster.UID = "test-uid"
+			r, nodes := newNodeReconciler(t, cluster, 1)
+			nodes[1].setNodeInfo(test.node)
+
+			group := &consensusv1beta1.RaftGroup{}
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-1"}, group); err != nil {
+				t.Fatal(err)
+			}
+			members, err := r.getMembers(context.TODO(), group)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The member's container was restarted and the member is being started again
+			member := members[2]
+			member.Status.State = consensusv1beta1.RaftMemberNotReady
+			member.Status.Version = nil
+			member.Status.HostID = test.hostID
+			if err := r.client.Status().Update(context.TODO(), member); err != nil {
+				t.Fatal(err)
+			}
+
+			if ok, err := r.reconcileMember(context.TODO(), cluster, group, 1, 2, member, members); err != nil {
+				t.Fatal(err)
+			} else if !ok {
+				t.Fatal("expected member to be reconciled")
+			}
+
+			stored := &consensusv1beta1.RaftMember{}
+			err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-1-2"}, stored)
+			if !test.replaced {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.Status.HostID != test.node.HostID || stored.Status.Version == nil {
+					t.Errorf("expected member to be started on host %s, got host %q", test.node.HostID, stored.Status.HostID)
+				}
+				requests := nodes[1].getRequests()
+				if len(requests) != 1 {
+					t.Fatalf("expected 1 request to the member's node, got %d", len(requests))
+				}
+				if _, ok := requests[0].(*consensus.BootstrapRequest); !ok {
+					t.Errorf("expected member to be bootstrapped, got %v", requests[0])
+				}
+				if requests := nodes[0].getRequests(); len(requests) != 0 {
+					t.Errorf("expected no requests to the leader, got %v", requests)
+				}
+				return
+			}
+
+			// The stale member is removed from the group and replaced by a new member on the same pod
+			if !k8serrors.IsNotFound(err) {
+				t.Fatalf("expected member to be deleted, got %v", err)
+			}
+			requests := nodes[0].getRequests()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 request to the leader, got %d", len(requests))
+			}
+			if request, ok := requests[0].(*consensus.RemoveMemberRequest); !ok || request.GroupID != 1 || request.MemberID != 2 {
+				t.Fatalf("expected member 2 to be removed through the leader, got %v", requests[0])
+			}
+			requests = nodes[1].getRequests()
+			if len(requests) != 1 {
+				t.Fatalf("expected 1 request to the member's node, got %d", len(requests))
+			}
+			if request, ok := requests[0].(*consensus.LeaveRequest); !ok || request.GroupID != 1 {
+				t.Fatalf("expected member to leave the group, got %v", requests[0])
+			}
+
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-1"}, group); err != nil {
+				t.Fatal(err)
+			}
+			if *group.Status.LastMemberID != 4 {
+				t.Fatalf("expected last member ID 4, got %d", *group.Status.LastMemberID)
+			}
+			replacement := &consensusv1beta1.RaftMember{}
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-1-4"}, replacement); err != nil {
+				t.Fatal(err)
+			}
+			if replacement.Spec.Pod.Name != "raft-1" || replacement.Spec.BootstrapPolicy != consensusv1beta1.RaftJoin {
+				t.Fatalf("expected member 4 to join from pod raft-1, got %s on %s", replacement.Spec.BootstrapPolicy, replacement.Spec.Pod.Name)
+			}
+
+			// The new member is added to the group through the leader before it joins, once its pod has been
+			// recorded and it has been marked not ready
+			members, err = r.getMembers(context.TODO(), group)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 3; i++ {
+				if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-1-4"}, replacement); err != nil {
+					t.Fatal(err)
+				}
+				if ok, err := r.reconcileMember(context.TODO(), cluster, group, 1, 4, replacement, members); err != nil {
+					t.Fatal(err)
+				} else if !ok {
+					t.Fatal("expected replacement member to be reconciled")
+				}
+			}
+			requests = nodes[0].getRequests()
+			if len(requests) != 2 {
+				t.Fatalf("expected 2 requests to the leader, got %d", len(requests))
+			}
+			if request, ok := requests[1].(*consensus.AddMemberRequest); !ok || request.GroupID != 1 || request.Member.MemberID != 4 || request.Member.Port != protocolPort {
+				t.Fatalf("expected member 4 to be added through the leader, got %v", requests[1])
+			}
+			requests = nodes[1].getRequests()
+			if len(requests) != 2 {
+				t.Fatalf("expected 2 requests to the member's node, got %d", len(requests))
+			}
+			if request, ok := requests[1].(*consensus.JoinRequest); !ok || request.Group.GroupID != 1 || request.Group.MemberID != 4 {
+				t.Fatalf("expected member 4 to join the group, got %v", requests[1])
+			}
+		})
+	}
+}
+
+func TestTracingConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		tracing     *consensusv1beta1.MultiRaftTracingConfig
+		sampleRatio *float64
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name: "default sample ratio",
+			tracing: &consensusv1beta1.MultiRaftTracingConfig{
+				Endpoint: "otel-collector:4317",
+				Insecure: true,
+			},
+		},
+		{
+			name: "sample percent",
+			tracing: &consensusv1beta1.MultiRaftTracingConfig{
+				Endpoint:      "otel-collector:4317",
+				SamplePercent: pointer.Int32Ptr(25),
+			},
+			sampleRatio: pointer.Float64Ptr(.25),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Spec.Config.Tracing = test.tracing
+			config := newTestNodeConfig(t, cluster).Tracing
+			if test.tracing == nil {
+				if config != nil {
+					t.Fatalf("expected tracing to be disabled, got %v", config)
+				}
+				return
+			}
+			if config == nil {
+				t.Fatal("expected tracing to be enabled")
+			}
+			if config.Endpoint != test.tracing.Endpoint || config.Insecure != test.tracing.Insecure {
+				t.Errorf("expected traces to be exported to %s, got %s", test.tracing.Endpoint, config.Endpoint)
+			}
+			if (config.SampleRatio == nil) != (test.sampleRatio == nil) ||
+				(test.sampleRatio != nil && *config.SampleRatio != *test.sampleRatio) {
+				t.Errorf("expected sample ratio %v, got %v", test.sampleRatio, config.SampleRatio)
+			}
+		})
+	}
+}
@@ -22,5 +22,11 @@ func AddControllers(mgr manager.Manager) error {
 	if err := addPodController(mgr); err != nil {
 		return err
 	}
+	if err := addConsensusBackupController(mgr); err != nil {
+		return err
+	}
+	if err := addConsensusRestoreController(mgr); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	metricsPort     = 5680
+	metricsPortName = "metrics"
+	metricsPath     = "/metrics"
+)
+
+var serviceMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "ServiceMonitor",
+}
+
+// newMetricsConfig returns the node metrics configuration for the given cluster
+func newMetricsConfig(cluster *consensusv1beta1.MultiRaftCluster) *consensus.MetricsConfig {
+	if cluster.Spec.Config.Metrics == nil {
+		return nil
+	}
+	port := metricsPort
+	return &consensus.MetricsConfig{
+		Port: &port,
+	}
+}
+
+// newMetricsServicePort returns the service port exposing the nodes' metrics endpoint
+func newMetricsServicePort() corev1.ServicePort {
+	return corev1.ServicePort{
+		Name: metricsPortName,
+		Port: metricsPort,
+	}
+}
+
+// hasServicePort returns whether the given service exposes a port with the given name
+func hasServicePort(service *corev1.Service, name string) bool {
+	for _, port := range service.Spec.Ports {
+		if port.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MultiRaftClusterReconciler) reconcileServiceMonitor(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
+	if cluster.Spec.Config.Metrics == nil || cluster.Spec.Config.Metrics.ServiceMonitor == nil {
+		return nil
+	}
+
+	log.Info("Reconcile raft protocol service monitor")
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(serviceMonitorGVK)
+	name := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name,
+	}
+	err := r.client.Get(ctx, name, serviceMonitor)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addServiceMonitor(ctx, cluster, name)
+		}
+		return err
+	}
+
+	spec, _, err := unstructured.NestedMap(serviceMonitor.Object, "spec")
+	if err != nil {
+		return err
+	}
+
+	labels := newServiceMonitorLabels(cluster)
+	var updated bool
+	if !equality.Semantic.DeepEqual(serviceMonitor.GetLabels(), labels) {
+		serviceMonitor.SetLabels(labels)
+		updated = true
+	}
+	for key, value := range newServiceMonitorSpec(cluster) {
+		if !equality.Semantic.DeepEqual(spec[key], value) {
+			spec[key] = value
+			updated = true
+		}
+	}
+	if updated {
+		log.Info("Updating raft ServiceMonitor", "Name", name.Name, "Namespace", name.Namespace)
+		if err := unstructured.SetNestedMap(serviceMonitor.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return r.client.Update(ctx, serviceMonitor)
+	}
+	return nil
+}
+
+func (r *MultiRaftClusterReconciler) addServiceMonitor(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, name types.NamespacedName) error {
+	log.Info("Creating raft ServiceMonitor", "Name", name.Name, "Namespace", name.Namespace)
+	serviceMonitor := &unstructured.Unstructured{}
+	serviceMonitor.SetGroupVersionKind(serviceMonitorGVK)
+	serviceMonitor.SetNamespace(name.Namespace)
+	serviceMonitor.SetName(name.Name)
+	serviceMonitor.SetLabels(newServiceMonitorLabels(cluster))
+	if err := unstructured.SetNestedMap(serviceMonitor.Object, newServiceMonitorSpec(cluster), "spec"); err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(cluster, serviceMonitor, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(ctx, serviceMonitor)
+}
+
+// newServiceMonitorLabels returns the labels of the ServiceMonitor for the given cluster
+func newServiceMonitorLabels(cluster *consensusv1beta1.MultiRaftCluster) map[string]string {
+	labels := make(map[string]string)
+	for key, value := range cluster.Labels {
+		labels[key] = value
+	}
+	for key, value := range cluster.Spec.Config.Metrics.ServiceMonitor.Labels {
+		labels[key] = value
+	}
+	return labels
+}
+
+// newServiceMonitorSpec returns the spec of the Prometheus Operator ServiceMonitor for the given cluster.
+// The metrics port is only exposed by the headless service, so each pod is scraped once although the
+// selector matches both of the cluster's services.
+func newServiceMonitorSpec(cluster *consensusv1beta1.MultiRaftCluster) map[string]interface{} {
+	matchLabels := make(map[string]interface{})
+	for key, value := range cluster.Labels {
+		matchLabels[key] = value
+	}
+	endpoint := map[string]interface{}{
+		"port": metricsPortName,
+		"path": metricsPath,
+	}
+	if interval := cluster.Spec.Config.Metrics.ServiceMonitor.Interval; interval != nil {
+		endpoint["interval"] = interval.Duration.String()
+	}
+	return map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": matchLabels,
+		},
+		"endpoints": []interface{}{
+			endpoint,
+		},
+	}
+}
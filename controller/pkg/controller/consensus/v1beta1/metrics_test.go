@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newTestNodeConfig returns the node configuration generated for the given cluster
+func newTestNodeConfig(t *testing.T, cluster *consensusv1beta1.MultiRaftCluster) consensus.Config {
+	data, err := newNodeConfig(cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config consensus.Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+func TestMetricsConfig(t *testing.T) {
+	t.Setenv(clusterDomainEnv, "cluster.local")
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Namespace = testNamespace
+	if config := newTestNodeConfig(t, cluster); config.Metrics != nil {
+		t.Errorf("expected metrics to be disabled, got %v", config.Metrics)
+	}
+	template, err := newPodTemplate(cluster, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, port := range template.Spec.Containers[0].Ports {
+		if port.Name == metricsPortName {
+			t.Error("expected no metrics port with metrics disabled")
+		}
+	}
+
+	cluster.Spec.Config.Metrics = &consensusv1beta1.MultiRaftMetricsConfig{}
+	config := newTestNodeConfig(t, cluster)
+	if config.Metrics == nil || config.Metrics.GetPort() != metricsPort {
+		t.Fatalf("expected metrics to be served on port %d, got %v", metricsPort, config.Metrics)
+	}
+	template, err = newPodTemplate(cluster, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exposed bool
+	for _, port := range template.Spec.Containers[0].Ports {
+		if port.Name == metricsPortName && port.ContainerPort == metricsPort {
+			exposed = true
+		}
+	}
+	if !exposed {
+		t.Error("expected the node container to expose the metrics port")
+	}
+}
+
+func TestReconcileHeadlessServiceMetrics(t *testing.T) {
+	t.Setenv(clusterDomainEnv, "cluster.local")
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Namespace = testNamespace
+	r := newSecretReconciler(t, cluster)
+	if err := r.reconcileHeadlessService(context.TODO(), getTestCluster(t, r, cluster)); err != nil {
+		t.Fatal(err)
+	}
+	service := &corev1.Service{}
+	serviceName := types.NamespacedName{Namespace: cluster.Namespace, Name: getHeadlessServiceName(cluster.Name)}
+	if err := r.client.Get(context.TODO(), serviceName, service); err != nil {
+		t.Fatal(err)
+	}
+	if hasServicePort(service, metricsPortName) {
+		t.Fatal("expected no metrics port with metrics disabled")
+	}
+
+	// The metrics port is added to the services of clusters created before metrics were enabled
+	cluster.Spec.Config.Metrics = &consensusv1beta1.MultiRaftMetricsConfig{}
+	for i := 0; i < 2; i++ {
+		if err := r.reconcileHeadlessService(context.TODO(), cluster); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.client.Get(context.TODO(), serviceName, service); err != nil {
+		t.Fatal(err)
+	}
+	var numPorts int
+	for _, port := range service.Spec.Ports {
+		if port.Name == metricsPortName {
+			numPorts++
+		}
+	}
+	if numPorts != 1 {
+		t.Errorf("expected 1 metrics port, got %d", numPorts)
+	}
+}
+
+func TestNewServiceMonitorSpec(t *testing.T) {
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Labels = map[string]string{
+		"app": "raft",
+	}
+	cluster.Spec.Config.Metrics = &consensusv1beta1.MultiRaftMetricsConfig{
+		ServiceMonitor: &consensusv1beta1.MultiRaftServiceMonitor{
+			Labels: map[string]string{
+				"release": "prometheus",
+			},
+		},
+	}
+
+	labels := newServiceMonitorLabels(cluster)
+	if labels["app"] != "raft" || labels["release"] != "prometheus" {
+		t.Errorf("expected cluster and configured labels, got %v", labels)
+	}
+
+	spec := newServiceMonitorSpec(cluster)
+	selector := spec["selector"].(map[string]interface{})["matchLabels"].(map[string]interface{})
+	if len(selector) != 1 || selector["app"] != "raft" {
+		t.Errorf("expected selector to match the cluster's labels, got %v", selector)
+	}
+	endpoint := spec["endpoints"].([]interface{})[0].(map[string]interface{})
+	if endpoint["port"] != metricsPortName || endpoint["path"] != metricsPath {
+		t.Errorf("expected endpoint to scrape the metrics port, got %v", endpoint)
+	}
+	if _, ok := endpoint["interval"]; ok {
+		t.Errorf("expected Prometheus' default interval, got %v", endpoint["interval"])
+	}
+
+	cluster.Spec.Config.Metrics.ServiceMonitor.Interval = &metav1.Duration{Duration: 15 * time.Second}
+	endpoint = newServiceMonitorSpec(cluster)["endpoints"].([]interface{})[0].(map[string]interface{})
+	if endpoint["interval"] != "15s" {
+		t.Errorf("expected interval 15s, got %v", endpoint["interval"])
+	}
+}
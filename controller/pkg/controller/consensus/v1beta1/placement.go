@@ -0,0 +1,294 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// memberPlacement is the placement of a member of a group on a pod
+type memberPlacement struct {
+	pod        string
+	memberType consensusv1beta1.RaftMemberType
+	domain     string
+}
+
+// getPlacements returns the desired placement of the members of the given group, voting members first.
+// If the cluster does not specify a topology key, members are placed on the StatefulSet ordinals by
+// getOrdinalPlacements. Otherwise, voting members are spread across the topology domains of the nodes hosting
+// the cluster's pods, retaining the placement of existing members wherever the spread allows. Returns false if
+// the placement cannot be computed until all the cluster's pods have been scheduled.
+func (r *MultiRaftClusterReconciler) getPlacements(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, groupID int, members map[int]*consensusv1beta1.RaftMember) ([]memberPlacement, bool, error) {
+	if cluster.Spec.Placement.TopologyKey == "" {
+		return getOrdinalPlacements(cluster, groupID, members), true, nil
+	}
+
+	pods, domains, ok, err := r.getPodDomains(ctx, cluster)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	load, err := r.getPodLoad(ctx, cluster)
+	if err != nil {
+		return nil, false, err
+	}
+
+	numDomains := 0
+	domainSet := make(map[string]bool)
+	for _, domain := range domains {
+		if !domainSet[domain] {
+			domainSet[domain] = true
+			numDomains++
+		}
+	}
+
+	// Losing a domain preserves quorum as long as no domain hosts more than (n-1)/2 voting members. If there
+	// are too few domains for that, voting members are spread as evenly as possible.
+	numVoting := getNumVotingMembers(cluster)
+	maxPerDomain := (numVoting - 1) / 2
+	if even := (numVoting + numDomains - 1) / numDomains; even > maxPerDomain {
+		maxPerDomain = even
+	}
+
+	occupied := make(map[string]bool)
+	for _, member := range members {
+		occupied[member.Spec.Pod.Name] = true
+	}
+
+	used := make(map[string]bool)
+	counts := make(map[string]int)
+	placements := make([]memberPlacement, 0, getNumMembers(cluster))
+	place := func(pod string, memberType consensusv1beta1.RaftMemberType) {
+		used[pod] = true
+		if memberType == consensusv1beta1.RaftVotingMember {
+			counts[domains[pod]]++
+		}
+		placements = append(placements, memberPlacement{
+			pod:        pod,
+			memberType: memberType,
+			domain:     domains[pod],
+		})
+	}
+
+	// Retain existing voting members within the spread, then place the remaining voting members in the
+	// least populated domains
+	for _, memberID := range getMemberIDs(members) {
+		member := members[memberID]
+		domain, ok := domains[member.Spec.Pod.Name]
+		if ok && member.Spec.Type == consensusv1beta1.RaftVotingMember && !used[member.Spec.Pod.Name] &&
+			len(placements) < numVoting && counts[domain] < maxPerDomain {
+			place(member.Spec.Pod.Name, member.Spec.Type)
+		}
+	}
+	for len(placements) < numVoting {
+		pod := pickPod(pods, domains, used, occupied, counts, load, true)
+		if pod == "" {
+			break
+		}
+		place(pod, consensusv1beta1.RaftVotingMember)
+	}
+
+	// Observers do not count toward quorum, so they're placed by load alone
+	numPlaced := len(placements)
+	for _, memberID := range getMemberIDs(members) {
+		member := members[memberID]
+		if _, ok := domains[member.Spec.Pod.Name]; ok && member.Spec.Type == consensusv1beta1.RaftObserver &&
+			!used[member.Spec.Pod.Name] && len(placements)-numPlaced < getNumNonVotingMembers(cluster) {
+			place(member.Spec.Pod.Name, member.Spec.Type)
+		}
+	}
+	for len(placements)-numPlaced < getNumNonVotingMembers(cluster) {
+		pod := pickPod(pods, domains, used, occupied, counts, load, false)
+		if pod == "" {
+			break
+		}
+		place(pod, consensusv1beta1.RaftObserver)
+	}
+	return placements, true, nil
+}
+
+// getOrdinalPlacements places the members of the given group on the StatefulSet ordinals. Existing members retain
+// their pods as long as the pods remain in the StatefulSet, so resizing the cluster only moves the members hosted
+// by removed ordinals. Each remaining member is placed on the pod chosen for its position by getPodName, or the
+// next ordinal not hosting a member of the group if that pod is taken.
+func getOrdinalPlacements(cluster *consensusv1beta1.MultiRaftCluster, groupID int, members map[int]*consensusv1beta1.RaftMember) []memberPlacement {
+	numReplicas := getNumReplicas(cluster)
+	occupied := make(map[int]bool)
+	for _, member := range members {
+		if ordinal, ok := getPodOrdinal(cluster, member.Spec.Pod.Name); ok {
+			occupied[ordinal] = true
+		}
+	}
+
+	used := make(map[int]bool)
+	placements := make([]memberPlacement, 0, getNumMembers(cluster))
+	place := func(memberType consensusv1beta1.RaftMemberType, count int, position int) {
+		numPlaced := 0
+		for _, memberID := range getMemberIDs(members) {
+			member := members[memberID]
+			ordinal, ok := getPodOrdinal(cluster, member.Spec.Pod.Name)
+			if ok && ordinal < numReplicas && member.Spec.Type == memberType && !used[ordinal] && numPlaced < count {
+				used[ordinal] = true
+				placements = append(placements, memberPlacement{
+					pod:        member.Spec.Pod.Name,
+					memberType: memberType,
+				})
+				numPlaced++
+			}
+		}
+
+		// New members prefer pods not hosting a member of the group, which would otherwise have to be removed first
+		for ; numPlaced < count; position++ {
+			start, _ := getPodOrdinal(cluster, getPodName(cluster, groupID, position))
+			ordinal := -1
+			for i := 0; i < numReplicas && ordinal == -1; i++ {
+				if next := (start + i) % numReplicas; !used[next] && !occupied[next] {
+					ordinal = next
+				}
+			}
+			for i := 0; i < numReplicas && ordinal == -1; i++ {
+				if next := (start + i) % numReplicas; !used[next] {
+					ordinal = next
+				}
+			}
+			if ordinal == -1 {
+				return
+			}
+			used[ordinal] = true
+			placements = append(placements, memberPlacement{
+				pod:        fmt.Sprintf("%s-%d", cluster.Name, ordinal),
+				memberType: memberType,
+			})
+			numPlaced++
+		}
+	}
+	place(consensusv1beta1.RaftVotingMember, getNumVotingMembers(cluster), 1)
+	place(consensusv1beta1.RaftObserver, getNumNonVotingMembers(cluster), getNumVotingMembers(cluster)+1)
+	return placements
+}
+
+// getPodOrdinal returns the StatefulSet ordinal of the given pod of the cluster
+func getPodOrdinal(cluster *consensusv1beta1.MultiRaftCluster, pod string) (int, bool) {
+	suffix := strings.TrimPrefix(pod, cluster.Name+"-")
+	if suffix == pod {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return ordinal, true
+}
+
+// pickPod selects an unused pod for a new member, preferring pods not hosting a member of the group, then
+// (if spreading) the least populated domain, then the pod hosting the fewest members across all groups
+func pickPod(pods []string, domains map[string]string, used, occupied map[string]bool, counts map[string]int, load map[string]int, spread bool) string {
+	var best string
+	for _, pod := range pods {
+		if used[pod] {
+			continue
+		}
+		if best == "" {
+			best = pod
+			continue
+		}
+		if occupied[pod] != occupied[best] {
+			if !occupied[pod] {
+				best = pod
+			}
+			continue
+		}
+		if spread && counts[domains[pod]] != counts[domains[best]] {
+			if counts[domains[pod]] < counts[domains[best]] {
+				best = pod
+			}
+			continue
+		}
+		if load[pod] < load[best] {
+			best = pod
+		}
+	}
+	return best
+}
+
+// getPodDomains returns the cluster's pods in ordinal order with the topology domain of the node hosting each
+// pod. Returns false if any pod has not yet been scheduled.
+func (r *MultiRaftClusterReconciler) getPodDomains(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) ([]string, map[string]string, bool, error) {
+	pods := make([]string, 0, getNumReplicas(cluster))
+	domains := make(map[string]string)
+	for ordinal := 0; ordinal < getNumReplicas(cluster); ordinal++ {
+		podName := types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      fmt.Sprintf("%s-%d", cluster.Name, ordinal),
+		}
+		pod := &corev1.Pod{}
+		if err := r.client.Get(ctx, podName, pod); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, nil, false, nil
+			}
+			return nil, nil, false, err
+		}
+		if pod.Spec.NodeName == "" {
+			return nil, nil, false, nil
+		}
+
+		node := &corev1.Node{}
+		if err := r.client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			return nil, nil, false, err
+		}
+		pods = append(pods, pod.Name)
+		domains[pod.Name] = node.Labels[cluster.Spec.Placement.TopologyKey]
+	}
+	return pods, domains, true, nil
+}
+
+// getPodLoad returns the number of members of any group hosted by each of the cluster's pods
+func (r *MultiRaftClusterReconciler) getPodLoad(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (map[string]int, error) {
+	members := &consensusv1beta1.RaftMemberList{}
+	if err := r.client.List(ctx, members, client.InNamespace(cluster.Namespace), client.MatchingLabels{multiRaftClusterKey: cluster.Name}); err != nil {
+		return nil, err
+	}
+	load := make(map[string]int)
+	for _, member := range members.Items {
+		load[member.Spec.Pod.Name]++
+	}
+	return load, nil
+}
+
+// getTopologySpreadConstraints returns the topology spread constraints for the cluster's pods
+func getTopologySpreadConstraints(cluster *consensusv1beta1.MultiRaftCluster) []corev1.TopologySpreadConstraint {
+	var constraints []corev1.TopologySpreadConstraint
+	if len(cluster.Spec.Placement.TopologySpreadConstraints) > 0 {
+		for _, constraint := range cluster.Spec.Placement.TopologySpreadConstraints {
+			constraint = *constraint.DeepCopy()
+			if constraint.LabelSelector == nil {
+				constraint.LabelSelector = &metav1.LabelSelector{
+					MatchLabels: cluster.Labels,
+				}
+			}
+			constraints = append(constraints, constraint)
+		}
+	} else if cluster.Spec.Placement.TopologyKey != "" {
+		constraints = append(constraints, corev1.TopologySpreadConstraint{
+			MaxSkew:           1,
+			TopologyKey:       cluster.Spec.Placement.TopologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: cluster.Labels,
+			},
+		})
+	}
+	return constraints
+}
@@ -0,0 +1,339 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	testNamespace   = "test"
+	testTopologyKey = "topology.kubernetes.io/zone"
+)
+
+// newPlacementReconciler returns a reconciler for a cluster whose pods are scheduled on nodes in the given
+// zones by ordinal, with the given members of other groups hosted by the cluster's pods. An empty zone
+// leaves the pod unscheduled.
+func newPlacementReconciler(t *testing.T, cluster *consensusv1beta1.MultiRaftCluster, zones []string, load map[string]int) *MultiRaftClusterReconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := consensusv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	var objects []client.Object
+	for ordinal, zone := range zones {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      fmt.Sprintf("%s-%d", cluster.Name, ordinal),
+			},
+		}
+		if zone != "" {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("node-%d", ordinal),
+					Labels: map[string]string{
+						testTopologyKey: zone,
+					},
+				},
+			}
+			pod.Spec.NodeName = node.Name
+			objects = append(objects, node)
+		}
+		objects = append(objects, pod)
+	}
+	for pod, n := range load {
+		for i := 0; i < n; i++ {
+			objects = append(objects, &consensusv1beta1.RaftMember{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cluster.Namespace,
+					Name:      fmt.Sprintf("%s-member-%d", pod, i),
+					Labels: map[string]string{
+						multiRaftClusterKey: cluster.Name,
+					},
+				},
+				Spec: consensusv1beta1.RaftMemberSpec{
+					Pod: corev1.LocalObjectReference{
+						Name: pod,
+					},
+					Type: consensusv1beta1.RaftVotingMember,
+				},
+			})
+		}
+	}
+	return &MultiRaftClusterReconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		scheme: scheme,
+	}
+}
+
+func newTestMember(pod string, memberType consensusv1beta1.RaftMemberType) *consensusv1beta1.RaftMember {
+	return &consensusv1beta1.RaftMember{
+		Spec: consensusv1beta1.RaftMemberSpec{
+			Pod: corev1.LocalObjectReference{
+				Name: pod,
+			},
+			Type: memberType,
+		},
+	}
+}
+
+func TestGetPlacements(t *testing.T) {
+	tests := []struct {
+		name         string
+		zones        []string
+		quorumSize   *int32
+		readReplicas *int32
+		members      map[int]*consensusv1beta1.RaftMember
+		load         map[string]int
+		pods         []string
+		types        []consensusv1beta1.RaftMemberType
+		pending      bool
+	}{
+		{
+			name:  "one pod per zone",
+			zones: []string{"a", "b", "c"},
+			pods:  []string{"raft-0", "raft-1", "raft-2"},
+		},
+		{
+			name:       "spread across zones",
+			zones:      []string{"a", "a", "b", "b", "c", "c"},
+			quorumSize: pointer.Int32Ptr(3),
+			pods:       []string{"raft-0", "raft-2", "raft-4"},
+		},
+		{
+			name:       "fewer zones than members",
+			zones:      []string{"a", "a", "b", "b"},
+			quorumSize: pointer.Int32Ptr(3),
+			pods:       []string{"raft-0", "raft-2", "raft-1"},
+		},
+		{
+			name:       "existing members retained within spread",
+			zones:      []string{"a", "a", "b", "b", "c", "c"},
+			quorumSize: pointer.Int32Ptr(3),
+			members: map[int]*consensusv1beta1.RaftMember{
+				1: newTestMember("raft-1", consensusv1beta1.RaftVotingMember),
+				2: newTestMember("raft-3", consensusv1beta1.RaftVotingMember),
+				3: newTestMember("raft-5", consensusv1beta1.RaftVotingMember),
+			},
+			pods: []string{"raft-1", "raft-3", "raft-5"},
+		},
+		{
+			name:       "existing members moved out of crowded zone",
+			zones:      []string{"a", "a", "b", "b", "c", "c"},
+			quorumSize: pointer.Int32Ptr(3),
+			members: map[int]*consensusv1beta1.RaftMember{
+				1: newTestMember("raft-0", consensusv1beta1.RaftVotingMember),
+				2: newTestMember("raft-1", consensusv1beta1.RaftVotingMember),
+			},
+			pods: []string{"raft-0", "raft-2", "raft-4"},
+		},
+		{
+			name:       "least loaded pod in zone",
+			zones:      []string{"a", "a", "b", "b", "c", "c"},
+			quorumSize: pointer.Int32Ptr(3),
+			load: map[string]int{
+				"raft-0": 1,
+			},
+			pods: []string{"raft-1", "raft-2", "raft-4"},
+		},
+		{
+			name:         "observers placed after voting members",
+			zones:        []string{"a", "b", "c", "a"},
+			quorumSize:   pointer.Int32Ptr(3),
+			readReplicas: pointer.Int32Ptr(1),
+			pods:         []string{"raft-0", "raft-1", "raft-2", "raft-3"},
+			types: []consensusv1beta1.RaftMemberType{
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftObserver,
+			},
+		},
+		{
+			name:    "unscheduled pod",
+			zones:   []string{"a", "", "c"},
+			pending: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(int32(len(test.zones)), test.quorumSize, test.readReplicas)
+			cluster.Namespace = testNamespace
+			cluster.Spec.Placement.TopologyKey = testTopologyKey
+			r := newPlacementReconciler(t, cluster, test.zones, test.load)
+
+			members := test.members
+			if members == nil {
+				members = make(map[int]*consensusv1beta1.RaftMember)
+			}
+			placements, ok, err := r.getPlacements(context.TODO(), cluster, 1, members)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok == test.pending {
+				t.Fatalf("expected pending %t, got %t", test.pending, !ok)
+			}
+			if len(placements) != len(test.pods) {
+				t.Fatalf("expected %d placements, got %d", len(test.pods), len(placements))
+			}
+			for i, placement := range placements {
+				if placement.pod != test.pods[i] {
+					t.Errorf("expected member %d on %s, got %s", i+1, test.pods[i], placement.pod)
+				}
+				memberType := consensusv1beta1.RaftVotingMember
+				if test.types != nil {
+					memberType = test.types[i]
+				}
+				if placement.memberType != memberType {
+					t.Errorf("expected member %d to be %s, got %s", i+1, memberType, placement.memberType)
+				}
+				if placement.domain != test.zones[ordinalOf(t, placement.pod)] {
+					t.Errorf("expected member %d in zone %s, got %s", i+1, test.zones[ordinalOf(t, placement.pod)], placement.domain)
+				}
+			}
+		})
+	}
+}
+
+func TestGetPlacementsByOrdinal(t *testing.T) {
+	tests := []struct {
+		name         string
+		replicas     int32
+		quorumSize   *int32
+		readReplicas *int32
+		members      map[int]*consensusv1beta1.RaftMember
+		pods         []string
+		types        []consensusv1beta1.RaftMemberType
+	}{
+		{
+			name:       "new group",
+			replicas:   5,
+			quorumSize: pointer.Int32Ptr(3),
+			pods:       []string{"raft-3", "raft-4", "raft-0"},
+		},
+		{
+			name:       "scale up retains wrapped members",
+			replicas:   7,
+			quorumSize: pointer.Int32Ptr(3),
+			members: map[int]*consensusv1beta1.RaftMember{
+				1: newTestMember("raft-3", consensusv1beta1.RaftVotingMember),
+				2: newTestMember("raft-4", consensusv1beta1.RaftVotingMember),
+				3: newTestMember("raft-0", consensusv1beta1.RaftVotingMember),
+			},
+			pods: []string{"raft-3", "raft-4", "raft-0"},
+		},
+		{
+			name:       "scale down moves only members on removed ordinals",
+			replicas:   4,
+			quorumSize: pointer.Int32Ptr(3),
+			members: map[int]*consensusv1beta1.RaftMember{
+				1: newTestMember("raft-3", consensusv1beta1.RaftVotingMember),
+				2: newTestMember("raft-4", consensusv1beta1.RaftVotingMember),
+				3: newTestMember("raft-0", consensusv1beta1.RaftVotingMember),
+			},
+			pods: []string{"raft-3", "raft-0", "raft-1"},
+		},
+		{
+			name:       "larger quorum adds members",
+			replicas:   7,
+			quorumSize: pointer.Int32Ptr(5),
+			members: map[int]*consensusv1beta1.RaftMember{
+				1: newTestMember("raft-3", consensusv1beta1.RaftVotingMember),
+				2: newTestMember("raft-4", consensusv1beta1.RaftVotingMember),
+				3: newTestMember("raft-0", consensusv1beta1.RaftVotingMember),
+			},
+			pods: []string{"raft-3", "raft-4", "raft-0", "raft-5", "raft-6"},
+		},
+		{
+			name:         "observers placed after voting members",
+			replicas:     5,
+			quorumSize:   pointer.Int32Ptr(3),
+			readReplicas: pointer.Int32Ptr(1),
+			pods:         []string{"raft-4", "raft-0", "raft-1", "raft-2"},
+			types: []consensusv1beta1.RaftMemberType{
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftObserver,
+			},
+		},
+		{
+			name:         "excess voting member replaced by observer",
+			replicas:     4,
+			quorumSize:   pointer.Int32Ptr(3),
+			readReplicas: pointer.Int32Ptr(1),
+			members: map[int]*consensusv1beta1.RaftMember{
+				1: newTestMember("raft-0", consensusv1beta1.RaftVotingMember),
+				2: newTestMember("raft-1", consensusv1beta1.RaftVotingMember),
+				3: newTestMember("raft-2", consensusv1beta1.RaftVotingMember),
+				4: newTestMember("raft-3", consensusv1beta1.RaftVotingMember),
+			},
+			pods: []string{"raft-0", "raft-1", "raft-2", "raft-3"},
+			types: []consensusv1beta1.RaftMemberType{
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftVotingMember,
+				consensusv1beta1.RaftObserver,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(test.replicas, test.quorumSize, test.readReplicas)
+			cluster.Namespace = testNamespace
+			r := newPlacementReconciler(t, cluster, nil, nil)
+
+			members := test.members
+			if members == nil {
+				members = make(map[int]*consensusv1beta1.RaftMember)
+			}
+			placements, ok, err := r.getPlacements(context.TODO(), cluster, 1, members)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatal("expected placement without a topology key")
+			}
+			if len(placements) != len(test.pods) {
+				t.Fatalf("expected %d placements, got %d", len(test.pods), len(placements))
+			}
+			for i, placement := range placements {
+				if placement.pod != test.pods[i] {
+					t.Errorf("expected member %d on %s, got %s", i+1, test.pods[i], placement.pod)
+				}
+				memberType := consensusv1beta1.RaftVotingMember
+				if test.types != nil {
+					memberType = test.types[i]
+				}
+				if placement.memberType != memberType {
+					t.Errorf("expected member %d to be %s, got %s", i+1, memberType, placement.memberType)
+				}
+			}
+		})
+	}
+}
+
+func ordinalOf(t *testing.T, pod string) int {
+	var ordinal int
+	if _, err := fmt.Sscanf(pod, "raft-%d", &ordinal); err != nil {
+		t.Fatal(err)
+	}
+	return ordinal
+}
@@ -0,0 +1,327 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func addConsensusRestoreController(mgr manager.Manager) error {
+	options := controller.Options{
+		Reconciler: &ConsensusRestoreReconciler{
+			client: mgr.GetClient(),
+			scheme: mgr.GetScheme(),
+			events: mgr.GetEventRecorderFor("atomix-consensus-storage"),
+		},
+		RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(time.Millisecond*10, time.Second*5),
+	}
+
+	// Create a new controller
+	controller, err := controller.New("atomix-consensus-restore-v1beta1", mgr, options)
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the restore resource
+	err = controller.Watch(&source.Kind{Type: &consensusv1beta1.ConsensusRestore{}}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConsensusRestoreReconciler reconciles a ConsensusRestore object
+type ConsensusRestoreReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	events record.EventRecorder
+}
+
+// Reconcile seeds each partition of a new cluster from the partition snapshots in a backup. The snapshots are
+// staged on the initial members of each group before the cluster bootstraps the group's members.
+func (r *ConsensusRestoreReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log.Info("Reconcile ConsensusRestore")
+	restore := &consensusv1beta1.ConsensusRestore{}
+	err := r.client.Get(ctx, request.NamespacedName, restore)
+	if err != nil {
+		log.Error(err, "Reconcile ConsensusRestore")
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	var result reconcile.Result
+	switch restore.Status.State {
+	case consensusv1beta1.ConsensusRestoreComplete, consensusv1beta1.ConsensusRestoreFailed:
+		return reconcile.Result{}, nil
+	case consensusv1beta1.ConsensusRestoreRunning:
+		result, err = r.reconcileRunning(ctx, restore)
+	default:
+		result, err = r.reconcilePending(ctx, restore)
+	}
+	if err != nil {
+		log.Error(err, "Reconcile ConsensusRestore")
+	}
+	return result, err
+}
+
+// reconcilePending starts the restore once the target cluster has been created
+func (r *ConsensusRestoreReconciler) reconcilePending(ctx context.Context, restore *consensusv1beta1.ConsensusRestore) (reconcile.Result, error) {
+	if restore.Status.State == "" {
+		restore.Status.State = consensusv1beta1.ConsensusRestorePending
+		if err := r.client.Status().Update(ctx, restore); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	cluster := &consensusv1beta1.MultiRaftCluster{}
+	clusterName := types.NamespacedName{
+		Namespace: restore.Namespace,
+		Name:      restore.Spec.Cluster.Name,
+	}
+	if err := r.client.Get(ctx, clusterName, cluster); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+	}
+
+	// Only a cluster that waits for the restore before bootstrapping its members can be restored
+	if cluster.Spec.Restore == nil || cluster.Spec.Restore.Name != restore.Name {
+		return r.setFailed(ctx, restore, "Cluster %s is not configured to be restored from %s", cluster.Name, restore.Name)
+	}
+
+	store, err := newBackupStore(ctx, r.client, restore.Namespace, restore.Spec.Source)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	reader, err := store.Read(ctx, path.Join(restore.Spec.Location, backupManifestFile))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer reader.Close()
+
+	var manifest backupManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return r.setFailed(ctx, restore, "Invalid backup manifest: %s", err)
+	}
+
+	if len(manifest.Partitions) != getNumGroups(cluster) {
+		return r.setFailed(ctx, restore, "Backup contains %d partitions, but cluster %s has %d partitions",
+			len(manifest.Partitions), cluster.Name, getNumGroups(cluster))
+	}
+
+	restore.Status.State = consensusv1beta1.ConsensusRestoreRunning
+	restore.Status.StartTime = &metav1.Time{Time: time.Now()}
+	restore.Status.Partitions = make([]consensusv1beta1.BackupPartitionStatus, 0, len(manifest.Partitions))
+	for _, partition := range manifest.Partitions {
+		status := consensusv1beta1.BackupPartitionStatus{
+			PartitionID: partition.PartitionID,
+			State:       consensusv1beta1.BackupPartitionPending,
+			Index:       partition.Index,
+			Size:        partition.Size,
+		}
+		if !partition.Time.IsZero() {
+			status.Time = &metav1.Time{Time: partition.Time}
+		}
+		restore.Status.Partitions = append(restore.Status.Partitions, status)
+	}
+	if err := r.client.Status().Update(ctx, restore); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.events.Eventf(restore, "Normal", "RestoreStarted", "Started restore of cluster %s from %s", cluster.Name, restore.Spec.Location)
+	return reconcile.Result{}, nil
+}
+
+// reconcileRunning stages the snapshot of the next pending partition on one of the partition's initial members,
+// completing a partition once its snapshot has been staged on all its initial members and completing the
+// restore once all partitions have been processed. Members that fail to be seeded are retried until
+// backupMaxAttempts attempts have failed.
+func (r *ConsensusRestoreReconciler) reconcileRunning(ctx context.Context, restore *consensusv1beta1.ConsensusRestore) (reconcile.Result, error) {
+	cluster := &consensusv1beta1.MultiRaftCluster{}
+	clusterName := types.NamespacedName{
+		Namespace: restore.Namespace,
+		Name:      restore.Spec.Cluster.Name,
+	}
+	if err := r.client.Get(ctx, clusterName, cluster); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	store, err := newBackupStore(ctx, r.client, restore.Namespace, restore.Spec.Source)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for i, partition := range restore.Status.Partitions {
+		if partition.State != consensusv1beta1.BackupPartitionPending {
+			continue
+		}
+
+		groupName := types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      fmt.Sprintf("%s-%d", cluster.Name, partition.PartitionID),
+		}
+		group := &consensusv1beta1.RaftGroup{}
+		if err := r.client.Get(ctx, groupName, group); err != nil {
+			if !k8serrors.IsNotFound(err) {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+		}
+
+		// Wait for the cluster to create all the initial members of the group
+		if group.Status.LastMemberID == nil {
+			return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+		}
+
+		members, err := getGroupMembers(ctx, r.client, group)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		for _, memberID := range getMemberIDs(members) {
+			member := members[memberID]
+			if member.Spec.BootstrapPolicy == consensusv1beta1.RaftJoin || isMemberRestored(partition, memberID) {
+				continue
+			}
+
+			// Members that have already been bootstrapped cannot be seeded
+			if member.Status.HostID != "" {
+				partition.State = consensusv1beta1.BackupPartitionFailed
+				partition.Message = fmt.Sprintf("member %d was bootstrapped before it was restored", memberID)
+				restore.Status.Partitions[i] = partition
+				if err := r.client.Status().Update(ctx, restore); err != nil {
+					return reconcile.Result{}, err
+				}
+				r.events.Eventf(restore, "Warning", "PartitionRestoreFailed", "Failed to restore partition %d: %s", partition.PartitionID, partition.Message)
+				return reconcile.Result{}, nil
+			}
+
+			pod := &corev1.Pod{}
+			podName := types.NamespacedName{
+				Namespace: member.Namespace,
+				Name:      member.Spec.Pod.Name,
+			}
+			if err := r.client.Get(ctx, podName, pod); err != nil {
+				if !k8serrors.IsNotFound(err) {
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+			}
+			if pod.Status.PodIP == "" {
+				return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+			}
+
+			err := r.restoreMember(ctx, cluster, store, restore, partition.PartitionID, int32(memberID), pod)
+			if err != nil {
+				partition.Attempts++
+				partition.Message = err.Error()
+				if partition.Attempts >= backupMaxAttempts {
+					partition.State = consensusv1beta1.BackupPartitionFailed
+					r.events.Eventf(restore, "Warning", "PartitionRestoreFailed", "Failed to restore partition %d: %s", partition.PartitionID, err)
+				} else {
+					r.events.Eventf(restore, "Warning", "PartitionRestoreRetrying", "Failed to restore partition %d member %d (attempt %d): %s", partition.PartitionID, memberID, partition.Attempts, err)
+				}
+			} else {
+				partition.Members = append(partition.Members, int32(memberID))
+				partition.Message = ""
+			}
+			restore.Status.Partitions[i] = partition
+			if err := r.client.Status().Update(ctx, restore); err != nil {
+				return reconcile.Result{}, err
+			}
+			if partition.State == consensusv1beta1.BackupPartitionPending && err != nil {
+				return reconcile.Result{RequeueAfter: backupRetryInterval}, nil
+			}
+			return reconcile.Result{}, nil
+		}
+
+		// The snapshot has been staged on all the initial members, so the group's members can be bootstrapped
+		partition.State = consensusv1beta1.BackupPartitionComplete
+		restore.Status.Partitions[i] = partition
+		if err := r.client.Status().Update(ctx, restore); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	state := consensusv1beta1.ConsensusRestoreComplete
+	for _, partition := range restore.Status.Partitions {
+		if partition.State == consensusv1beta1.BackupPartitionFailed {
+			state = consensusv1beta1.ConsensusRestoreFailed
+		}
+	}
+
+	restore.Status.State = state
+	restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	if err := r.client.Status().Update(ctx, restore); err != nil {
+		return reconcile.Result{}, err
+	}
+	if state == consensusv1beta1.ConsensusRestoreComplete {
+		r.events.Eventf(restore, "Normal", "RestoreComplete", "Completed restore of cluster %s from %s", cluster.Name, restore.Spec.Location)
+	} else {
+		r.events.Eventf(restore, "Warning", "RestoreFailed", "Restore of cluster %s from %s failed", cluster.Name, restore.Spec.Location)
+	}
+	return reconcile.Result{}, nil
+}
+
+// restoreMember streams the partition's snapshot from the backup store to the node hosting the given member
+func (r *ConsensusRestoreReconciler) restoreMember(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, store backupStore, restore *consensusv1beta1.ConsensusRestore, partitionID int32, memberID int32, pod *corev1.Pod) error {
+	opts, err := getDialOptions(ctx, r.client, cluster, getPodDNSName(cluster.Namespace, cluster.Name, pod.Name))
+	if err != nil {
+		return err
+	}
+	reader, err := store.Read(ctx, path.Join(restore.Spec.Location, getPartitionSnapshotFile(partitionID)))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	address := fmt.Sprintf("%s:%d", pod.Status.PodIP, apiPort)
+	return importSnapshot(ctx, address, opts, partitionID, memberID, reader)
+}
+
+// isMemberRestored returns whether the partition's snapshot has been staged on the given member
+func isMemberRestored(partition consensusv1beta1.BackupPartitionStatus, memberID int) bool {
+	for _, id := range partition.Members {
+		if int(id) == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ConsensusRestoreReconciler) setFailed(ctx context.Context, restore *consensusv1beta1.ConsensusRestore, message string, args ...interface{}) (reconcile.Result, error) {
+	restore.Status.State = consensusv1beta1.ConsensusRestoreFailed
+	restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	if err := r.client.Status().Update(ctx, restore); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.events.Eventf(restore, "Warning", "RestoreFailed", message, args...)
+	return reconcile.Result{}, nil
+}
+
+var _ reconcile.Reconciler = (*ConsensusRestoreReconciler)(nil)
@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleSearch bounds the search for the next activation of a cron schedule
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// cronSchedule is a standard five field cron schedule (minute, hour, day of month, month, day of week)
+type cronSchedule struct {
+	minutes     []bool
+	hours       []bool
+	daysOfMonth []bool
+	months      []bool
+	daysOfWeek  []bool
+	anyDOM      bool
+	anyDOW      bool
+}
+
+// parseCronSchedule parses a standard five field cron expression
+func parseCronSchedule(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule '%s': expected 5 fields, found %d", spec, len(fields))
+	}
+	minutes, err := parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %v", spec, err)
+	}
+	hours, err := parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %v", spec, err)
+	}
+	daysOfMonth, err := parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %v", spec, err)
+	}
+	months, err := parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %v", spec, err)
+	}
+	daysOfWeek, err := parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule '%s': %v", spec, err)
+	}
+	// Both 0 and 7 represent Sunday
+	if daysOfWeek[7] {
+		daysOfWeek[0] = true
+	}
+	return &cronSchedule{
+		minutes:     minutes,
+		hours:       hours,
+		daysOfMonth: daysOfMonth,
+		months:      months,
+		daysOfWeek:  daysOfWeek,
+		anyDOM:      fields[2] == "*" || fields[2] == "?",
+		anyDOW:      fields[4] == "*" || fields[4] == "?",
+	}, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps into a set of values
+func parseCronField(field string, min, max int) ([]bool, error) {
+	values := make([]bool, max+1)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return nil, fmt.Errorf("invalid step in '%s'", part)
+			}
+			step = s
+			part = part[:i]
+		}
+
+		start, end := min, max
+		if part != "*" && part != "?" {
+			if i := strings.Index(part, "-"); i >= 0 {
+				s, err := strconv.Atoi(part[:i])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range '%s'", part)
+				}
+				e, err := strconv.Atoi(part[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid range '%s'", part)
+				}
+				start, end = s, e
+			} else {
+				v, err := strconv.Atoi(part)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value '%s'", part)
+				}
+				start, end = v, v
+				if step > 1 {
+					end = max
+				}
+			}
+		}
+		if start < min || end > max || start > end {
+			return nil, fmt.Errorf("value '%s' out of range [%d, %d]", part, min, max)
+		}
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+// Next returns the first activation time of the schedule after the given time
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	deadline := t.Add(maxScheduleSearch)
+	for next.Before(deadline) {
+		if !s.months[int(next.Month())] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.matchDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.hours[next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+		if !s.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+		return next
+	}
+	return time.Time{}
+}
+
+// matchDay returns whether the day of the given time matches the schedule. Following cron semantics,
+// when both the day of month and day of week are restricted a day matching either field matches.
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := s.daysOfMonth[t.Day()]
+	dow := s.daysOfWeek[int(t.Weekday())]
+	if s.anyDOM || s.anyDOW {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		valid bool
+	}{
+		{name: "every minute", spec: "* * * * *", valid: true},
+		{name: "values", spec: "30 2 15 6 1", valid: true},
+		{name: "ranges", spec: "0-30 9-17 1-15 1-6 1-5", valid: true},
+		{name: "lists", spec: "0,15,30,45 0,12 1,15 1,7 0,6", valid: true},
+		{name: "steps", spec: "*/15 */2 */5 */3 *", valid: true},
+		{name: "stepped range", spec: "0-30/10 * * * *", valid: true},
+		{name: "stepped value", spec: "5/20 * * * *", valid: true},
+		{name: "question mark", spec: "0 0 ? * 1", valid: true},
+		{name: "sunday as 7", spec: "0 0 * * 7", valid: true},
+		{name: "too few fields", spec: "* * * *"},
+		{name: "too many fields", spec: "* * * * * *"},
+		{name: "empty", spec: ""},
+		{name: "minute out of range", spec: "60 * * * *"},
+		{name: "hour out of range", spec: "* 24 * * *"},
+		{name: "day of month zero", spec: "* * 0 * *"},
+		{name: "month out of range", spec: "* * * 13 *"},
+		{name: "day of week out of range", spec: "* * * * 8"},
+		{name: "reversed range", spec: "30-10 * * * *"},
+		{name: "zero step", spec: "*/0 * * * *"},
+		{name: "invalid step", spec: "*/x * * * *"},
+		{name: "invalid value", spec: "x * * * *"},
+		{name: "invalid range", spec: "1-x * * * *"},
+		{name: "names", spec: "0 0 * JAN MON"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := parseCronSchedule(test.spec)
+			if test.valid {
+				if err != nil {
+					t.Fatalf("expected valid schedule, got %v", err)
+				}
+				if schedule == nil {
+					t.Fatal("expected schedule")
+				}
+			} else if err == nil {
+				t.Fatal("expected invalid schedule")
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2022-01-05 is a Wednesday
+	from := time.Date(2022, time.January, 5, 10, 30, 45, 0, time.UTC)
+	tests := []struct {
+		name string
+		spec string
+		next time.Time
+	}{
+		{
+			name: "every minute",
+			spec: "* * * * *",
+			next: time.Date(2022, time.January, 5, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			name: "next hour",
+			spec: "0 * * * *",
+			next: time.Date(2022, time.January, 5, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "later today",
+			spec: "0 12 * * *",
+			next: time.Date(2022, time.January, 5, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "tomorrow",
+			spec: "0 2 * * *",
+			next: time.Date(2022, time.January, 6, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "every fifteen minutes",
+			spec: "*/15 * * * *",
+			next: time.Date(2022, time.January, 5, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			name: "day of week",
+			spec: "0 0 * * 1",
+			next: time.Date(2022, time.January, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "sunday as 7",
+			spec: "0 0 * * 7",
+			next: time.Date(2022, time.January, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month",
+			spec: "0 0 1 * *",
+			next: time.Date(2022, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "day of month or day of week",
+			spec: "0 0 20 * 5",
+			next: time.Date(2022, time.January, 7, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "month",
+			spec: "0 0 1 6 *",
+			next: time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "next year",
+			spec: "0 0 1 1 *",
+			next: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "leap day",
+			spec: "0 0 29 2 *",
+			next: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "never",
+			spec: "0 0 31 2 *",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := parseCronSchedule(test.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next := schedule.Next(from); !next.Equal(test.next) {
+				t.Errorf("expected %s, got %s", test.next, next)
+			}
+		})
+	}
+}
@@ -5,15 +5,20 @@
 package v1beta1
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	atomixv3beta3 "github.com/atomix/runtime/controller/pkg/apis/atomix/v3beta3"
 	"github.com/atomix/runtime/sdk/pkg/protocol"
 	"github.com/gogo/protobuf/jsonpb"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/strings/slices"
+	"reflect"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -124,6 +129,16 @@ func (r *MultiRaftStoreReconciler) Reconcile(ctx context.Context, request reconc
 		return reconcile.Result{}, nil
 	}
 
+	if !equality.Semantic.DeepEqual(cluster.Spec, store.Spec.MultiRaftClusterSpec) {
+		log.Info("Updating MultiRaftCluster", "Name", store.Name, "Namespace", store.Namespace)
+		cluster.Spec = store.Spec.MultiRaftClusterSpec
+		if err := r.client.Update(ctx, cluster); err != nil {
+			log.Error(err, "Reconcile ConsensusStore")
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
 	if cluster.Status.Partitions == nil {
 		return reconcile.Result{}, nil
 	}
@@ -137,6 +152,12 @@ func (r *MultiRaftStoreReconciler) Reconcile(ctx context.Context, request reconc
 		return reconcile.Result{Requeue: true}, nil
 	}
 
+	tlsConfig, err := r.getDataStoreTLSConfig(ctx, cluster)
+	if err != nil {
+		log.Error(err, "Reconcile ConsensusStore")
+		return reconcile.Result{}, err
+	}
+
 	dataStore := &atomixv3beta3.DataStore{}
 	dataStoreName := types.NamespacedName{
 		Namespace: store.Namespace,
@@ -149,8 +170,7 @@ func (r *MultiRaftStoreReconciler) Reconcile(ctx context.Context, request reconc
 		}
 
 		config := getProtocolConfig(cluster.Status.Partitions)
-		marshaler := &jsonpb.Marshaler{}
-		configString, err := marshaler.MarshalToString(&config)
+		configBytes, err := newDataStoreConfig(config, tlsConfig)
 		if err != nil {
 			log.Error(err, "Reconcile ConsensusStore")
 			return reconcile.Result{}, err
@@ -168,7 +188,7 @@ func (r *MultiRaftStoreReconciler) Reconcile(ctx context.Context, request reconc
 					Version: driverVersion,
 				},
 				Config: runtime.RawExtension{
-					Raw: []byte(configString),
+					Raw: configBytes,
 				},
 			},
 		}
@@ -184,22 +204,28 @@ func (r *MultiRaftStoreReconciler) Reconcile(ctx context.Context, request reconc
 	}
 
 	var config protocol.ProtocolConfig
-	if err := jsonpb.UnmarshalString(string(dataStore.Spec.Config.Raw), &config); err != nil {
+	unmarshaler := &jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(bytes.NewReader(dataStore.Spec.Config.Raw), &config); err != nil {
+		log.Error(err, "Reconcile ConsensusStore")
+		return reconcile.Result{}, err
+	}
+
+	var connConfig dataStoreConnConfig
+	if err := json.Unmarshal(dataStore.Spec.Config.Raw, &connConfig); err != nil {
 		log.Error(err, "Reconcile ConsensusStore")
 		return reconcile.Result{}, err
 	}
 
 	newConfig := getProtocolConfig(cluster.Status.Partitions)
-	if !isProtocolConfigEqual(config, newConfig) {
-		marshaler := &jsonpb.Marshaler{}
-		configString, err := marshaler.MarshalToString(&newConfig)
+	if !isProtocolConfigEqual(config, newConfig) || !reflect.DeepEqual(connConfig.TLS, tlsConfig) {
+		configBytes, err := newDataStoreConfig(newConfig, tlsConfig)
 		if err != nil {
 			log.Error(err, "Reconcile ConsensusStore")
 			return reconcile.Result{}, err
 		}
 
 		dataStore.Spec.Config = runtime.RawExtension{
-			Raw: []byte(configString),
+			Raw: configBytes,
 		}
 		if err := r.client.Update(ctx, dataStore); err != nil {
 			log.Error(err, "Reconcile ConsensusStore")
@@ -219,6 +245,57 @@ func (r *MultiRaftStoreReconciler) Reconcile(ctx context.Context, request reconc
 	return reconcile.Result{}, nil
 }
 
+// dataStoreConnConfig is the driver configuration provided alongside the protocol configuration
+type dataStoreConnConfig struct {
+	TLS *dataStoreTLSConfig `json:"tls,omitempty"`
+}
+
+// dataStoreTLSConfig is the TLS configuration with which the driver connects to the store's nodes
+type dataStoreTLSConfig struct {
+	CACert string `json:"caCert"`
+}
+
+// getDataStoreTLSConfig returns the driver TLS configuration for the given cluster
+func (r *MultiRaftStoreReconciler) getDataStoreTLSConfig(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (*dataStoreTLSConfig, error) {
+	if cluster.Spec.Config.TLS == nil {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      getTLSSecretName(cluster),
+	}
+	if err := r.client.Get(ctx, secretName, secret); err != nil {
+		return nil, err
+	}
+	return &dataStoreTLSConfig{
+		CACert: string(secret.Data[caCertKey]),
+	}, nil
+}
+
+// newDataStoreConfig returns the DataStore configuration for the given protocol and TLS configurations
+func newDataStoreConfig(config protocol.ProtocolConfig, tlsConfig *dataStoreTLSConfig) ([]byte, error) {
+	marshaler := &jsonpb.Marshaler{}
+	configString, err := marshaler.MarshalToString(&config)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return []byte(configString), nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(configString), &fields); err != nil {
+		return nil, err
+	}
+	tlsBytes, err := json.Marshal(tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	fields["tls"] = tlsBytes
+	return json.Marshal(fields)
+}
+
 func getProtocolConfig(partitions []consensusv1beta1.RaftPartitionStatus) protocol.ProtocolConfig {
 	var config protocol.ProtocolConfig
 	for _, partition := range partitions {
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	tlsPath         = "/etc/atomix/tls"
+	tlsVolume       = "tls"
+	tlsSecretSuffix = "tls"
+	caCertKey       = "ca.crt"
+)
+
+const (
+	defaultIssuerKind  = "Issuer"
+	defaultIssuerGroup = "cert-manager.io"
+)
+
+var certificateGVK = schema.GroupVersionKind{
+	Group:   "cert-manager.io",
+	Version: "v1",
+	Kind:    "Certificate",
+}
+
+// getTLSSecretName returns the name of the Secret containing the certificate for the given cluster
+func getTLSSecretName(cluster *consensusv1beta1.MultiRaftCluster) string {
+	if cluster.Spec.Config.TLS.SecretName != "" {
+		return cluster.Spec.Config.TLS.SecretName
+	}
+	return fmt.Sprintf("%s-%s", cluster.Name, tlsSecretSuffix)
+}
+
+// newTLSConfig returns the node TLS configuration for the given cluster
+func newTLSConfig(cluster *consensusv1beta1.MultiRaftCluster) *consensus.TLSConfig {
+	if cluster.Spec.Config.TLS == nil {
+		return nil
+	}
+	reload := cluster.Spec.Config.TLS.Reload
+	if reload == nil {
+		defaultReload := true
+		reload = &defaultReload
+	}
+	return &consensus.TLSConfig{
+		CAFile:   path.Join(tlsPath, caCertKey),
+		CertFile: path.Join(tlsPath, corev1.TLSCertKey),
+		KeyFile:  path.Join(tlsPath, corev1.TLSPrivateKeyKey),
+		Reload:   reload,
+	}
+}
+
+// getTLSHash returns a hash of the certificate used by the nodes of the given cluster, or an empty string if
+// TLS is disabled. Dragonboat only loads the Raft transport's certificate when a node starts, so the pods
+// are rolled one at a time by reconcileUpgrade when the hash changes.
+func (r *MultiRaftClusterReconciler) getTLSHash(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (string, error) {
+	if cluster.Spec.Config.TLS == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      getTLSSecretName(cluster),
+	}
+	if err := r.client.Get(ctx, secretName, secret); err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, key := range []string{caCertKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		hash.Write([]byte(key))
+		hash.Write(secret.Data[key])
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (r *MultiRaftClusterReconciler) reconcileCertificate(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) error {
+	if cluster.Spec.Config.TLS == nil || cluster.Spec.Config.TLS.IssuerRef == nil {
+		return nil
+	}
+
+	log.Info("Reconcile raft protocol certificate")
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	name := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      fmt.Sprintf("%s-%s", cluster.Name, tlsSecretSuffix),
+	}
+	err := r.client.Get(ctx, name, certificate)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = r.addCertificate(ctx, cluster, name)
+		}
+		return err
+	}
+
+	spec, _, err := unstructured.NestedMap(certificate.Object, "spec")
+	if err != nil {
+		return err
+	}
+
+	// Only the fields managed by the controller are compared to avoid conflicting with fields defaulted by cert-manager
+	var updated bool
+	for key, value := range newCertificateSpec(cluster) {
+		if !equality.Semantic.DeepEqual(spec[key], value) {
+			spec[key] = value
+			updated = true
+		}
+	}
+	if updated {
+		log.Info("Updating raft Certificate", "Name", name.Name, "Namespace", name.Namespace)
+		if err := unstructured.SetNestedMap(certificate.Object, spec, "spec"); err != nil {
+			return err
+		}
+		return r.client.Update(ctx, certificate)
+	}
+	return nil
+}
+
+func (r *MultiRaftClusterReconciler) addCertificate(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, name types.NamespacedName) error {
+	log.Info("Creating raft Certificate", "Name", name.Name, "Namespace", name.Namespace)
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetNamespace(name.Namespace)
+	certificate.SetName(name.Name)
+	certificate.SetLabels(cluster.Labels)
+	if err := unstructured.SetNestedMap(certificate.Object, newCertificateSpec(cluster), "spec"); err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(cluster, certificate, r.scheme); err != nil {
+		return err
+	}
+	return r.client.Create(ctx, certificate)
+}
+
+// newCertificateSpec returns the spec of the cert-manager Certificate for the given cluster. The certificate
+// is valid for the DNS names of the cluster's pods and service, and for both server and client authentication
+// since nodes authenticate with each other using the same certificate.
+func newCertificateSpec(cluster *consensusv1beta1.MultiRaftCluster) map[string]interface{} {
+	issuer := cluster.Spec.Config.TLS.IssuerRef
+	kind := issuer.Kind
+	if kind == "" {
+		kind = defaultIssuerKind
+	}
+	group := issuer.Group
+	if group == "" {
+		group = defaultIssuerGroup
+	}
+	return map[string]interface{}{
+		"secretName": getTLSSecretName(cluster),
+		"dnsNames": []interface{}{
+			fmt.Sprintf("*.%s.%s.svc.%s", getHeadlessServiceName(cluster.Name), cluster.Namespace, getClusterDomain()),
+			fmt.Sprintf("%s.%s.svc.%s", cluster.Name, cluster.Namespace, getClusterDomain()),
+		},
+		"usages": []interface{}{
+			"server auth",
+			"client auth",
+		},
+		"issuerRef": map[string]interface{}{
+			"name":  issuer.Name,
+			"kind":  kind,
+			"group": group,
+		},
+	}
+}
+
+// getTransportCredentials returns the credentials with which the controller connects to the nodes of the
+// given cluster. When TLS is enabled, the controller authenticates with the cluster's certificate. The node's
+// certificate is verified against the given server name, or against the dialed host if the name is empty.
+func getTransportCredentials(ctx context.Context, c client.Client, cluster *consensusv1beta1.MultiRaftCluster, serverName string) (credentials.TransportCredentials, error) {
+	if cluster.Spec.Config.TLS == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	secret := &corev1.Secret{}
+	secretName := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      getTLSSecretName(cluster),
+	}
+	if err := c.Get(ctx, secretName, secret); err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(secret.Data[caCertKey]) {
+		return nil, fmt.Errorf("secret %s does not contain a valid %s", secretName.Name, caCertKey)
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		ServerName:   serverName,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+	}), nil
+}
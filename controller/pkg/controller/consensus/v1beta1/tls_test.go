@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newTestTLSSecret returns a Secret with a self-signed certificate, which also serves as the CA
+func newTestTLSSecret(t *testing.T, name string) *corev1.Secret {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "raft"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Data: map[string][]byte{
+			caCertKey:               certPEM,
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		},
+	}
+}
+
+// newSecretReconciler returns a reconciler for the given Secrets and other objects
+func newSecretReconciler(t *testing.T, objects ...client.Object) *MultiRaftClusterReconciler {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := consensusv1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return &MultiRaftClusterReconciler{
+		client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		scheme: scheme,
+	}
+}
+
+func TestGetTLSHash(t *testing.T) {
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Namespace = testNamespace
+	secret := newTestTLSSecret(t, "raft-tls")
+	r := newSecretReconciler(t, secret)
+
+	hash, err := r.getTLSHash(context.TODO(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "" {
+		t.Fatalf("expected no hash with TLS disabled, got %s", hash)
+	}
+
+	cluster.Spec.Config.TLS = &consensusv1beta1.MultiRaftTLSConfig{}
+	hash, err = r.getTLSHash(context.TODO(), cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == "" {
+		t.Fatal("expected hash of the certificate")
+	}
+	if unchanged, err := r.getTLSHash(context.TODO(), cluster); err != nil {
+		t.Fatal(err)
+	} else if unchanged != hash {
+		t.Errorf("expected hash %s of unchanged certificate, got %s", hash, unchanged)
+	}
+
+	// A renewed certificate rolls the cluster's pods
+	secret.Data = newTestTLSSecret(t, secret.Name).Data
+	if err := r.client.Update(context.TODO(), secret); err != nil {
+		t.Fatal(err)
+	}
+	if renewed, err := r.getTLSHash(context.TODO(), cluster); err != nil {
+		t.Fatal(err)
+	} else if renewed == hash {
+		t.Error("expected hash to change when the certificate is renewed")
+	}
+
+	cluster.Spec.Config.TLS.SecretName = "missing"
+	if _, err := r.getTLSHash(context.TODO(), cluster); err == nil {
+		t.Error("expected error for missing Secret")
+	}
+}
+
+func TestGetTransportCredentials(t *testing.T) {
+	valid := newTestTLSSecret(t, "raft-tls")
+	invalid := newTestTLSSecret(t, "invalid")
+	delete(invalid.Data, caCertKey)
+	tests := []struct {
+		name     string
+		tls      *consensusv1beta1.MultiRaftTLSConfig
+		protocol string
+		err      bool
+	}{
+		{
+			name:     "disabled",
+			protocol: "insecure",
+		},
+		{
+			name:     "default secret",
+			tls:      &consensusv1beta1.MultiRaftTLSConfig{},
+			protocol: "tls",
+		},
+		{
+			name: "missing CA",
+			tls:  &consensusv1beta1.MultiRaftTLSConfig{SecretName: invalid.Name},
+			err:  true,
+		},
+		{
+			name: "missing secret",
+			tls:  &consensusv1beta1.MultiRaftTLSConfig{SecretName: "missing"},
+			err:  true,
+		},
+	}
+	r := newSecretReconciler(t, valid, invalid)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Namespace = testNamespace
+			cluster.Spec.Config.TLS = test.tls
+			creds, err := getTransportCredentials(context.TODO(), r.client, cluster, "raft-0")
+			if test.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if protocol := creds.Info().SecurityProtocol; protocol != test.protocol {
+				t.Errorf("expected %s credentials, got %s", test.protocol, protocol)
+			}
+		})
+	}
+}
+
+func TestNewCertificateSpec(t *testing.T) {
+	t.Setenv(clusterDomainEnv, "cluster.local")
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Namespace = testNamespace
+	cluster.Spec.Config.TLS = &consensusv1beta1.MultiRaftTLSConfig{
+		IssuerRef: &consensusv1beta1.MultiRaftCertificateIssuer{
+			Name: "issuer",
+		},
+	}
+	spec := newCertificateSpec(cluster)
+	if spec["secretName"] != "raft-tls" {
+		t.Errorf("expected secret raft-tls, got %v", spec["secretName"])
+	}
+	issuer := spec["issuerRef"].(map[string]interface{})
+	if issuer["kind"] != defaultIssuerKind || issuer["group"] != defaultIssuerGroup {
+		t.Errorf("expected default issuer kind and group, got %v", issuer)
+	}
+	dnsNames := spec["dnsNames"].([]interface{})
+	if dnsNames[0] != "*."+getHeadlessServiceName(cluster.Name)+".test.svc.cluster.local" {
+		t.Errorf("expected certificate to be valid for the cluster's pods, got %v", dnsNames)
+	}
+	usages := spec["usages"].([]interface{})
+	if len(usages) != 2 {
+		t.Errorf("expected certificate to be valid for server and client authentication, got %v", usages)
+	}
+}
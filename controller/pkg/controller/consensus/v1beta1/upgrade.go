@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const upgradeRetryInterval = 5 * time.Second
+
+// defaultUpgradeMaxLag is the default number of entries by which an upgraded member may trail the leader of its
+// group and still be considered caught up. The leader continues to apply entries while a member catches up, so
+// requiring a member to reach the leader's latest index could stall an upgrade indefinitely under a steady write load.
+const defaultUpgradeMaxLag = 1000
+
+// reconcileUpgrade rolls a pending StatefulSet revision out to the cluster's pods one pod at a time, from the
+// highest ordinal to the lowest. Before a pod is released for upgrade, leadership of all the groups it hosts is
+// moved to other pods, and the previously upgraded pod must have rejoined and caught up with all its groups.
+func (r *MultiRaftClusterReconciler) reconcileUpgrade(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster) (reconcile.Result, error) {
+	statefulSet := &appsv1.StatefulSet{}
+	name := types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      cluster.Name,
+	}
+	if err := r.client.Get(ctx, name, statefulSet); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil || rollingUpdate.Partition == nil || statefulSet.Spec.Replicas == nil {
+		return reconcile.Result{}, nil
+	}
+
+	// Wait for the StatefulSet controller to compute the update revision
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		return reconcile.Result{}, nil
+	}
+
+	revision := statefulSet.Status.UpdateRevision
+	replicas := *statefulSet.Spec.Replicas
+	partition := *rollingUpdate.Partition
+	if partition > replicas {
+		partition = replicas
+	}
+
+	upgrade := cluster.Status.Upgrade
+	if upgrade == nil || upgrade.Revision != revision {
+		if partition == 0 {
+			return reconcile.Result{}, nil
+		}
+		cluster.Status.Upgrade = &consensusv1beta1.MultiRaftClusterUpgradeStatus{
+			Revision:  revision,
+			Replicas:  replicas,
+			StartTime: &metav1.Time{Time: time.Now()},
+		}
+		if err := r.client.Status().Update(ctx, cluster); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.events.Eventf(cluster, "Normal", "UpgradeStarted", "Started upgrade to revision %s", revision)
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	if upgrade.CompletionTime != nil {
+		return reconcile.Result{}, nil
+	}
+
+	// The last pod released for upgrade must be running the new revision and caught up with all its groups
+	if partition < replicas {
+		podName := fmt.Sprintf("%s-%d", cluster.Name, partition)
+		if ok, err := r.isPodCaughtUp(ctx, cluster, podName, revision); err != nil {
+			return reconcile.Result{}, err
+		} else if !ok {
+			log.Infof("Waiting for pod %s to catch up", podName)
+			return reconcile.Result{RequeueAfter: upgradeRetryInterval}, nil
+		}
+	}
+
+	if partition == 0 {
+		upgrade.Pod = ""
+		upgrade.CompletionTime = &metav1.Time{Time: time.Now()}
+		if err := r.client.Status().Update(ctx, cluster); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.events.Eventf(cluster, "Normal", "UpgradeComplete", "Completed upgrade to revision %s", revision)
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	next := partition - 1
+	podName := fmt.Sprintf("%s-%d", cluster.Name, next)
+	if ok, err := r.transferLeadershipFromPod(ctx, cluster, podName); err != nil {
+		return reconcile.Result{}, err
+	} else if ok {
+		return reconcile.Result{RequeueAfter: upgradeRetryInterval}, nil
+	}
+
+	log.Info("Upgrading raft replica", "Name", podName, "Namespace", cluster.Namespace, "Revision", revision)
+	rollingUpdate.Partition = &next
+	if err := r.client.Update(ctx, statefulSet); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	upgrade.Replicas = replicas
+	upgrade.UpdatedReplicas = replicas - next
+	upgrade.Pod = podName
+	if err := r.client.Status().Update(ctx, cluster); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.events.Eventf(cluster, "Normal", "UpgradingPod", "Upgrading pod %s to revision %s", podName, revision)
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// isPodCaughtUp returns whether the given pod is running the given revision and all the members it hosts
+// have rejoined their groups and applied the entries applied by the group leaders, within the upgrade's max lag
+func (r *MultiRaftClusterReconciler) isPodCaughtUp(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, podName string, revision string) (bool, error) {
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: podName}, pod); err != nil {
+		return false, err
+	}
+	if pod.Labels[appsv1.ControllerRevisionHashLabelKey] != revision || !isPodReady(pod) {
+		return false, nil
+	}
+
+	members, err := r.getPodMembers(ctx, cluster, podName)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range members {
+		if member.Status.State != consensusv1beta1.RaftMemberReady ||
+			member.Status.PodRef == nil || member.Status.PodRef.UID != pod.UID {
+			return false, nil
+		}
+
+		group := &consensusv1beta1.RaftGroup{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: member.Namespace, Name: member.Labels[raftGroupKey]}, group); err != nil {
+			return false, err
+		}
+		if group.Status.State != consensusv1beta1.RaftGroupReady {
+			return false, nil
+		}
+
+		leader, err := r.getLeader(ctx, group)
+		if err != nil || leader == nil {
+			return false, err
+		}
+
+		groupID, err := strconv.Atoi(member.Labels[raftPartitionKey])
+		if err != nil {
+			return false, err
+		}
+
+		leaderStatus, err := r.getMemberStatus(ctx, leader, groupID)
+		if err != nil {
+			return false, err
+		}
+		memberStatus, err := r.getMemberStatus(ctx, &member, groupID)
+		if err != nil {
+			return false, err
+		}
+		if uint64(memberStatus.AppliedIndex)+getUpgradeMaxLag(cluster) < uint64(leaderStatus.AppliedIndex) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// transferLeadershipFromPod requests a leadership transfer for each group led by a member on the given pod,
+// returning true if any transfer was requested
+func (r *MultiRaftClusterReconciler) transferLeadershipFromPod(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, podName string) (bool, error) {
+	members, err := r.getPodMembers(ctx, cluster, podName)
+	if err != nil {
+		return false, err
+	}
+
+	transferred := false
+	for _, member := range members {
+		group := &consensusv1beta1.RaftGroup{}
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: member.Namespace, Name: member.Labels[raftGroupKey]}, group); err != nil {
+			return false, err
+		}
+		if group.Status.Leader == nil || group.Status.Leader.Name != member.Name {
+			continue
+		}
+
+		groupID, err := strconv.Atoi(member.Labels[raftPartitionKey])
+		if err != nil {
+			return false, err
+		}
+
+		peers, err := r.getMembers(ctx, group)
+		if err != nil {
+			return false, err
+		}
+
+		for _, peerID := range getMemberIDs(peers) {
+			peer := peers[peerID]
+			if peer.Spec.Pod.Name == podName ||
+				peer.Spec.Type != consensusv1beta1.RaftVotingMember ||
+				peer.Status.State != consensusv1beta1.RaftMemberReady {
+				continue
+			}
+
+			conn, err := r.connect(ctx, &member)
+			if err != nil {
+				return false, err
+			}
+			client := consensus.NewNodeClient(conn)
+			request := &consensus.TransferLeadershipRequest{
+				GroupID:        consensus.GroupID(groupID),
+				TargetMemberID: consensus.MemberID(peerID),
+			}
+			_, err = client.TransferLeadership(ctx, request)
+			conn.Close()
+			if err != nil {
+				return false, err
+			}
+			r.events.Eventf(group, "Normal", "LeadershipTransferred", "Transferring leadership from %s to %s for upgrade of pod %s", member.Name, peer.Name, podName)
+			transferred = true
+			break
+		}
+	}
+	return transferred, nil
+}
+
+// getPodMembers returns the members of the cluster hosted by the given pod
+func (r *MultiRaftClusterReconciler) getPodMembers(ctx context.Context, cluster *consensusv1beta1.MultiRaftCluster, podName string) ([]consensusv1beta1.RaftMember, error) {
+	memberList := &consensusv1beta1.RaftMemberList{}
+	if err := r.client.List(ctx, memberList, client.InNamespace(cluster.Namespace), client.MatchingLabels{multiRaftClusterKey: cluster.Name}); err != nil {
+		return nil, err
+	}
+	var members []consensusv1beta1.RaftMember
+	for _, member := range memberList.Items {
+		if member.Spec.Pod.Name == podName {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+// getMemberStatus returns the status of the given group reported by the node hosting the given member
+func (r *MultiRaftClusterReconciler) getMemberStatus(ctx context.Context, member *consensusv1beta1.RaftMember, groupID int) (*consensus.GroupStatus, error) {
+	conn, err := r.connect(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := consensus.NewNodeClient(conn)
+	response, err := client.GetGroupStatus(ctx, &consensus.GetGroupStatusRequest{GroupID: consensus.GroupID(groupID)})
+	if err != nil {
+		return nil, err
+	}
+	return &response.Group, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func getUpgradeMaxLag(cluster *consensusv1beta1.MultiRaftCluster) uint64 {
+	if cluster.Spec.UpgradeStrategy == nil || cluster.Spec.UpgradeStrategy.MaxLag == nil || *cluster.Spec.UpgradeStrategy.MaxLag < 0 {
+		return defaultUpgradeMaxLag
+	}
+	return uint64(*cluster.Spec.UpgradeStrategy.MaxLag)
+}
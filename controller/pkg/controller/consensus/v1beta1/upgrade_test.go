@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestGetUpgradeMaxLag(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy *consensusv1beta1.MultiRaftUpgradeStrategy
+		maxLag   uint64
+	}{
+		{
+			name:   "no strategy",
+			maxLag: defaultUpgradeMaxLag,
+		},
+		{
+			name:     "no max lag",
+			strategy: &consensusv1beta1.MultiRaftUpgradeStrategy{},
+			maxLag:   defaultUpgradeMaxLag,
+		},
+		{
+			name:     "max lag",
+			strategy: &consensusv1beta1.MultiRaftUpgradeStrategy{MaxLag: pointer.Int64Ptr(50000)},
+			maxLag:   50000,
+		},
+		{
+			name:     "zero max lag",
+			strategy: &consensusv1beta1.MultiRaftUpgradeStrategy{MaxLag: pointer.Int64Ptr(0)},
+			maxLag:   0,
+		},
+		{
+			name:     "negative max lag",
+			strategy: &consensusv1beta1.MultiRaftUpgradeStrategy{MaxLag: pointer.Int64Ptr(-1)},
+			maxLag:   defaultUpgradeMaxLag,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cluster := newTestCluster(3, nil, nil)
+			cluster.Spec.UpgradeStrategy = test.strategy
+			if maxLag := getUpgradeMaxLag(cluster); maxLag != test.maxLag {
+				t.Errorf("expected %d, got %d", test.maxLag, maxLag)
+			}
+		})
+	}
+}
+
+func TestReconcileUpgrade(t *testing.T) {
+	cluster := newTestCluster(3, nil, nil)
+	cluster.Namespace = testNamespace
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: pointer.Int32Ptr(3),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: pointer.Int32Ptr(3),
+				},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			UpdateRevision: "2",
+		},
+	}
+	r, nodes := newNodeReconciler(t, cluster, 1, statefulSet)
+	for _, node := range nodes {
+		node.setGroupStatus(consensus.GroupStatus{GroupID: 1, AppliedIndex: 5000})
+	}
+	// The group is led by the member on the last pod, which is upgraded first
+	setTestLeader(t, r, cluster.Namespace, "raft-1", "raft-1-3")
+
+	reconcileUpgrade := func() reconcile.Result {
+		result, err := r.reconcileUpgrade(context.TODO(), getTestCluster(t, r, cluster))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	expectPartition := func(partition int32) {
+		stored := &appsv1.StatefulSet{}
+		if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, stored); err != nil {
+			t.Fatal(err)
+		}
+		if *stored.Spec.UpdateStrategy.RollingUpdate.Partition != partition {
+			t.Fatalf("expected partition %d, got %d", partition, *stored.Spec.UpdateStrategy.RollingUpdate.Partition)
+		}
+	}
+
+	if result := reconcileUpgrade(); !result.Requeue {
+		t.Fatal("expected requeue once the upgrade is started")
+	}
+	upgrade := getTestCluster(t, r, cluster).Status.Upgrade
+	if upgrade == nil || upgrade.Revision != "2" || upgrade.StartTime == nil {
+		t.Fatalf("expected upgrade to revision 2 to be started, got %v", upgrade)
+	}
+
+	// Leadership is moved off the pod before it's released for upgrade
+	if result := reconcileUpgrade(); result.RequeueAfter != upgradeRetryInterval {
+		t.Fatal("expected retry while leadership is transferred")
+	}
+	requests := nodes[2].getRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request to the leader's node, got %d", len(requests))
+	}
+	if request, ok := requests[0].(*consensus.TransferLeadershipRequest); !ok || request.GroupID != 1 || request.TargetMemberID != 1 {
+		t.Fatalf("expected leadership to be transferred to member 1, got %v", requests[0])
+	}
+	expectPartition(3)
+
+	setTestLeader(t, r, cluster.Namespace, "raft-1", "raft-1-1")
+	if result := reconcileUpgrade(); !result.Requeue {
+		t.Fatal("expected requeue once the pod is released for upgrade")
+	}
+	expectPartition(2)
+	upgrade = getTestCluster(t, r, cluster).Status.Upgrade
+	if upgrade.Pod != "raft-2" || upgrade.UpdatedReplicas != 1 {
+		t.Fatalf("expected pod raft-2 to be upgrading with 1 updated replica, got %s with %d", upgrade.Pod, upgrade.UpdatedReplicas)
+	}
+
+	// The next pod is not released until the upgraded pod has caught up with the leader
+	if result := reconcileUpgrade(); result.RequeueAfter != upgradeRetryInterval {
+		t.Fatal("expected retry while the pod is running the old revision")
+	}
+	pod := &corev1.Pod{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cluster.Namespace, Name: "raft-2"}, pod); err != nil {
+		t.Fatal(err)
+	}
+	pod.Labels = map[string]string{
+		appsv1.ControllerRevisionHashLabelKey: "2",
+	}
+	if err := r.client.Update(context.TODO(), pod); err != nil {
+		t.Fatal(err)
+	}
+	nodes[2].setGroupStatus(consensus.GroupStatus{GroupID: 1, AppliedIndex: 3000})
+	if result := reconcileUpgrade(); result.RequeueAfter != upgradeRetryInterval {
+		t.Fatal("expected retry while the pod lags the leader")
+	}
+	expectPartition(2)
+
+	nodes[2].setGroupStatus(consensus.GroupStatus{GroupID: 1, AppliedIndex: 4500})
+	if result := reconcileUpgrade(); !result.Requeue {
+		t.Fatal("expected requeue once the next pod is released for upgrade")
+	}
+	expectPartition(1)
+	upgrade = getTestCluster(t, r, cluster).Status.Upgrade
+	if upgrade.Pod != "raft-1" || upgrade.UpdatedReplicas != 2 {
+		t.Fatalf("expected pod raft-1 to be upgrading with 2 updated replicas, got %s with %d", upgrade.Pod, upgrade.UpdatedReplicas)
+	}
+	if requests := nodes[0].getRequests(); len(requests) != 0 {
+		t.Errorf("expected no leadership transfer from a pod not being upgraded, got %v", requests)
+	}
+}
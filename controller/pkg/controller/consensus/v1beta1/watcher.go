@@ -9,11 +9,8 @@ import (
 	"fmt"
 	"github.com/atomix/consensus-storage/node/pkg/consensus"
 	"github.com/atomix/runtime/sdk/pkg/errors"
-	"github.com/atomix/runtime/sdk/pkg/grpc/retry"
 	"github.com/cenkalti/backoff"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"io"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +31,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// watchEventTypes are the types of node events recorded by the controller
+var watchEventTypes = []consensus.EventType{
+	consensus.EventType_MEMBER_READY,
+	consensus.EventType_LEADER_UPDATED,
+	consensus.EventType_MEMBERSHIP_CHANGED,
+	consensus.EventType_SEND_SNAPSHOT_STARTED,
+	consensus.EventType_SEND_SNAPSHOT_COMPLETED,
+	consensus.EventType_SEND_SNAPSHOT_ABORTED,
+	consensus.EventType_SNAPSHOT_RECEIVED,
+	consensus.EventType_SNAPSHOT_RECOVERED,
+	consensus.EventType_SNAPSHOT_CREATED,
+	consensus.EventType_SNAPSHOT_COMPACTED,
+	consensus.EventType_LOG_COMPACTED,
+	consensus.EventType_LOGDB_COMPACTED,
+	consensus.EventType_MEMBER_UNLOADED,
+	consensus.EventType_HOST_SHUTTING_DOWN,
+}
+
 func addPodController(mgr manager.Manager) error {
 	options := controller.Options{
 		Reconciler: &PodReconciler{
@@ -113,7 +128,15 @@ func (r *PodReconciler) reconcileCreate(ctx context.Context, pod *corev1.Pod) er
 		Namespace: pod.Namespace,
 		Name:      store,
 	}
-	if err := r.watch(storeName, address); err != nil {
+	cluster := &consensusv1beta1.MultiRaftCluster{}
+	if err := r.client.Get(ctx, storeName, cluster); err != nil {
+		return err
+	}
+	opts, err := getDialOptions(ctx, r.client, cluster, "")
+	if err != nil {
+		return err
+	}
+	if err := r.watch(storeName, address, opts...); err != nil {
 		return err
 	}
 	return nil
@@ -141,7 +164,7 @@ func (r *PodReconciler) reconcileDelete(ctx context.Context, pod *corev1.Pod) er
 	return nil
 }
 
-func (r *PodReconciler) watch(storeName types.NamespacedName, address string) error {
+func (r *PodReconciler) watch(storeName types.NamespacedName, address string, opts ...grpc.DialOption) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -160,237 +183,304 @@ func (r *PodReconciler) watch(storeName types.NamespacedName, address string) er
 		}()
 
 		log.Infof("Creating new Watch for %s", address)
-		conn, err := grpc.Dial(
-			address,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-			grpc.WithStreamInterceptor(retry.RetryingStreamClientInterceptor(retry.WithRetryOn(codes.Unavailable))))
+		conn, err := grpc.Dial(address, opts...)
 		if err != nil {
 			log.Error(err)
 			return
 		}
+		defer conn.Close()
 
 		client := consensus.NewNodeClient(conn)
 
-		request := &consensus.WatchRequest{}
-		stream, err := client.Watch(ctx, request)
-		if err != nil {
-			log.Error(err)
-			return
-		}
-
+		// When the stream fails, the watch is resumed following the last event received to avoid losing events
+		// published while the watch was reconnecting
+		var sequence uint64
+		retries := backoff.NewExponentialBackOff()
+		retries.MaxElapsedTime = 0
 		for {
-			event, err := stream.Recv()
-			if err == io.EOF {
+			lastSequence := sequence
+			err := r.watchEvents(ctx, client, storeName, address, &sequence)
+			if err == nil {
 				log.Debugf("Watch for %s complete", address)
 				return
 			}
-			if err != nil {
-				err = errors.FromProto(err)
-				if errors.IsCanceled(err) {
-					log.Warnf("Watch for %s canceled", address)
-					return
-				}
-				log.Error(err)
-			} else {
-				log.Infof("Received event %+v from %s", event, address)
-				timestamp := metav1.NewTime(event.Timestamp)
-				switch e := event.Event.(type) {
-				case *consensus.Event_MemberReady:
-					r.recordMemberEvent(ctx, storeName, e.MemberReady.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							if status.State != consensusv1beta1.RaftMemberReady {
-								status.State = consensusv1beta1.RaftMemberReady
-								status.LastUpdated = &timestamp
-								return true
-							}
-							return false
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "StateChanged", "Member is ready")
-						})
-					r.recordGroupEvent(ctx, storeName, e.MemberReady.GroupID,
-						func(status *consensusv1beta1.RaftGroupStatus) bool {
-							memberName := corev1.LocalObjectReference{
-								Name: fmt.Sprintf("%s-%d-%d", storeName.Name, e.MemberReady.GroupID, e.MemberReady.MemberID),
-							}
-							if status.Leader != nil && status.Leader.Name == memberName.Name {
-								return false
-							}
-							for _, follower := range status.Followers {
-								if follower.Name == memberName.Name {
-									return false
-								}
-							}
-							status.Followers = append(status.Followers, memberName)
-							return true
-						}, func(group *consensusv1beta1.RaftGroup) {})
-				case *consensus.Event_LeaderUpdated:
-					r.recordMemberEvent(ctx, storeName, e.LeaderUpdated.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							term := uint64(e.LeaderUpdated.Term)
-							if status.Term == nil || *status.Term < term || (*status.Term == term && status.Leader == nil && e.LeaderUpdated.Leader != 0) {
-								role := consensusv1beta1.RaftFollower
-								if e.LeaderUpdated.Leader == 0 {
-									status.Leader = nil
-								} else {
-									status.Leader = &corev1.LocalObjectReference{
-										Name: fmt.Sprintf("%s-%d-%d", storeName.Name, e.LeaderUpdated.GroupID, e.LeaderUpdated.Leader),
-									}
-									if e.LeaderUpdated.Leader == e.LeaderUpdated.MemberID {
-										role = consensusv1beta1.RaftLeader
-									}
-								}
-								status.Term = &term
-								status.Role = &role
-								status.LastUpdated = &timestamp
-								return true
-							}
-							return false
-						}, func(member *consensusv1beta1.RaftMember) {
-							if member.Status.Role != nil && *member.Status.Role == consensusv1beta1.RaftLeader {
-								r.events.Eventf(member, "Normal", "ElectedLeader", "Elected leader for term %d", e.LeaderUpdated.Term)
-							}
-						})
-					r.recordGroupEvent(ctx, storeName, e.LeaderUpdated.MemberEvent.GroupID,
-						func(status *consensusv1beta1.RaftGroupStatus) bool {
-							term := uint64(e.LeaderUpdated.Term)
-							if status.Term == nil || *status.Term < term || (*status.Term == term && status.Leader == nil && e.LeaderUpdated.Leader != 0) {
-								var leader *corev1.LocalObjectReference
-								if e.LeaderUpdated.Leader != 0 {
-									leader = &corev1.LocalObjectReference{
-										Name: fmt.Sprintf("%s-%d-%d", storeName.Name, e.LeaderUpdated.GroupID, e.LeaderUpdated.Leader),
-									}
-								}
-								if status.Leader != nil && (leader == nil || status.Leader.Name != leader.Name) {
-									status.Followers = append(status.Followers, *status.Leader)
-								}
-								var followers []corev1.LocalObjectReference
-								for _, follower := range status.Followers {
-									if leader == nil || follower.Name != leader.Name {
-										followers = append(followers, follower)
-									}
-								}
-								status.Term = &term
-								status.Leader = leader
-								status.Followers = followers
-								return true
-							}
-							return false
-						}, func(group *consensusv1beta1.RaftGroup) {
-							if group.Status.Leader != nil {
-								r.events.Eventf(group, "Normal", "LeaderChanged", "%s elected leader for term %d", group.Status.Leader.Name, e.LeaderUpdated.Term)
-							} else {
-								r.events.Eventf(group, "Normal", "TermChanged", "Term changed to %d", e.LeaderUpdated.Term)
-							}
-						})
-				case *consensus.Event_MembershipChanged:
-					r.recordMemberEvent(ctx, storeName, e.MembershipChanged.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							return true
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "MembershipChanged", "Membership changed")
-						})
-				case *consensus.Event_SendSnapshotStarted:
-					r.recordMemberEvent(ctx, storeName, e.SendSnapshotStarted.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							return true
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SendSnapshotStared", "Started sending snapshot at index %d to %s-%d-%d",
-								e.SendSnapshotStarted.Index, storeName.Name, e.SendSnapshotStarted.GroupID, e.SendSnapshotStarted.To)
-						})
-				case *consensus.Event_SendSnapshotCompleted:
-					r.recordMemberEvent(ctx, storeName, e.SendSnapshotCompleted.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							return true
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SendSnapshotCompleted", "Completed sending snapshot at index %d to %s-%d-%d",
-								e.SendSnapshotCompleted.Index, storeName.Name, e.SendSnapshotCompleted.GroupID, e.SendSnapshotCompleted.To)
-						})
-				case *consensus.Event_SendSnapshotAborted:
-					r.recordMemberEvent(ctx, storeName, e.SendSnapshotAborted.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							return true
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SendSnapshotAborted", "Aborted sending snapshot at index %d to %s-%d-%d",
-								e.SendSnapshotAborted.Index, storeName.Name, e.SendSnapshotAborted.GroupID, e.SendSnapshotAborted.To)
-						})
-				case *consensus.Event_SnapshotReceived:
-					index := uint64(e.SnapshotReceived.Index)
-					r.recordMemberEvent(ctx, storeName, e.SnapshotReceived.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
-								status.LastUpdated = &timestamp
-								status.LastSnapshotTime = &timestamp
-								status.LastSnapshotIndex = &index
-								return true
-							}
-							return false
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SnapshotReceived", "Snapshot received from %s-%d-%d at index %d",
-								storeName.Name, e.SnapshotReceived.GroupID, e.SnapshotReceived.From, e.SnapshotReceived.Index)
-						})
-				case *consensus.Event_SnapshotRecovered:
-					index := uint64(e.SnapshotRecovered.Index)
-					r.recordMemberEvent(ctx, storeName, e.SnapshotRecovered.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
-								status.LastUpdated = &timestamp
-								status.LastSnapshotTime = &timestamp
-								status.LastSnapshotIndex = &index
-								return true
-							}
-							return false
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SnapshotRecovered", "Recovered from snapshot at index %d", e.SnapshotRecovered.Index)
-						})
-				case *consensus.Event_SnapshotCreated:
-					index := uint64(e.SnapshotCreated.Index)
-					r.recordMemberEvent(ctx, storeName, e.SnapshotCreated.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
-								status.LastUpdated = &timestamp
-								status.LastSnapshotTime = &timestamp
-								status.LastSnapshotIndex = &index
-								return true
-							}
-							return false
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SnapshotCreated", "Created snapshot at index %d", e.SnapshotCreated.Index)
-						})
-				case *consensus.Event_SnapshotCompacted:
-					index := uint64(e.SnapshotCompacted.Index)
-					r.recordMemberEvent(ctx, storeName, e.SnapshotCompacted.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
-								status.LastUpdated = &timestamp
-								status.LastSnapshotTime = &timestamp
-								status.LastSnapshotIndex = &index
-								return true
-							}
-							return false
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "SnapshotCompacted", "Compacted snapshot at index %d", e.SnapshotCompacted.Index)
-						})
-				case *consensus.Event_LogCompacted:
-					r.recordMemberEvent(ctx, storeName, e.LogCompacted.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							return true
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "LogCompacted", "Compacted log at index %d", e.LogCompacted.Index)
-						})
-				case *consensus.Event_LogdbCompacted:
-					r.recordMemberEvent(ctx, storeName, e.LogdbCompacted.MemberEvent,
-						func(status *consensusv1beta1.RaftMemberStatus) bool {
-							return true
-						}, func(member *consensusv1beta1.RaftMember) {
-							r.events.Eventf(member, "Normal", "LogCompacted", "Compacted log at index %d", e.LogdbCompacted.Index)
-						})
-				}
+			if errors.IsCanceled(err) || ctx.Err() != nil {
+				log.Warnf("Watch for %s canceled", address)
+				return
+			}
+			log.Error(err)
+			if sequence != lastSequence {
+				retries.Reset()
+			}
+			select {
+			case <-time.After(retries.NextBackOff()):
+				log.Infof("Resuming Watch for %s from event %d", address, sequence)
+			case <-ctx.Done():
+				return
 			}
 		}
 	}()
 	return nil
 }
 
+// watchEvents records the events received from the node at the given address following the given sequence
+// number, updating the sequence number as events are received. A nil error is returned when the node closes
+// the stream.
+func (r *PodReconciler) watchEvents(ctx context.Context, client consensus.NodeClient, storeName types.NamespacedName, address string, sequence *uint64) error {
+	request := &consensus.WatchRequest{
+		EventTypes:   watchEventTypes,
+		FromSequence: *sequence,
+	}
+	stream, err := client.Watch(ctx, request)
+	if err != nil {
+		return errors.FromProto(err)
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.FromProto(err)
+		}
+		if resync, ok := event.Event.(*consensus.Event_Resync); ok {
+			if resync.Resync.FullState {
+				// The watch could not be resumed, e.g. because the node was restarted, and the current state of
+				// each group follows, so the watch continues from the node's current sequence number
+				log.Infof("Watch for %s could not be resumed from event %d", address, *sequence)
+				*sequence = event.Sequence
+				continue
+			}
+			// The node dropped events that did not fit in the watch's queue, which are recovered by resuming
+			// the watch from the last event received
+			return errors.NewUnavailable("Watch for %s fell behind at event %d", address, *sequence)
+		}
+		// Sequence numbers are only ordered within a stream, as they're not ordered across restarts of the node
+		*sequence = event.Sequence
+		r.recordEvent(ctx, storeName, address, event)
+	}
+}
+
+func (r *PodReconciler) recordEvent(ctx context.Context, storeName types.NamespacedName, address string, event *consensus.Event) {
+	log.Infof("Received event %+v from %s", event, address)
+	timestamp := metav1.NewTime(event.Timestamp)
+	switch e := event.Event.(type) {
+	case *consensus.Event_MemberReady:
+		r.recordMemberEvent(ctx, storeName, e.MemberReady.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				if status.State != consensusv1beta1.RaftMemberReady {
+					status.State = consensusv1beta1.RaftMemberReady
+					status.LastUpdated = &timestamp
+					return true
+				}
+				return false
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "StateChanged", "Member is ready")
+			})
+		r.recordGroupEvent(ctx, storeName, e.MemberReady.GroupID,
+			func(status *consensusv1beta1.RaftGroupStatus) bool {
+				memberName := corev1.LocalObjectReference{
+					Name: fmt.Sprintf("%s-%d-%d", storeName.Name, e.MemberReady.GroupID, e.MemberReady.MemberID),
+				}
+				if status.Leader != nil && status.Leader.Name == memberName.Name {
+					return false
+				}
+				for _, follower := range status.Followers {
+					if follower.Name == memberName.Name {
+						return false
+					}
+				}
+				status.Followers = append(status.Followers, memberName)
+				return true
+			}, func(group *consensusv1beta1.RaftGroup) {})
+	case *consensus.Event_LeaderUpdated:
+		r.recordMemberEvent(ctx, storeName, e.LeaderUpdated.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				term := uint64(e.LeaderUpdated.Term)
+				if status.Term == nil || *status.Term < term || (*status.Term == term && status.Leader == nil && e.LeaderUpdated.Leader != 0) {
+					role := consensusv1beta1.RaftFollower
+					if e.LeaderUpdated.Leader == 0 {
+						status.Leader = nil
+					} else {
+						status.Leader = &corev1.LocalObjectReference{
+							Name: fmt.Sprintf("%s-%d-%d", storeName.Name, e.LeaderUpdated.GroupID, e.LeaderUpdated.Leader),
+						}
+						if e.LeaderUpdated.Leader == e.LeaderUpdated.MemberID {
+							role = consensusv1beta1.RaftLeader
+						}
+					}
+					status.Term = &term
+					status.Role = &role
+					status.LastUpdated = &timestamp
+					return true
+				}
+				return false
+			}, func(member *consensusv1beta1.RaftMember) {
+				if member.Status.Role != nil && *member.Status.Role == consensusv1beta1.RaftLeader {
+					r.events.Eventf(member, "Normal", "ElectedLeader", "Elected leader for term %d", e.LeaderUpdated.Term)
+				}
+			})
+		r.recordGroupEvent(ctx, storeName, e.LeaderUpdated.MemberEvent.GroupID,
+			func(status *consensusv1beta1.RaftGroupStatus) bool {
+				term := uint64(e.LeaderUpdated.Term)
+				if status.Term == nil || *status.Term < term || (*status.Term == term && status.Leader == nil && e.LeaderUpdated.Leader != 0) {
+					var leader *corev1.LocalObjectReference
+					if e.LeaderUpdated.Leader != 0 {
+						leader = &corev1.LocalObjectReference{
+							Name: fmt.Sprintf("%s-%d-%d", storeName.Name, e.LeaderUpdated.GroupID, e.LeaderUpdated.Leader),
+						}
+					}
+					if status.Leader != nil && (leader == nil || status.Leader.Name != leader.Name) {
+						status.Followers = append(status.Followers, *status.Leader)
+					}
+					var followers []corev1.LocalObjectReference
+					for _, follower := range status.Followers {
+						if leader == nil || follower.Name != leader.Name {
+							followers = append(followers, follower)
+						}
+					}
+					status.Term = &term
+					status.Leader = leader
+					status.Followers = followers
+					return true
+				}
+				return false
+			}, func(group *consensusv1beta1.RaftGroup) {
+				if group.Status.Leader != nil {
+					r.events.Eventf(group, "Normal", "LeaderChanged", "%s elected leader for term %d", group.Status.Leader.Name, e.LeaderUpdated.Term)
+				} else {
+					r.events.Eventf(group, "Normal", "TermChanged", "Term changed to %d", e.LeaderUpdated.Term)
+				}
+			})
+	case *consensus.Event_MembershipChanged:
+		r.recordMemberEvent(ctx, storeName, e.MembershipChanged.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				return true
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "MembershipChanged", "Membership changed")
+			})
+	case *consensus.Event_SendSnapshotStarted:
+		r.recordMemberEvent(ctx, storeName, e.SendSnapshotStarted.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				return true
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SendSnapshotStared", "Started sending snapshot at index %d to %s-%d-%d",
+					e.SendSnapshotStarted.Index, storeName.Name, e.SendSnapshotStarted.GroupID, e.SendSnapshotStarted.To)
+			})
+	case *consensus.Event_SendSnapshotCompleted:
+		r.recordMemberEvent(ctx, storeName, e.SendSnapshotCompleted.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				return true
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SendSnapshotCompleted", "Completed sending snapshot at index %d to %s-%d-%d",
+					e.SendSnapshotCompleted.Index, storeName.Name, e.SendSnapshotCompleted.GroupID, e.SendSnapshotCompleted.To)
+			})
+	case *consensus.Event_SendSnapshotAborted:
+		r.recordMemberEvent(ctx, storeName, e.SendSnapshotAborted.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				return true
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SendSnapshotAborted", "Aborted sending snapshot at index %d to %s-%d-%d",
+					e.SendSnapshotAborted.Index, storeName.Name, e.SendSnapshotAborted.GroupID, e.SendSnapshotAborted.To)
+			})
+	case *consensus.Event_SnapshotReceived:
+		index := uint64(e.SnapshotReceived.Index)
+		r.recordMemberEvent(ctx, storeName, e.SnapshotReceived.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
+					status.LastUpdated = &timestamp
+					status.LastSnapshotTime = &timestamp
+					status.LastSnapshotIndex = &index
+					return true
+				}
+				return false
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SnapshotReceived", "Snapshot received from %s-%d-%d at index %d",
+					storeName.Name, e.SnapshotReceived.GroupID, e.SnapshotReceived.From, e.SnapshotReceived.Index)
+			})
+	case *consensus.Event_SnapshotRecovered:
+		index := uint64(e.SnapshotRecovered.Index)
+		r.recordMemberEvent(ctx, storeName, e.SnapshotRecovered.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
+					status.LastUpdated = &timestamp
+					status.LastSnapshotTime = &timestamp
+					status.LastSnapshotIndex = &index
+					return true
+				}
+				return false
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SnapshotRecovered", "Recovered from snapshot at index %d", e.SnapshotRecovered.Index)
+			})
+	case *consensus.Event_SnapshotCreated:
+		index := uint64(e.SnapshotCreated.Index)
+		r.recordMemberEvent(ctx, storeName, e.SnapshotCreated.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
+					status.LastUpdated = &timestamp
+					status.LastSnapshotTime = &timestamp
+					status.LastSnapshotIndex = &index
+					return true
+				}
+				return false
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SnapshotCreated", "Created snapshot at index %d", e.SnapshotCreated.Index)
+			})
+	case *consensus.Event_SnapshotCompacted:
+		index := uint64(e.SnapshotCompacted.Index)
+		r.recordMemberEvent(ctx, storeName, e.SnapshotCompacted.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				if index > 0 && (status.LastSnapshotIndex == nil || index > *status.LastSnapshotIndex) {
+					status.LastUpdated = &timestamp
+					status.LastSnapshotTime = &timestamp
+					status.LastSnapshotIndex = &index
+					return true
+				}
+				return false
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "SnapshotCompacted", "Compacted snapshot at index %d", e.SnapshotCompacted.Index)
+			})
+	case *consensus.Event_LogCompacted:
+		r.recordMemberEvent(ctx, storeName, e.LogCompacted.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				return true
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "LogCompacted", "Compacted log at index %d", e.LogCompacted.Index)
+			})
+	case *consensus.Event_LogdbCompacted:
+		r.recordMemberEvent(ctx, storeName, e.LogdbCompacted.MemberEvent,
+			func(status *consensusv1beta1.RaftMemberStatus) bool {
+				return true
+			}, func(member *consensusv1beta1.RaftMember) {
+				r.events.Eventf(member, "Normal", "LogCompacted", "Compacted log at index %d", e.LogdbCompacted.Index)
+			})
+	case *consensus.Event_MemberUnloaded:
+		r.recordMemberNotReady(ctx, storeName, e.MemberUnloaded.MemberEvent, timestamp, "Member was stopped")
+	case *consensus.Event_HostShuttingDown:
+		for _, member := range e.HostShuttingDown.Members {
+			r.recordMemberNotReady(ctx, storeName, member, timestamp, "Node is shutting down")
+		}
+	}
+}
+
+// recordMemberNotReady marks the given member NotReady and clears its role when the member is stopped,
+// without waiting for the node's container to be restarted
+func (r *PodReconciler) recordMemberNotReady(ctx context.Context, storeName types.NamespacedName, event consensus.MemberEvent, timestamp metav1.Time, reason string) {
+	r.recordMemberEvent(ctx, storeName, event,
+		func(status *consensusv1beta1.RaftMemberStatus) bool {
+			if status.State != consensusv1beta1.RaftMemberNotReady || status.Role != nil {
+				status.State = consensusv1beta1.RaftMemberNotReady
+				status.Role = nil
+				status.LastUpdated = &timestamp
+				return true
+			}
+			return false
+		}, func(member *consensusv1beta1.RaftMember) {
+			r.events.Eventf(member, "Normal", "StateChanged", "%s; state changed to %s", reason, member.Status.State)
+		})
+}
+
 func (r *PodReconciler) recordMemberEvent(ctx context.Context,
 	storeName types.NamespacedName, event consensus.MemberEvent,
 	updater func(*consensusv1beta1.RaftMemberStatus) bool, recorder func(*consensusv1beta1.RaftMember)) {
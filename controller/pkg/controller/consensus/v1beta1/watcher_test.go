@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	consensusv1beta1 "github.com/atomix/consensus-storage/controller/pkg/apis/consensus/v1beta1"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRecordMemberNotReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    consensus.Event
+		notReady []int
+		events   int
+	}{
+		{
+			name: "member unloaded",
+			event: consensus.Event{
+				Event: &consensus.Event_MemberUnloaded{
+					MemberUnloaded: &consensus.MemberUnloadedEvent{
+						MemberEvent: consensus.MemberEvent{GroupID: 1, MemberID: 2},
+					},
+				},
+			},
+			notReady: []int{2},
+			events:   1,
+		},
+		{
+			name: "host shutting down",
+			event: consensus.Event{
+				Event: &consensus.Event_HostShuttingDown{
+					HostShuttingDown: &consensus.HostShuttingDownEvent{
+						Members: []consensus.MemberEvent{
+							{GroupID: 1, MemberID: 1},
+							{GroupID: 1, MemberID: 3},
+						},
+					},
+				},
+			},
+			notReady: []int{1, 3},
+			events:   2,
+		},
+		{
+			name: "member already stopped",
+			event: consensus.Event{
+				Event: &consensus.Event_MemberUnloaded{
+					MemberUnloaded: &consensus.MemberUnloadedEvent{
+						MemberEvent: consensus.MemberEvent{GroupID: 1, MemberID: 4},
+					},
+				},
+			},
+		},
+		{
+			name: "unknown member",
+			event: consensus.Event{
+				Event: &consensus.Event_MemberUnloaded{
+					MemberUnloaded: &consensus.MemberUnloadedEvent{
+						MemberEvent: consensus.MemberEvent{GroupID: 2, MemberID: 1},
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			leader := consensusv1beta1.RaftLeader
+			var objects []client.Object
+			for memberID := 1; memberID <= 4; memberID++ {
+				member := newTestMember(fmt.Sprintf("raft-%d", memberID-1), consensusv1beta1.RaftVotingMember)
+				member.Namespace = testNamespace
+				member.Name = fmt.Sprintf("raft-1-%d", memberID)
+				member.Status.State = consensusv1beta1.RaftMemberReady
+				member.Status.Role = &leader
+				// Member 4 has already been marked stopped
+				if memberID == 4 {
+					member.Status.State = consensusv1beta1.RaftMemberNotReady
+					member.Status.Role = nil
+				}
+				objects = append(objects, member)
+			}
+			events := record.NewFakeRecorder(10)
+			r := &PodReconciler{
+				client: newSecretReconciler(t, objects...).client,
+				events: events,
+			}
+
+			// Status timestamps are stored at a resolution of seconds
+			test.event.Timestamp = time.Now().Truncate(time.Second)
+			r.recordEvent(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: "raft"}, "127.0.1.1:5678", &test.event)
+
+			notReady := map[int]bool{4: true}
+			for _, memberID := range test.notReady {
+				notReady[memberID] = true
+			}
+			for memberID := 1; memberID <= 4; memberID++ {
+				member := &consensusv1beta1.RaftMember{}
+				if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: fmt.Sprintf("raft-1-%d", memberID)}, member); err != nil {
+					t.Fatal(err)
+				}
+				if notReady[memberID] {
+					if member.Status.State != consensusv1beta1.RaftMemberNotReady || member.Status.Role != nil {
+						t.Errorf("expected member %d to be NotReady with no role, got %s", memberID, member.Status.State)
+					}
+				} else if member.Status.State != consensusv1beta1.RaftMemberReady || member.Status.Role == nil {
+					t.Errorf("expected member %d to be unchanged, got %s", memberID, member.Status.State)
+				}
+				if memberID != 4 && notReady[memberID] && !member.Status.LastUpdated.Equal(&metav1.Time{Time: test.event.Timestamp}) {
+					t.Errorf("expected member %d to be updated at the time of the event, got %v", memberID, member.Status.LastUpdated)
+				}
+			}
+			if len(events.Events) != test.events {
+				t.Errorf("expected %d events, got %d", test.events, len(events.Events))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/runtime"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ConnConfig is the driver configuration for a connection to the store, provided alongside the
+// protocol configuration
+type ConnConfig struct {
+	// TLS configures TLS for connections to the store's nodes. If unset, connections are not encrypted.
+	TLS *TLSConfig `json:"tls,omitempty"`
+}
+
+// TLSConfig configures TLS for connections to the store's nodes
+type TLSConfig struct {
+	// CACert is the PEM encoded CA certificate with which to verify the nodes' certificates
+	CACert string `json:"caCert"`
+}
+
+// getTransportCredentials returns the credentials with which to connect to the nodes of the given store
+func getTransportCredentials(spec runtime.ConnSpec) (credentials.TransportCredentials, error) {
+	var config ConnConfig
+	if err := spec.UnmarshalConfig(&config); err != nil {
+		return nil, errors.NewInvalid("invalid configuration for store %s: %v", spec.Name, err)
+	}
+	if config.TLS == nil {
+		return insecure.NewCredentials(), nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(config.TLS.CACert)) {
+		return nil, errors.NewInvalid("invalid CA certificate for store %s", spec.Name)
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+	}), nil
+}
+
+// ReadPolicy is the policy with which the queries of a primitive are served
+type ReadPolicy string
+
+const (
+	// LinearizableReadPolicy serves queries from the leader after confirming its leadership with a quorum
+	LinearizableReadPolicy ReadPolicy = "linearizable"
+	// LeaderReadPolicy serves queries from the local state of the leader
+	LeaderReadPolicy ReadPolicy = "leader"
+	// BoundedStalenessReadPolicy serves queries from followers and observers that have applied the log
+	// to within MaxLag entries of the index applied by the leader when the query is received
+	BoundedStalenessReadPolicy ReadPolicy = "bounded-staleness"
+)
+
+// PrimitiveConfig is the driver configuration for a primitive
+type PrimitiveConfig struct {
+	// ReadPolicy is the policy with which the primitive's queries are served. Defaults to LeaderReadPolicy.
+	ReadPolicy ReadPolicy `json:"readPolicy,omitempty"`
+	// MaxLag is the maximum number of Raft entries by which a bounded-staleness query may lag behind
+	// the index applied by the leader when the query is received
+	MaxLag uint64 `json:"maxLag,omitempty"`
+}
+
+// getPrimitiveConfig returns the driver configuration for the given primitive
+func getPrimitiveConfig(spec runtime.PrimitiveSpec) (PrimitiveConfig, error) {
+	var config PrimitiveConfig
+	if len(spec.Config) > 0 {
+		if err := spec.UnmarshalConfig(&config); err != nil {
+			return config, errors.NewInvalid("invalid configuration for primitive %s: %v", spec.Name, err)
+		}
+	}
+	switch config.ReadPolicy {
+	case "":
+		config.ReadPolicy = LeaderReadPolicy
+	case LinearizableReadPolicy, LeaderReadPolicy, BoundedStalenessReadPolicy:
+	default:
+		return config, errors.NewInvalid("unknown read policy %s for primitive %s", config.ReadPolicy, spec.Name)
+	}
+	if config.ReadPolicy != BoundedStalenessReadPolicy {
+		config.MaxLag = 0
+	}
+	return config, nil
+}
@@ -24,20 +24,41 @@ import (
 	multimapv1 "github.com/atomix/runtime/primitives/pkg/multimap/v1"
 	setv1 "github.com/atomix/runtime/primitives/pkg/set/v1"
 	valuev1 "github.com/atomix/runtime/primitives/pkg/value/v1"
+	"github.com/atomix/runtime/sdk/pkg/errors"
 	"github.com/atomix/runtime/sdk/pkg/network"
 	"github.com/atomix/runtime/sdk/pkg/protocol"
 	"github.com/atomix/runtime/sdk/pkg/protocol/client"
 	"github.com/atomix/runtime/sdk/pkg/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"sync"
+	"time"
 )
 
-func newConn(network network.Network) *multiRaftConn {
+const connectTimeout = time.Minute
+
+func newConn(network network.Network, creds credentials.TransportCredentials) *multiRaftConn {
 	return &multiRaftConn{
-		ProtocolClient: client.NewClient(network),
+		ProtocolClient: client.NewClient(network, client.WithGRPCDialOptions(grpc.WithTransportCredentials(creds))),
+		network:        network,
+		creds:          creds,
+		readClients:    make(map[PrimitiveConfig]*readClient),
 	}
 }
 
 type multiRaftConn struct {
 	*client.ProtocolClient
+	network     network.Network
+	creds       credentials.TransportCredentials
+	config      *protocol.ProtocolConfig
+	readClients map[PrimitiveConfig]*readClient
+	mu          sync.Mutex
+}
+
+// readClient is a protocol client whose queries are served according to a read policy
+type readClient struct {
+	*client.ProtocolClient
+	router *readRouter
 }
 
 func (c *multiRaftConn) Connect(ctx context.Context, spec runtime.ConnSpec) error {
@@ -45,7 +66,13 @@ func (c *multiRaftConn) Connect(ctx context.Context, spec runtime.ConnSpec) erro
 	if err := spec.UnmarshalConfig(&config); err != nil {
 		return err
 	}
-	return c.ProtocolClient.Connect(ctx, config)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ProtocolClient.Connect(ctx, config); err != nil {
+		return err
+	}
+	c.config = &config
+	return nil
 }
 
 func (c *multiRaftConn) Configure(ctx context.Context, spec runtime.ConnSpec) error {
@@ -53,41 +80,142 @@ func (c *multiRaftConn) Configure(ctx context.Context, spec runtime.ConnSpec) er
 	if err := spec.UnmarshalConfig(&config); err != nil {
 		return err
 	}
-	return c.ProtocolClient.Configure(ctx, config)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ProtocolClient.Configure(ctx, config); err != nil {
+		return err
+	}
+	for _, readClient := range c.readClients {
+		readClient.router.configure(config)
+		if err := readClient.Configure(ctx, config); err != nil {
+			return err
+		}
+	}
+	c.config = &config
+	return nil
+}
+
+func (c *multiRaftConn) Close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for primitiveConfig, readClient := range c.readClients {
+		if err := readClient.Close(ctx); err != nil {
+			return err
+		}
+		readClient.router.close()
+		delete(c.readClients, primitiveConfig)
+	}
+	return c.ProtocolClient.Close(ctx)
+}
+
+// getProtocol returns the protocol with which to create the given primitive. Primitives using the default
+// leader read policy share the connection's protocol client. Primitives using any other read policy share
+// a protocol client per policy whose queries are routed by a readRouter.
+func (c *multiRaftConn) getProtocol(spec runtime.PrimitiveSpec) (*client.Protocol, error) {
+	primitiveConfig, err := getPrimitiveConfig(spec)
+	if err != nil {
+		return nil, err
+	}
+	if primitiveConfig.ReadPolicy == LeaderReadPolicy {
+		return c.Protocol, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if readClient, ok := c.readClients[primitiveConfig]; ok {
+		return readClient.Protocol, nil
+	}
+	if c.config == nil {
+		return nil, errors.NewUnavailable("connection not configured")
+	}
+
+	router := newReadRouter(c.network, c.creds, primitiveConfig)
+	router.configure(*c.config)
+	protocolClient := client.NewClient(c.network, client.WithGRPCDialOptions(
+		grpc.WithTransportCredentials(c.creds),
+		grpc.WithChainUnaryInterceptor(router.unaryInterceptor),
+		grpc.WithChainStreamInterceptor(router.streamInterceptor)))
+	ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+	defer cancel()
+	if err := protocolClient.Connect(ctx, *c.config); err != nil {
+		router.close()
+		return nil, err
+	}
+	c.readClients[primitiveConfig] = &readClient{
+		ProtocolClient: protocolClient,
+		router:         router,
+	}
+	return protocolClient.Protocol, nil
 }
 
 func (c *multiRaftConn) NewCounter(spec runtime.PrimitiveSpec) (counterv1api.CounterServer, error) {
-	return counterv1.NewCounterProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return counterv1.NewCounterProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewCounterMap(spec runtime.PrimitiveSpec) (countermapv1api.CounterMapServer, error) {
-	return countermapv1.NewCounterMapProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return countermapv1.NewCounterMapProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewLeaderElection(spec runtime.PrimitiveSpec) (electionv1api.LeaderElectionServer, error) {
-	return electionv1.NewLeaderElectionProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return electionv1.NewLeaderElectionProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewIndexedMap(spec runtime.PrimitiveSpec) (indexedmapv1api.IndexedMapServer, error) {
-	return indexedmapv1.NewIndexedMapProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return indexedmapv1.NewIndexedMapProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewLock(spec runtime.PrimitiveSpec) (lockv1api.LockServer, error) {
-	return lockv1.NewLockProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return lockv1.NewLockProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewMap(spec runtime.PrimitiveSpec) (mapv1api.MapServer, error) {
-	return mapv1.NewMapProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return mapv1.NewMapProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewMultiMap(spec runtime.PrimitiveSpec) (multimapv1api.MultiMapServer, error) {
-	return multimapv1.NewMultiMapProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return multimapv1.NewMultiMapProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewSet(spec runtime.PrimitiveSpec) (setv1api.SetServer, error) {
-	return setv1.NewSetProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return setv1.NewSetProxy(proxyProtocol, spec)
 }
 
 func (c *multiRaftConn) NewValue(spec runtime.PrimitiveSpec) (valuev1api.ValueServer, error) {
-	return valuev1.NewValueProxy(c.Protocol, spec)
+	proxyProtocol, err := c.getProtocol(spec)
+	if err != nil {
+		return nil, err
+	}
+	return valuev1.NewValueProxy(proxyProtocol, spec)
 }
@@ -30,7 +30,11 @@ func (d *multiRaftDriver) ID() runtime.DriverID {
 }
 
 func (d *multiRaftDriver) Connect(ctx context.Context, spec runtime.ConnSpec) (runtime.Conn, error) {
-	conn := newConn(d.network)
+	creds, err := getTransportCredentials(spec)
+	if err != nil {
+		return nil, err
+	}
+	conn := newConn(d.network, creds)
 	if err := conn.Connect(ctx, spec); err != nil {
 		return nil, err
 	}
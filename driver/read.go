@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package driver
+
+import (
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/network"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// maxLagHeader is the request header carrying the maximum number of Raft entries by which a query may
+	// trail the index applied by the leader
+	maxLagHeader = "raft-max-lag"
+	// syncReadHeader is the request header requesting a linearizable read
+	syncReadHeader = "raft-sync-read"
+)
+
+// queryRequest is implemented by the requests of primitive queries
+type queryRequest interface {
+	GetHeaders() *protocol.QueryRequestHeaders
+}
+
+func newReadRouter(network network.Network, creds credentials.TransportCredentials, config PrimitiveConfig) *readRouter {
+	return &readRouter{
+		network:    network,
+		creds:      creds,
+		config:     config,
+		partitions: make(map[protocol.PartitionID]*readPartition),
+		conns:      make(map[string]*grpc.ClientConn),
+	}
+}
+
+// readRouter applies a read policy to the requests of a protocol client. The router is installed as a client
+// interceptor on the connections to each partition. Under the linearizable policy, queries are sent to the
+// leader as linearizable reads. Under the bounded-staleness policy, queries are sent to the partition's
+// followers and observers in turn with the policy's maximum lag. The node serving a query resolves the
+// index applied by the leader when the query is received and waits to apply the entries up to the maximum
+// lag behind it, so the bound holds regardless of which entries the client itself has observed.
+type readRouter struct {
+	network    network.Network
+	creds      credentials.TransportCredentials
+	config     PrimitiveConfig
+	partitions map[protocol.PartitionID]*readPartition
+	conns      map[string]*grpc.ClientConn
+	mu         sync.RWMutex
+}
+
+// readPartition is the read state of a partition
+type readPartition struct {
+	followers []string
+	next      uint32
+}
+
+// configure updates the followers and observers of each partition
+func (r *readRouter) configure(config protocol.ProtocolConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	addresses := make(map[string]bool)
+	for _, partitionConfig := range config.Partitions {
+		partition, ok := r.partitions[partitionConfig.PartitionID]
+		if !ok {
+			partition = &readPartition{}
+			r.partitions[partitionConfig.PartitionID] = partition
+		}
+		partition.followers = partitionConfig.Followers
+		for _, address := range partitionConfig.Followers {
+			addresses[address] = true
+		}
+	}
+	for address, conn := range r.conns {
+		if !addresses[address] {
+			_ = conn.Close()
+			delete(r.conns, address)
+		}
+	}
+}
+
+// route prepares the context for the given request, returning the connection to which to send it. A nil
+// connection indicates the request should be sent through the protocol client's connection to the partition.
+func (r *readRouter) route(ctx context.Context, req interface{}) (context.Context, *grpc.ClientConn, error) {
+	query, ok := req.(queryRequest)
+	if !ok {
+		return ctx, nil, nil
+	}
+
+	r.mu.RLock()
+	partition, ok := r.partitions[query.GetHeaders().PartitionID]
+	var followers []string
+	if ok {
+		followers = partition.followers
+	}
+	r.mu.RUnlock()
+	if !ok {
+		return ctx, nil, nil
+	}
+
+	switch r.config.ReadPolicy {
+	case LinearizableReadPolicy:
+		return metadata.AppendToOutgoingContext(ctx, syncReadHeader, "true"), nil, nil
+	case BoundedStalenessReadPolicy:
+		ctx = metadata.AppendToOutgoingContext(ctx, maxLagHeader, strconv.FormatUint(r.config.MaxLag, 10))
+		if len(followers) == 0 {
+			return ctx, nil, nil
+		}
+		next := atomic.AddUint32(&partition.next, 1)
+		conn, err := r.connect(followers[int(next)%len(followers)])
+		if err != nil {
+			return ctx, nil, err
+		}
+		return ctx, conn, nil
+	}
+	return ctx, nil, nil
+}
+
+// connect returns a connection to the given follower
+func (r *readRouter) connect(address string) (*grpc.ClientConn, error) {
+	r.mu.RLock()
+	conn, ok := r.conns[address]
+	r.mu.RUnlock()
+	if ok {
+		return conn, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	conn, ok = r.conns[address]
+	if ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address,
+		grpc.WithTransportCredentials(r.creds),
+		grpc.WithContextDialer(r.network.Connect))
+	if err != nil {
+		return nil, errors.NewUnavailable("failed to connect to %s: %v", address, err)
+	}
+	r.conns[address] = conn
+	return conn, nil
+}
+
+func (r *readRouter) close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for address, conn := range r.conns {
+		_ = conn.Close()
+		delete(r.conns, address)
+	}
+}
+
+func (r *readRouter) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, conn, err := r.route(ctx, req)
+	if err != nil {
+		return err
+	}
+	if conn != nil {
+		return conn.Invoke(ctx, method, req, reply, opts...)
+	}
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (r *readRouter) streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if desc.ClientStreams {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+	return &routedStream{
+		router:   r,
+		ctx:      ctx,
+		desc:     desc,
+		cc:       cc,
+		method:   method,
+		streamer: streamer,
+		opts:     opts,
+	}, nil
+}
+
+// routedStream is a server streaming call whose destination is determined by its request. The underlying
+// stream is opened when the request is sent.
+type routedStream struct {
+	grpc.ClientStream
+	router   *readRouter
+	ctx      context.Context
+	desc     *grpc.StreamDesc
+	cc       *grpc.ClientConn
+	method   string
+	streamer grpc.Streamer
+	opts     []grpc.CallOption
+}
+
+func (s *routedStream) Context() context.Context {
+	if s.ClientStream == nil {
+		return s.ctx
+	}
+	return s.ClientStream.Context()
+}
+
+func (s *routedStream) SendMsg(m interface{}) error {
+	if s.ClientStream != nil {
+		return s.ClientStream.SendMsg(m)
+	}
+
+	ctx, conn, err := s.router.route(s.ctx, m)
+	if err != nil {
+		return err
+	}
+	var stream grpc.ClientStream
+	if conn != nil {
+		stream, err = conn.NewStream(ctx, s.desc, s.method, s.opts...)
+	} else {
+		stream, err = s.streamer(ctx, s.desc, s.cc, s.method, s.opts...)
+	}
+	if err != nil {
+		return err
+	}
+	s.ClientStream = stream
+	return stream.SendMsg(m)
+}
@@ -0,0 +1,465 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/atomix/consensus-storage/node/pkg/consensus"
+	mapv1 "github.com/atomix/runtime/primitives/pkg/map/v1"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"github.com/atomix/runtime/sdk/pkg/protocol/node"
+	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/gogo/protobuf/proto"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	benchGroupID  = consensus.GroupID(1)
+	benchMemberID = consensus.MemberID(1)
+	readyTimeout  = time.Minute
+)
+
+// atomix-consensus-bench measures the throughput and latency of proposals to a single member Raft group.
+// Run it once with --sync and once without to compare blocking proposals to pipelined proposals. Run it with
+// --entries and --snapshot-interval to compare the latency of proposals during snapshots to the latency of
+// proposals between snapshots.
+func main() {
+	cmd := &cobra.Command{
+		Use: "atomix-consensus-bench",
+		Run: func(cmd *cobra.Command, args []string) {
+			dataDir, err := cmd.Flags().GetString("data-dir")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			raftPort, err := cmd.Flags().GetInt("raft-port")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			concurrency, err := cmd.Flags().GetInt("concurrency")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			duration, err := cmd.Flags().GetDuration("duration")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			timeout, err := cmd.Flags().GetDuration("proposal-timeout")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			syncProposals, err := cmd.Flags().GetBool("sync")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			entries, err := cmd.Flags().GetInt("entries")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			valueSize, err := cmd.Flags().GetInt("value-size")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+			snapshotInterval, err := cmd.Flags().GetDuration("snapshot-interval")
+			if err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+
+			if dataDir == "" {
+				dataDir, err = os.MkdirTemp("", "atomix-consensus-bench")
+				if err != nil {
+					fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+					os.Exit(1)
+				}
+				defer os.RemoveAll(dataDir)
+			}
+
+			config := consensus.RaftConfig{
+				DataDir:         &dataDir,
+				ProposalTimeout: &timeout,
+			}
+			raft := consensus.NewProtocol(
+				config,
+				statemachine.NewPrimitiveTypeRegistry(),
+				consensus.WithHost("localhost"),
+				consensus.WithPort(raftPort),
+				consensus.WithSyncProposals(syncProposals))
+			defer raft.Shutdown()
+
+			options := benchOptions{
+				raftPort:         raftPort,
+				concurrency:      concurrency,
+				duration:         duration,
+				entries:          entries,
+				valueSize:        valueSize,
+				snapshotInterval: snapshotInterval,
+			}
+			if err := bench(raft, options); err != nil {
+				fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().String("data-dir", "", "the directory in which to store the Raft log (defaults to a temporary directory)")
+	cmd.Flags().Int("raft-port", 5000, "the port to which to bind the Multi-Raft server")
+	cmd.Flags().Int("concurrency", 64, "the number of concurrent proposers")
+	cmd.Flags().Duration("duration", 30*time.Second, "the duration of the benchmark")
+	cmd.Flags().Duration("proposal-timeout", time.Minute, "the timeout for each proposal")
+	cmd.Flags().Bool("sync", false, "block on each proposal until it's applied rather than pipelining proposals")
+	cmd.Flags().Int("entries", 0, "the number of map entries to load before the benchmark to increase the size of snapshots")
+	cmd.Flags().Int("value-size", 1024, "the size of each map entry loaded before the benchmark")
+	cmd.Flags().Duration("snapshot-interval", 0, "the interval at which to take snapshots during the benchmark (disabled if zero)")
+
+	if err := cmd.Execute(); err != nil {
+		panic(err)
+	}
+}
+
+// benchOptions are the options for a benchmark run
+type benchOptions struct {
+	raftPort         int
+	concurrency      int
+	duration         time.Duration
+	entries          int
+	valueSize        int
+	snapshotInterval time.Duration
+}
+
+// sample is the latency of a proposal submitted at a point in time
+type sample struct {
+	start   time.Time
+	latency time.Duration
+}
+
+// window is the period during which a snapshot was taken
+type window struct {
+	start time.Time
+	end   time.Time
+}
+
+func (w window) overlaps(s sample) bool {
+	return s.start.Before(w.end) && s.start.Add(s.latency).After(w.start)
+}
+
+func bench(raft *consensus.Protocol, options benchOptions) error {
+	config := consensus.GroupConfig{
+		GroupID:  benchGroupID,
+		MemberID: benchMemberID,
+		Role:     consensus.MemberRole_MEMBER,
+		Members: []consensus.MemberConfig{
+			{
+				MemberID: benchMemberID,
+				Host:     "localhost",
+				Port:     int32(options.raftPort),
+			},
+		},
+	}
+	if err := raft.Bootstrap(config); err != nil {
+		return err
+	}
+	if err := awaitLeader(raft); err != nil {
+		return err
+	}
+
+	partition, ok := raft.Partition(protocol.PartitionID(benchGroupID))
+	if !ok {
+		return fmt.Errorf("group %d not found", benchGroupID)
+	}
+
+	ctx := context.Background()
+	output, err := partition.Propose(ctx, &protocol.ProposalInput{
+		Timestamp: time.Now(),
+		Input: &protocol.ProposalInput_OpenSession{
+			OpenSession: &protocol.OpenSessionInput{
+				Timeout: options.duration + time.Minute,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	sessionID := output.GetOpenSession().SessionID
+
+	inputFilter, err := json.Marshal(bloom.NewWithEstimates(1000, .01))
+	if err != nil {
+		return err
+	}
+
+	if options.entries > 0 {
+		if err := load(partition, sessionID, inputFilter, options); err != nil {
+			return err
+		}
+	}
+
+	// Snapshots are requested at the configured interval while the benchmark runs, recording the period
+	// during which each snapshot was taken
+	var windows []window
+	snapshotErrCount := 0
+	snapshotCh := make(chan struct{})
+	if options.snapshotInterval > 0 {
+		go func() {
+			defer close(snapshotCh)
+			timer := time.NewTimer(options.snapshotInterval)
+			deadline := time.Now().Add(options.duration)
+			for range timer.C {
+				if time.Now().After(deadline) {
+					return
+				}
+				snapshotStart := time.Now()
+				if _, err := raft.Snapshot(ctx, benchGroupID); err != nil {
+					snapshotErrCount++
+				} else {
+					windows = append(windows, window{start: snapshotStart, end: time.Now()})
+				}
+				timer.Reset(options.snapshotInterval)
+			}
+		}()
+	} else {
+		close(snapshotCh)
+	}
+
+	// Each proposer submits keep-alives for the session until the benchmark expires, recording the latency
+	// of every proposal
+	samples := make([][]sample, options.concurrency)
+	errCount := 0
+	errMu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	start := time.Now()
+	deadline := start.Add(options.duration)
+	for i := 0; i < options.concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				proposalStart := time.Now()
+				_, err := partition.Propose(ctx, &protocol.ProposalInput{
+					Timestamp: proposalStart,
+					Input: &protocol.ProposalInput_KeepAlive{
+						KeepAlive: &protocol.KeepAliveInput{
+							SessionID:   sessionID,
+							InputFilter: inputFilter,
+						},
+					},
+				})
+				if err != nil {
+					errMu.Lock()
+					errCount++
+					errMu.Unlock()
+					continue
+				}
+				samples[i] = append(samples[i], sample{start: proposalStart, latency: time.Since(proposalStart)})
+			}
+		}(i)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	<-snapshotCh
+
+	var results []sample
+	for _, workerSamples := range samples {
+		results = append(results, workerSamples...)
+	}
+	if len(results) == 0 {
+		return fmt.Errorf("no proposals completed (%d errors)", errCount)
+	}
+
+	fmt.Printf("proposals:  %d (%d errors)\n", len(results), errCount)
+	fmt.Printf("throughput: %.1f proposals/s\n", float64(len(results))/elapsed.Seconds())
+	printLatencies("latency:   ", results)
+	if options.snapshotInterval == 0 {
+		return nil
+	}
+
+	var snapshotTime time.Duration
+	for _, w := range windows {
+		snapshotTime += w.end.Sub(w.start)
+	}
+	var during, between []sample
+	for _, result := range results {
+		overlaps := false
+		for _, w := range windows {
+			if w.overlaps(result) {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			during = append(during, result)
+		} else {
+			between = append(between, result)
+		}
+	}
+	fmt.Printf("snapshots:  %d (%d errors)\n", len(windows), snapshotErrCount)
+	if len(windows) > 0 {
+		fmt.Printf("            mean=%s\n", snapshotTime/time.Duration(len(windows)))
+	}
+	printLatencies("during:    ", during)
+	printLatencies("between:   ", between)
+	return nil
+}
+
+// load creates a map and puts the configured number of entries to it to increase the size of snapshots
+func load(partition node.Partition, sessionID protocol.SessionID, inputFilter []byte, options benchOptions) error {
+	ctx := context.Background()
+	output, err := partition.Propose(ctx, &protocol.ProposalInput{
+		Timestamp: time.Now(),
+		Input: &protocol.ProposalInput_Proposal{
+			Proposal: &protocol.SessionProposalInput{
+				SessionID:   sessionID,
+				SequenceNum: 1,
+				Input: &protocol.SessionProposalInput_CreatePrimitive{
+					CreatePrimitive: &protocol.CreatePrimitiveInput{
+						PrimitiveSpec: protocol.PrimitiveSpec{
+							Service: mapv1.Service,
+							Name:    "atomix-consensus-bench",
+						},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if failure := output.GetProposal().Failure; failure != nil {
+		return fmt.Errorf("failed to create map: %s", failure.Message)
+	}
+	primitiveID := output.GetProposal().GetCreatePrimitive().PrimitiveID
+
+	value := make([]byte, options.valueSize)
+	sequenceNum := uint64(1)
+	errCh := make(chan error, options.concurrency)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < options.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				n := atomic.AddUint64(&sequenceNum, 1)
+				if n > uint64(options.entries)+1 {
+					return
+				}
+				payload, err := proto.Marshal(&mapv1.MapInput{
+					Input: &mapv1.MapInput_Put{
+						Put: &mapv1.PutInput{
+							Key:   fmt.Sprintf("key-%d", n),
+							Value: value,
+						},
+					},
+				})
+				if err != nil {
+					errCh <- err
+					return
+				}
+				output, err := partition.Propose(ctx, &protocol.ProposalInput{
+					Timestamp: time.Now(),
+					Input: &protocol.ProposalInput_Proposal{
+						Proposal: &protocol.SessionProposalInput{
+							SessionID:   sessionID,
+							SequenceNum: protocol.SequenceNum(n),
+							Input: &protocol.SessionProposalInput_Proposal{
+								Proposal: &protocol.PrimitiveProposalInput{
+									PrimitiveID: primitiveID,
+									Payload:     payload,
+								},
+							},
+						},
+					},
+				})
+				if err != nil {
+					errCh <- err
+					return
+				}
+				if failure := output.GetProposal().Failure; failure != nil {
+					errCh <- fmt.Errorf("failed to load map: %s", failure.Message)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+	if err := <-errCh; err != nil {
+		return err
+	}
+
+	// Acknowledge the outputs of the loaded entries to release them from the session
+	_, err = partition.Propose(ctx, &protocol.ProposalInput{
+		Timestamp: time.Now(),
+		Input: &protocol.ProposalInput_KeepAlive{
+			KeepAlive: &protocol.KeepAliveInput{
+				SessionID:            sessionID,
+				InputFilter:          inputFilter,
+				LastInputSequenceNum: protocol.SequenceNum(options.entries + 1),
+			},
+		},
+	})
+	return err
+}
+
+// printLatencies prints the mean and percentile latencies of the given samples
+func printLatencies(label string, samples []sample) {
+	if len(samples) == 0 {
+		fmt.Printf("%s n=0\n", label)
+		return
+	}
+	latencies := make([]time.Duration, len(samples))
+	var total time.Duration
+	for i, s := range samples {
+		latencies[i] = s.latency
+		total += s.latency
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	fmt.Printf("%s n=%d mean=%s p50=%s p90=%s p99=%s max=%s\n",
+		label,
+		len(latencies),
+		total/time.Duration(len(latencies)),
+		percentile(latencies, .5),
+		percentile(latencies, .9),
+		percentile(latencies, .99),
+		latencies[len(latencies)-1])
+}
+
+// awaitLeader waits for the benchmark member to be elected leader of the group
+func awaitLeader(raft *consensus.Protocol) error {
+	ctx, cancel := context.WithTimeout(context.Background(), readyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			status, err := raft.GetGroupStatus(ctx, benchGroupID)
+			if err == nil && status.Leader == benchMemberID {
+				return nil
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for group %d to elect a leader", benchGroupID)
+		}
+	}
+}
+
+// percentile returns the given percentile of the sorted latencies
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	return latencies[int(float64(len(latencies)-1)*p)]
+}
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/atomix/consensus-storage/node/pkg/consensus"
 	counterv1 "github.com/atomix/runtime/primitives/pkg/counter/v1"
@@ -20,12 +21,24 @@ import (
 	"github.com/atomix/runtime/sdk/pkg/protocol/node"
 	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"gopkg.in/yaml.v3"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+)
+
+const (
+	resignTimeout         = 20 * time.Second
+	tracerShutdownTimeout = 5 * time.Second
 )
 
 func main() {
@@ -80,13 +93,64 @@ func main() {
 			setv1.RegisterStateMachine(registry)
 			valuev1.RegisterStateMachine(registry)
 
-			protocol := consensus.NewProtocol(
-				config.Raft,
-				registry,
+			protocolOptions := []consensus.Option{
 				consensus.WithHost(raftHost),
-				consensus.WithPort(raftPort))
+				consensus.WithPort(raftPort),
+				consensus.WithAPIPort(apiPort),
+			}
+			if config.Server.ForwardProposals != nil {
+				protocolOptions = append(protocolOptions, consensus.WithProposalForwarding(*config.Server.ForwardProposals))
+			}
+			if config.Server.WatchQueueSize != nil {
+				protocolOptions = append(protocolOptions, consensus.WithWatchQueueSize(*config.Server.WatchQueueSize))
+			}
+			if config.Server.WatchOverflowPolicy != nil {
+				protocolOptions = append(protocolOptions, consensus.WithWatchOverflowPolicy(*config.Server.WatchOverflowPolicy))
+			}
 
 			var serverOptions []grpc.ServerOption
+			var tracerProvider *sdktrace.TracerProvider
+			if config.Tracing != nil {
+				tracerProvider, err = consensus.NewTracerProvider(context.Background(), *config.Tracing)
+				if err != nil {
+					fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+					os.Exit(1)
+				}
+				otel.SetTracerProvider(tracerProvider)
+				otel.SetTextMapPropagator(propagation.TraceContext{})
+				serverOptions = append(serverOptions,
+					grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
+					grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor()))
+			}
+			if config.TLS != nil {
+				certs, err := consensus.NewCertificates(*config.TLS)
+				if err != nil {
+					fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+					os.Exit(1)
+				}
+				protocolOptions = append(protocolOptions, consensus.WithCertificates(certs))
+				serverOptions = append(serverOptions,
+					grpc.Creds(credentials.NewTLS(certs.ServerConfig())),
+					grpc.ChainUnaryInterceptor(consensus.NewClientCertUnaryInterceptor()),
+					grpc.ChainStreamInterceptor(consensus.NewClientCertStreamInterceptor()))
+			}
+			if config.Auth != nil {
+				authenticator, err := consensus.NewAuthenticator(*config.Auth)
+				if err != nil {
+					fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+					os.Exit(1)
+				}
+				serverOptions = append(serverOptions,
+					grpc.ChainUnaryInterceptor(authenticator.NewUnaryInterceptor()),
+					grpc.ChainStreamInterceptor(authenticator.NewStreamInterceptor()))
+			}
+			var metrics *consensus.Metrics
+			if config.Metrics != nil {
+				metrics = consensus.NewMetrics()
+				protocolOptions = append(protocolOptions, consensus.WithMetrics(metrics))
+			}
+			protocol := consensus.NewProtocol(config.Raft, registry, protocolOptions...)
+
 			if config.Server.ReadBufferSize != nil {
 				serverOptions = append(serverOptions, grpc.ReadBufferSize(*config.Server.ReadBufferSize))
 			}
@@ -124,6 +188,7 @@ func main() {
 			valuev1.RegisterServer(node)
 			node.RegisterService(func(server *grpc.Server) {
 				consensus.RegisterNodeServer(server, consensus.NewNodeServer(protocol))
+				consensus.RegisterForwarderServer(server, consensus.NewForwarderServer(protocol))
 			})
 
 			// Start the node
@@ -132,16 +197,58 @@ func main() {
 				os.Exit(1)
 			}
 
+			// Start the metrics server
+			var metricsServer *http.Server
+			if metrics != nil {
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", metrics.Handler())
+				metricsServer = &http.Server{
+					Addr:    fmt.Sprintf(":%d", config.Metrics.GetPort()),
+					Handler: mux,
+				}
+				go func() {
+					if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+						fmt.Fprintln(cmd.OutOrStderr(), err.Error())
+						os.Exit(1)
+					}
+				}()
+			}
+
 			// Wait for an interrupt signal
 			ch := make(chan os.Signal, 2)
 			signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
 			<-ch
 
+			// Hand off leadership of any partitions led by this node to avoid an election timeout
+			ctx, cancel := context.WithTimeout(context.Background(), resignTimeout)
+			if err := protocol.Resign(ctx); err != nil {
+				fmt.Println(err)
+			}
+			cancel()
+
+			if metricsServer != nil {
+				_ = metricsServer.Close()
+			}
+
+			// Stop the Raft host before the API server, allowing watchers to observe the node shutting down
+			if err := protocol.Shutdown(); err != nil {
+				fmt.Println(err)
+			}
+
 			// Stop the node
 			if err := node.Stop(); err != nil {
 				fmt.Println(err)
 				os.Exit(1)
 			}
+
+			// Flush any spans that have not been exported
+			if tracerProvider != nil {
+				ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+				if err := tracerProvider.Shutdown(ctx); err != nil {
+					fmt.Println(err)
+				}
+				cancel()
+			}
 		},
 	}
 	cmd.Flags().StringP("config", "c", "", "the path to the consensus cluster configuration")
@@ -0,0 +1,305 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "Bearer "
+	nodeService         = "/atomix.consensus.node.v1.Node/"
+)
+
+const (
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+	serviceAccountPrefix    = "system:serviceaccount:"
+	tokenReviewPath         = "/apis/authentication.k8s.io/v1/tokenreviews"
+	tokenReviewTimeout      = 10 * time.Second
+	tokenReviewCacheTTL     = time.Minute
+	tokenReviewCacheSize    = 1024
+)
+
+// NewAuthenticator returns an authenticator for the node's administrative RPCs
+func NewAuthenticator(config AuthConfig) (*Authenticator, error) {
+	authenticator := &Authenticator{
+		config: config,
+	}
+	if len(config.ServiceAccounts) > 0 {
+		reviewer, err := newTokenReviewer()
+		if err != nil {
+			return nil, err
+		}
+		authenticator.reviewer = reviewer
+	}
+	return authenticator, nil
+}
+
+// Authenticator authenticates calls to the node's administrative RPCs. Callers present a bearer token, which
+// is accepted if it matches the token in the configured token file or if it's the token of one of the configured
+// Kubernetes service accounts as verified by a TokenReview. The token file is read on each call, allowing the
+// Secret from which it's mounted to be rotated without restarting the node.
+type Authenticator struct {
+	config   AuthConfig
+	reviewer *tokenReviewer
+}
+
+// authenticate returns an error if the caller did not present an accepted bearer token
+func (a *Authenticator) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return errors.NewUnauthorized("bearer token required")
+	}
+	token := strings.TrimPrefix(values[0], bearerPrefix)
+
+	if a.config.TokenFile != "" {
+		expected, err := os.ReadFile(a.config.TokenFile)
+		if err != nil {
+			log.Warnw("Failed to read token file",
+				logging.String("TokenFile", a.config.TokenFile),
+				logging.Error("Error", err))
+		} else if subtle.ConstantTimeCompare([]byte(token), bytes.TrimSpace(expected)) == 1 {
+			return nil
+		}
+	}
+
+	if a.reviewer != nil {
+		username, err := a.reviewer.review(ctx, token)
+		if err != nil {
+			log.Warnw("Failed to review token",
+				logging.Error("Error", err))
+			return errors.NewUnavailable("failed to review token: %v", err)
+		}
+		for _, serviceAccount := range a.config.ServiceAccounts {
+			if username == serviceAccountPrefix+strings.Replace(serviceAccount, "/", ":", 1) {
+				return nil
+			}
+		}
+	}
+	return errors.NewUnauthorized("invalid bearer token")
+}
+
+// NewUnaryInterceptor returns a server interceptor authenticating calls to the node's administrative RPCs
+func (a *Authenticator) NewUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, nodeService) {
+			if err := a.authenticate(ctx); err != nil {
+				return nil, errors.ToProto(err)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewStreamInterceptor returns a server interceptor authenticating streams to the node's administrative RPCs
+func (a *Authenticator) NewStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, nodeService) {
+			if err := a.authenticate(stream.Context()); err != nil {
+				return errors.ToProto(err)
+			}
+		}
+		return handler(srv, stream)
+	}
+}
+
+func newTokenReviewer() (*tokenReviewer, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("service account authentication requires the node to run in a Kubernetes cluster")
+	}
+	caBytes, err := os.ReadFile(serviceAccountCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBytes) {
+		return nil, fmt.Errorf("failed to load CA certificate from %s", serviceAccountCAFile)
+	}
+	return &tokenReviewer{
+		url: fmt.Sprintf("https://%s%s", net.JoinHostPort(host, port), tokenReviewPath),
+		client: &http.Client{
+			Timeout: tokenReviewTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+					RootCAs:    pool,
+				},
+			},
+		},
+		cache:     make(map[[sha256.Size]byte]*list.Element),
+		lru:       list.New(),
+		lastSweep: time.Now(),
+	}, nil
+}
+
+// tokenReviewer verifies service account tokens with the Kubernetes TokenReview API. The node's service
+// account must be permitted to create TokenReviews, e.g. by binding it to the system:auth-delegator role.
+//
+// Authenticated tokens are cached by their hash for tokenReviewCacheTTL. The cache holds at most
+// tokenReviewCacheSize tokens, evicting the least recently used, and expired tokens are swept every
+// tokenReviewCacheTTL. Tokens that fail to authenticate are not cached, so invalid tokens cannot fill the cache.
+type tokenReviewer struct {
+	url       string
+	client    *http.Client
+	cache     map[[sha256.Size]byte]*list.Element
+	lru       *list.List
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// tokenReviewResult is a cached TokenReview result
+type tokenReviewResult struct {
+	key      [sha256.Size]byte
+	username string
+	expires  time.Time
+}
+
+type tokenReview struct {
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Spec       tokenReviewSpec   `json:"spec"`
+	Status     tokenReviewStatus `json:"status,omitempty"`
+}
+
+type tokenReviewSpec struct {
+	Token string `json:"token"`
+}
+
+type tokenReviewStatus struct {
+	Authenticated bool            `json:"authenticated"`
+	User          tokenReviewUser `json:"user"`
+	Error         string          `json:"error,omitempty"`
+}
+
+type tokenReviewUser struct {
+	Username string `json:"username"`
+}
+
+// review returns the username of the given token, or an empty username if the token is not authenticated
+func (r *tokenReviewer) review(ctx context.Context, token string) (string, error) {
+	key := sha256.Sum256([]byte(token))
+	if username, ok := r.get(key); ok {
+		return username, nil
+	}
+
+	requestBytes, err := json.Marshal(&tokenReview{
+		APIVersion: "authentication.k8s.io/v1",
+		Kind:       "TokenReview",
+		Spec: tokenReviewSpec{
+			Token: token,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(requestBytes))
+	if err != nil {
+		return "", err
+	}
+	// The service account token is read on each review since it's rotated by the kubelet
+	nodeToken, err := os.ReadFile(serviceAccountTokenFile)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Authorization", bearerPrefix+string(bytes.TrimSpace(nodeToken)))
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("TokenReview failed with status %s", response.Status)
+	}
+
+	review := &tokenReview{}
+	if err := json.NewDecoder(response.Body).Decode(review); err != nil {
+		return "", err
+	}
+
+	if !review.Status.Authenticated {
+		return "", nil
+	}
+	username := review.Status.User.Username
+	r.put(key, username)
+	return username, nil
+}
+
+// get returns the cached username for the given token hash
+func (r *tokenReviewer) get(key [sha256.Size]byte) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	elem, ok := r.cache[key]
+	if !ok {
+		return "", false
+	}
+	result := elem.Value.(*tokenReviewResult)
+	if time.Now().After(result.expires) {
+		r.lru.Remove(elem)
+		delete(r.cache, key)
+		return "", false
+	}
+	r.lru.MoveToFront(elem)
+	return result.username, true
+}
+
+// put caches the username for the given token hash, sweeping expired tokens and evicting the least recently
+// used tokens to bound the size of the cache
+func (r *tokenReviewer) put(key [sha256.Size]byte, username string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if now.Sub(r.lastSweep) >= tokenReviewCacheTTL {
+		r.lastSweep = now
+		for elem := r.lru.Back(); elem != nil; {
+			prev := elem.Prev()
+			if result := elem.Value.(*tokenReviewResult); now.After(result.expires) {
+				r.lru.Remove(elem)
+				delete(r.cache, result.key)
+			}
+			elem = prev
+		}
+	}
+
+	result := &tokenReviewResult{
+		key:      key,
+		username: username,
+		expires:  now.Add(tokenReviewCacheTTL),
+	}
+	if elem, ok := r.cache[key]; ok {
+		elem.Value = result
+		r.lru.MoveToFront(elem)
+		return
+	}
+	r.cache[key] = r.lru.PushFront(result)
+	for r.lru.Len() > tokenReviewCacheSize {
+		elem := r.lru.Back()
+		r.lru.Remove(elem)
+		delete(r.cache, elem.Value.(*tokenReviewResult).key)
+	}
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testToken = "test-token"
+
+func newTestAuthenticator(t *testing.T) (*Authenticator, string) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(testToken+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	authenticator, err := NewAuthenticator(AuthConfig{
+		TokenFile: tokenFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authenticator, tokenFile
+}
+
+func newTestAuthContext(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationHeader, authorization))
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		authorization string
+		authorized    bool
+	}{
+		{
+			name:          "valid token",
+			method:        nodeService + "Bootstrap",
+			authorization: bearerPrefix + testToken,
+			authorized:    true,
+		},
+		{
+			name:   "missing token",
+			method: nodeService + "Leave",
+		},
+		{
+			name:          "invalid token",
+			method:        nodeService + "Join",
+			authorization: bearerPrefix + "invalid",
+		},
+		{
+			name:          "not a bearer token",
+			method:        nodeService + "Join",
+			authorization: "Basic " + testToken,
+		},
+		{
+			name:       "data plane service",
+			method:     "/atomix.runtime.counter.v1.Counter/Increment",
+			authorized: true,
+		},
+		{
+			name:       "forwarder service",
+			method:     "/atomix.consensus.node.v1.Forwarder/Propose",
+			authorized: true,
+		},
+	}
+	authenticator, _ := newTestAuthenticator(t)
+	interceptor := authenticator.NewUnaryInterceptor()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return req, nil
+			}
+			info := &grpc.UnaryServerInfo{FullMethod: test.method}
+			_, err := interceptor(newTestAuthContext(test.authorization), nil, info, handler)
+			if test.authorized {
+				if err != nil {
+					t.Fatalf("expected call to be authorized, got %v", err)
+				}
+				if !called {
+					t.Error("expected handler to be called")
+				}
+			} else {
+				if !errors.IsUnauthorized(errors.FromProto(err)) {
+					t.Fatalf("expected Unauthorized error, got %v", err)
+				}
+				if called {
+					t.Error("expected handler not to be called")
+				}
+			}
+		})
+	}
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	authenticator, _ := newTestAuthenticator(t)
+	interceptor := authenticator.NewStreamInterceptor()
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: nodeService + "Watch"}
+
+	stream := &testServerStream{ctx: newTestAuthContext(bearerPrefix + testToken)}
+	if err := interceptor(nil, stream, info, handler); err != nil {
+		t.Fatalf("expected stream to be authorized, got %v", err)
+	}
+	stream = &testServerStream{ctx: newTestAuthContext("")}
+	if err := interceptor(nil, stream, info, handler); !errors.IsUnauthorized(errors.FromProto(err)) {
+		t.Fatalf("expected Unauthorized error, got %v", err)
+	}
+}
+
+func TestAuthenticateRotatedToken(t *testing.T) {
+	authenticator, tokenFile := newTestAuthenticator(t)
+	if err := os.WriteFile(tokenFile, []byte("rotated-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := authenticator.authenticate(newTestAuthContext(bearerPrefix + testToken)); !errors.IsUnauthorized(err) {
+		t.Fatalf("expected the replaced token to be rejected, got %v", err)
+	}
+	if err := authenticator.authenticate(newTestAuthContext(bearerPrefix + "rotated-token")); err != nil {
+		t.Fatalf("expected the rotated token to be accepted, got %v", err)
+	}
+}
+
+func newTestTokenReviewer() *tokenReviewer {
+	return &tokenReviewer{
+		cache:     make(map[[sha256.Size]byte]*list.Element),
+		lru:       list.New(),
+		lastSweep: time.Now(),
+	}
+}
+
+func TestTokenReviewCacheEviction(t *testing.T) {
+	reviewer := newTestTokenReviewer()
+	for i := 0; i <= tokenReviewCacheSize; i++ {
+		reviewer.put(sha256.Sum256([]byte(strconv.Itoa(i))), "user")
+		// Keep the first token in use so the second is the least recently used
+		if i == 1 {
+			if _, ok := reviewer.get(sha256.Sum256([]byte("0"))); !ok {
+				t.Fatal("expected first token to be cached")
+			}
+		}
+	}
+	if len(reviewer.cache) != tokenReviewCacheSize || reviewer.lru.Len() != tokenReviewCacheSize {
+		t.Fatalf("expected %d cached tokens, got %d", tokenReviewCacheSize, len(reviewer.cache))
+	}
+	if _, ok := reviewer.get(sha256.Sum256([]byte("0"))); !ok {
+		t.Error("expected recently used token to be cached")
+	}
+	if _, ok := reviewer.get(sha256.Sum256([]byte("1"))); ok {
+		t.Error("expected least recently used token to be evicted")
+	}
+	if username, ok := reviewer.get(sha256.Sum256([]byte(strconv.Itoa(tokenReviewCacheSize)))); !ok || username != "user" {
+		t.Error("expected latest token to be cached")
+	}
+}
+
+func TestTokenReviewCacheExpiry(t *testing.T) {
+	reviewer := newTestTokenReviewer()
+	expired := sha256.Sum256([]byte("expired"))
+	stale := sha256.Sum256([]byte("stale"))
+	reviewer.put(expired, "user")
+	reviewer.put(stale, "user")
+	for _, key := range [][sha256.Size]byte{expired, stale} {
+		reviewer.cache[key].Value.(*tokenReviewResult).expires = time.Now().Add(-time.Second)
+	}
+
+	// Expired tokens are removed when they're looked up
+	if _, ok := reviewer.get(expired); ok {
+		t.Fatal("expected expired token to be a cache miss")
+	}
+	if _, ok := reviewer.cache[expired]; ok {
+		t.Error("expected expired token to be removed when looked up")
+	}
+
+	// Expired tokens that are never looked up again are swept
+	reviewer.lastSweep = time.Now().Add(-tokenReviewCacheTTL)
+	reviewer.put(sha256.Sum256([]byte("fresh")), "user")
+	if _, ok := reviewer.cache[stale]; ok {
+		t.Error("expected expired token to be swept")
+	}
+	if len(reviewer.cache) != 1 || reviewer.lru.Len() != 1 {
+		t.Errorf("expected 1 cached token, got %d", len(reviewer.cache))
+	}
+}
@@ -11,12 +11,85 @@ const (
 	defaultSnapshotEntryThreshold  = uint64(10000)
 	defaultCompactionRetainEntries = uint64(1000)
 	defaultHeartbeatPeriod         = 200 * time.Millisecond
+	defaultElectionRTT             = 10
 	defaultClientTimeout           = time.Minute
+	defaultMetricsPort             = 5680
+	defaultTraceSampleRatio        = 1.0
+	defaultWatchQueueSize          = 1000
+)
+
+// WatchOverflowPolicy is the policy applied to a watcher whose queue of events is full
+type WatchOverflowPolicy string
+
+const (
+	// DropWatchOverflow drops the events that do not fit in the watcher's queue, sending a resync event in their place
+	DropWatchOverflow WatchOverflowPolicy = "Drop"
+	// DisconnectWatchOverflow closes the stream of a watcher whose queue is full
+	DisconnectWatchOverflow WatchOverflowPolicy = "Disconnect"
 )
 
 type Config struct {
-	Server ServerConfig `json:"server" yaml:"server"`
-	Raft   RaftConfig   `json:"raft" yaml:"raft"`
+	Server  ServerConfig   `json:"server" yaml:"server"`
+	Raft    RaftConfig     `json:"raft" yaml:"raft"`
+	TLS     *TLSConfig     `json:"tls" yaml:"tls"`
+	Auth    *AuthConfig    `json:"auth" yaml:"auth"`
+	Metrics *MetricsConfig `json:"metrics" yaml:"metrics"`
+	Tracing *TracingConfig `json:"tracing" yaml:"tracing"`
+}
+
+// TracingConfig is the configuration for exporting OpenTelemetry traces to an OTLP collector over gRPC
+type TracingConfig struct {
+	// Endpoint is the host:port of the OTLP collector
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+	// Insecure disables TLS for the connection to the collector
+	Insecure bool `json:"insecure" yaml:"insecure"`
+	// SampleRatio is the fraction of traces sampled by the node. Defaults to 1.
+	SampleRatio *float64 `json:"sampleRatio" yaml:"sampleRatio"`
+}
+
+// GetSampleRatio returns the fraction of traces sampled by the node
+func (c TracingConfig) GetSampleRatio() float64 {
+	if c.SampleRatio != nil {
+		return *c.SampleRatio
+	}
+	return defaultTraceSampleRatio
+}
+
+// MetricsConfig is the configuration for the node's Prometheus metrics endpoint
+type MetricsConfig struct {
+	// Port is the port on which metrics are served at /metrics
+	Port *int `json:"port" yaml:"port"`
+}
+
+// GetPort returns the port on which metrics are served
+func (c MetricsConfig) GetPort() int {
+	if c.Port != nil {
+		return *c.Port
+	}
+	return defaultMetricsPort
+}
+
+// TLSConfig is the configuration for mutual TLS between nodes and with clients. The CA, certificate
+// and key are used for both the Raft transport and the API server.
+type TLSConfig struct {
+	CAFile   string `json:"caFile" yaml:"caFile"`
+	CertFile string `json:"certFile" yaml:"certFile"`
+	KeyFile  string `json:"keyFile" yaml:"keyFile"`
+	Reload   *bool  `json:"reload" yaml:"reload"`
+}
+
+// GetReload returns whether the certificates are reloaded when the files change
+func (c TLSConfig) GetReload() bool {
+	return c.Reload != nil && *c.Reload
+}
+
+// AuthConfig is the configuration for authenticating calls to the node's administrative RPCs. Callers must
+// present a bearer token matching the token file or belonging to one of the service accounts.
+type AuthConfig struct {
+	// TokenFile is the path to the file containing the accepted bearer token
+	TokenFile string `json:"tokenFile" yaml:"tokenFile"`
+	// ServiceAccounts are the Kubernetes service accounts, as namespace/name, whose tokens are accepted
+	ServiceAccounts []string `json:"serviceAccounts" yaml:"serviceAccounts"`
 }
 
 type ServerConfig struct {
@@ -26,6 +99,11 @@ type ServerConfig struct {
 	MaxSendMsgSize       *int    `json:"maxSendMsgSize" yaml:"maxSendMsgSize"`
 	NumStreamWorkers     *uint32 `json:"numStreamWorkers" yaml:"numStreamWorkers"`
 	MaxConcurrentStreams *uint32 `json:"maxConcurrentStreams" yaml:"maxConcurrentStreams"`
+	ForwardProposals     *bool   `json:"forwardProposals" yaml:"forwardProposals"`
+	// WatchQueueSize is the number of events queued for each watcher before the overflow policy is applied
+	WatchQueueSize *int `json:"watchQueueSize" yaml:"watchQueueSize"`
+	// WatchOverflowPolicy is the policy applied to watchers that fall behind. Defaults to Drop.
+	WatchOverflowPolicy *WatchOverflowPolicy `json:"watchOverflowPolicy" yaml:"watchOverflowPolicy"`
 }
 
 type RaftConfig struct {
@@ -34,6 +112,10 @@ type RaftConfig struct {
 	SnapshotEntryThreshold  *uint64        `json:"snapshotEntryThreshold" yaml:"snapshotEntryThreshold"`
 	CompactionRetainEntries *uint64        `json:"compactionRetainEntries" yaml:"compactionRetainEntries"`
 	DataDir                 *string        `json:"dataDir" yaml:"dataDir"`
+	ProposalTimeout         *time.Duration `json:"proposalTimeout" yaml:"proposalTimeout"`
+	// DeploymentID isolates the node from the nodes of other clusters, which cannot exchange Raft messages
+	// with nodes started with a different deployment ID
+	DeploymentID *uint64 `json:"deploymentID" yaml:"deploymentID"`
 }
 
 func (c RaftConfig) GetDataDir() string {
@@ -63,3 +145,17 @@ func (c RaftConfig) GetHeartbeatPeriod() time.Duration {
 	}
 	return defaultHeartbeatPeriod
 }
+
+func (c RaftConfig) GetElectionTimeout() time.Duration {
+	if c.ElectionTimeout != nil {
+		return *c.ElectionTimeout
+	}
+	return c.GetHeartbeatPeriod() * defaultElectionRTT
+}
+
+func (c RaftConfig) GetProposalTimeout() time.Duration {
+	if c.ProposalTimeout != nil {
+		return *c.ProposalTimeout
+	}
+	return defaultClientTimeout
+}
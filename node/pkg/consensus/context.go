@@ -5,10 +5,16 @@
 package consensus
 
 import (
+	"context"
 	"github.com/atomix/runtime/sdk/pkg/protocol"
 	streams "github.com/atomix/runtime/sdk/pkg/stream"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"io"
+	"strconv"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // newContext returns a new protocol context
@@ -74,3 +80,154 @@ type protocolQuery struct {
 	input  *protocol.QueryInput
 	stream streams.WriteStream[*protocol.QueryOutput]
 }
+
+// snapshotQuery is a query that writes a snapshot of the state machine to the given writer. If a time is
+// provided, the snapshot is cut at the last change proposed at or before the time.
+type snapshotQuery struct {
+	writer io.Writer
+	time   *time.Time
+}
+
+// newResultStream returns a stream that records the outputs written to the given stream
+func newResultStream(stream streams.WriteStream[*protocol.ProposalOutput]) *resultStream {
+	return &resultStream{
+		stream: stream,
+	}
+}
+
+// resultStream records the outputs of a proposal as it is applied, to be returned to retries of the
+// proposal deduplicated by its Raft client session
+type resultStream struct {
+	stream  streams.WriteStream[*protocol.ProposalOutput]
+	outputs []*protocol.ProposalOutput
+	closed  bool
+}
+
+func (s *resultStream) Send(result streams.Result[*protocol.ProposalOutput]) {
+	if result.Succeeded() {
+		s.outputs = append(s.outputs, result.Value)
+	}
+	s.stream.Send(result)
+}
+
+func (s *resultStream) Result(value *protocol.ProposalOutput, err error) {
+	if err == nil {
+		s.outputs = append(s.outputs, value)
+	}
+	s.stream.Result(value, err)
+}
+
+func (s *resultStream) Value(value *protocol.ProposalOutput) {
+	s.outputs = append(s.outputs, value)
+	s.stream.Value(value)
+}
+
+func (s *resultStream) Error(err error) {
+	s.stream.Error(err)
+}
+
+func (s *resultStream) Close() {
+	s.closed = true
+	s.stream.Close()
+}
+
+// newAppliedIndexStream returns a stream that reports the applied index of the partition in the response
+// header of the given context when the first output of a proposal is written
+func newAppliedIndexStream(ctx context.Context, partition *Partition, stream streams.WriteStream[*protocol.ProposalOutput]) *appliedIndexStream {
+	return &appliedIndexStream{
+		ctx:       ctx,
+		partition: partition,
+		stream:    stream,
+	}
+}
+
+// appliedIndexStream sets the AppliedIndexHeader for a proposal. Outputs are written while the entry that
+// produced them is applied, so the index is the index at which the proposal was applied.
+type appliedIndexStream struct {
+	ctx       context.Context
+	partition *Partition
+	stream    streams.WriteStream[*protocol.ProposalOutput]
+	once      sync.Once
+}
+
+func (s *appliedIndexStream) setHeader() {
+	s.once.Do(func() {
+		index := s.partition.getAppliedIndex()
+		_ = grpc.SetHeader(s.ctx, metadata.Pairs(AppliedIndexHeader, strconv.FormatUint(uint64(index), 10)))
+	})
+}
+
+func (s *appliedIndexStream) Send(result streams.Result[*protocol.ProposalOutput]) {
+	s.setHeader()
+	s.stream.Send(result)
+}
+
+func (s *appliedIndexStream) Result(value *protocol.ProposalOutput, err error) {
+	s.setHeader()
+	s.stream.Result(value, err)
+}
+
+func (s *appliedIndexStream) Value(value *protocol.ProposalOutput) {
+	s.setHeader()
+	s.stream.Value(value)
+}
+
+func (s *appliedIndexStream) Error(err error) {
+	s.stream.Error(err)
+}
+
+func (s *appliedIndexStream) Close() {
+	s.stream.Close()
+}
+
+// newLatencyStream returns a stream that records the latency of a proposal to the given partition
+func newLatencyStream(partition *Partition, stream streams.WriteStream[*protocol.ProposalOutput]) *latencyStream {
+	return &latencyStream{
+		partition: partition,
+		stream:    stream,
+		start:     time.Now(),
+	}
+}
+
+// latencyStream records the time from the submission of a proposal to its first output as the latency of the
+// proposal. Proposals that complete without outputs or fail are recorded when the stream is closed or fails,
+// and proposals that fail before the stream is used are recorded by calling observe.
+type latencyStream struct {
+	partition *Partition
+	stream    streams.WriteStream[*protocol.ProposalOutput]
+	start     time.Time
+	forwarded bool
+	once      sync.Once
+}
+
+// observe records the latency of the proposal if it has not already been recorded
+func (s *latencyStream) observe() {
+	s.once.Do(func() {
+		s.partition.metrics.observeProposal(s.partition, s.forwarded, time.Since(s.start))
+	})
+}
+
+func (s *latencyStream) Send(result streams.Result[*protocol.ProposalOutput]) {
+	s.observe()
+	s.stream.Send(result)
+}
+
+func (s *latencyStream) Result(value *protocol.ProposalOutput, err error) {
+	s.observe()
+	s.stream.Result(value, err)
+}
+
+func (s *latencyStream) Value(value *protocol.ProposalOutput) {
+	s.observe()
+	s.stream.Value(value)
+}
+
+func (s *latencyStream) Error(err error) {
+	s.observe()
+	s.stream.Error(err)
+}
+
+func (s *latencyStream) Close() {
+	s.observe()
+	s.stream.Close()
+}
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"fmt"
+	"github.com/atomix/runtime/sdk/pkg/logging"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const deploymentIDFile = "deployment-id"
+
+// unmanagedDeploymentID is the deployment ID used by dragonboat when no deployment ID is configured
+const unmanagedDeploymentID = uint64(1)
+
+// getDeploymentID returns the deployment ID with which to start the node host. The configured ID is persisted
+// in the data directory the first time the node starts, and the node refuses to start with an ID that does not
+// match the persisted ID, preventing a data directory from being reused by the nodes of another cluster.
+func getDeploymentID(dataDir string, deploymentID uint64) (uint64, error) {
+	path := filepath.Join(dataDir, deploymentIDFile)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		storedID, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid deployment ID in %s: %v", path, err)
+		}
+		if storedID != deploymentID {
+			return 0, fmt.Errorf("data directory %s belongs to deployment %d, not %d", dataDir, storedID, deploymentID)
+		}
+		return deploymentID, nil
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+
+	// Data written before the deployment ID was configured is stored by dragonboat under the unmanaged
+	// deployment ID, and would not be found if the node were started with another ID. The deployment ID is
+	// chosen for the whole cluster, so the node refuses to start rather than choosing an ID of its own.
+	if deploymentID != unmanagedDeploymentID {
+		if ok, err := hasUnmanagedData(dataDir); err != nil {
+			return 0, err
+		} else if ok {
+			return 0, fmt.Errorf("data directory %s contains data for unmanaged deployment %d, not %d", dataDir, unmanagedDeploymentID, deploymentID)
+		}
+	}
+
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return 0, err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(strconv.FormatUint(deploymentID, 10)), 0644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, err
+	}
+	log.Infow("Persisted deployment ID",
+		logging.String("DataDir", dataDir),
+		logging.Uint64("DeploymentID", deploymentID))
+	return deploymentID, nil
+}
+
+// hasUnmanagedData returns whether the data directory contains dragonboat data for the unmanaged deployment ID
+func hasUnmanagedData(dataDir string) (bool, error) {
+	matches, err := filepath.Glob(filepath.Join(dataDir, "*", fmt.Sprintf("%020d", unmanagedDeploymentID)))
+	if err != nil {
+		return false, err
+	}
+	return len(matches) > 0, nil
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestGetDeploymentID(t *testing.T) {
+	tests := []struct {
+		name          string
+		storedID      string
+		unmanagedData bool
+		deploymentID  uint64
+		valid         bool
+	}{
+		{
+			name:         "new data directory",
+			deploymentID: 12345,
+			valid:        true,
+		},
+		{
+			name:         "matching deployment ID",
+			storedID:     "12345",
+			deploymentID: 12345,
+			valid:        true,
+		},
+		{
+			name:         "mismatched deployment ID",
+			storedID:     "54321",
+			deploymentID: 12345,
+		},
+		{
+			name:         "invalid stored deployment ID",
+			storedID:     "invalid",
+			deploymentID: 12345,
+		},
+		{
+			name:          "unmanaged data",
+			unmanagedData: true,
+			deploymentID:  12345,
+		},
+		{
+			name:          "unmanaged data with unmanaged deployment ID",
+			unmanagedData: true,
+			deploymentID:  unmanagedDeploymentID,
+			valid:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dataDir := t.TempDir()
+			if test.storedID != "" {
+				if err := os.WriteFile(filepath.Join(dataDir, deploymentIDFile), []byte(test.storedID+"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if test.unmanagedData {
+				if err := os.MkdirAll(filepath.Join(dataDir, "host", fmt.Sprintf("%020d", unmanagedDeploymentID)), 0755); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			deploymentID, err := getDeploymentID(dataDir, test.deploymentID)
+			if !test.valid {
+				if err == nil {
+					t.Fatal("expected node to refuse to start")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deploymentID != test.deploymentID {
+				t.Errorf("expected deployment ID %d, got %d", test.deploymentID, deploymentID)
+			}
+
+			// The deployment ID is persisted for later restarts
+			data, err := os.ReadFile(filepath.Join(dataDir, deploymentIDFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.storedID == "" && string(data) != strconv.FormatUint(test.deploymentID, 10) {
+				t.Errorf("expected persisted deployment ID %d, got %s", test.deploymentID, data)
+			}
+		})
+	}
+}
+
+func TestGetDeploymentIDRestart(t *testing.T) {
+	dataDir := t.TempDir()
+	if _, err := getDeploymentID(dataDir, 12345); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := getDeploymentID(dataDir, 12345); err != nil {
+		t.Fatalf("expected node to restart with the persisted deployment ID, got %v", err)
+	}
+	if _, err := getDeploymentID(dataDir, 54321); err == nil {
+		t.Fatal("expected node to refuse to start with another cluster's deployment ID")
+	}
+}
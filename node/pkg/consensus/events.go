@@ -20,6 +20,7 @@ type eventListener struct {
 }
 
 func (e *eventListener) publish(event *Event) {
+	e.protocol.options.Metrics.recordEvent(event)
 	e.protocol.publish(event)
 }
 
@@ -40,11 +41,37 @@ func (e *eventListener) LeaderUpdated(info raftio.LeaderInfo) {
 }
 
 func (e *eventListener) NodeHostShuttingDown() {
-
+	e.protocol.mu.RLock()
+	members := make([]MemberEvent, 0, len(e.protocol.partitions))
+	for _, partition := range e.protocol.partitions {
+		members = append(members, MemberEvent{
+			GroupID:  GroupID(partition.ID()),
+			MemberID: partition.memberID,
+		})
+	}
+	e.protocol.mu.RUnlock()
+	e.publish(&Event{
+		Timestamp: time.Now(),
+		Event: &Event_HostShuttingDown{
+			HostShuttingDown: &HostShuttingDownEvent{
+				Members: members,
+			},
+		},
+	})
 }
 
 func (e *eventListener) NodeUnloaded(info raftio.NodeInfo) {
-
+	e.publish(&Event{
+		Timestamp: time.Now(),
+		Event: &Event_MemberUnloaded{
+			MemberUnloaded: &MemberUnloadedEvent{
+				MemberEvent: MemberEvent{
+					GroupID:  GroupID(info.ClusterID),
+					MemberID: MemberID(info.NodeID),
+				},
+			},
+		},
+	})
 }
 
 func (e *eventListener) NodeReady(info raftio.NodeInfo) {
@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"github.com/lni/dragonboat/v3/raftio"
+	"sort"
+	"testing"
+)
+
+func TestMemberUnloaded(t *testing.T) {
+	unloaded := newTestMemberPartition(nil, 1, 1)
+	unloaded.setReady()
+	loaded := newTestMemberPartition(nil, 2, 1)
+	loaded.setReady()
+	p := newTestWatchProtocol(unloaded, loaded)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := p.Watch(ctx, &WatchRequest{EventTypes: []EventType{EventType_MEMBER_UNLOADED}})
+
+	newEventListener(p).NodeUnloaded(raftio.NodeInfo{ClusterID: 1, NodeID: 1})
+
+	events := readTestEvents(t, watcher, 1)
+	event, ok := events[0].Event.(*Event_MemberUnloaded)
+	if !ok {
+		t.Fatalf("expected MemberUnloaded event, got %s", getEventType(&events[0]))
+	}
+	if event.MemberUnloaded.GroupID != 1 || event.MemberUnloaded.MemberID != 1 {
+		t.Errorf("expected member 1 of group 1 to be unloaded, got %v", event.MemberUnloaded.MemberEvent)
+	}
+	if unloaded.getReady() {
+		t.Error("expected unloaded member to be marked not ready")
+	}
+	if !loaded.getReady() {
+		t.Error("expected other members to remain ready")
+	}
+}
+
+func TestHostShuttingDown(t *testing.T) {
+	partitions := []*Partition{
+		newTestMemberPartition(nil, 1, 1),
+		newTestMemberPartition(nil, 2, 3),
+	}
+	for _, partition := range partitions {
+		partition.setReady()
+	}
+	p := newTestWatchProtocol(partitions...)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := p.Watch(ctx, &WatchRequest{EventTypes: []EventType{EventType_HOST_SHUTTING_DOWN}})
+
+	newEventListener(p).NodeHostShuttingDown()
+
+	events := readTestEvents(t, watcher, 1)
+	event, ok := events[0].Event.(*Event_HostShuttingDown)
+	if !ok {
+		t.Fatalf("expected HostShuttingDown event, got %s", getEventType(&events[0]))
+	}
+	members := event.HostShuttingDown.Members
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].GroupID < members[j].GroupID
+	})
+	expected := []MemberEvent{
+		{GroupID: 1, MemberID: 1},
+		{GroupID: 2, MemberID: 3},
+	}
+	if len(members) != len(expected) {
+		t.Fatalf("expected %d members, got %d", len(expected), len(members))
+	}
+	for i, member := range members {
+		if member != expected[i] {
+			t.Errorf("expected member %v, got %v", expected[i], member)
+		}
+	}
+	for _, partition := range partitions {
+		if partition.getReady() {
+			t.Errorf("expected member of group %d to be marked not ready", partition.ID())
+		}
+	}
+}
+
+func TestHostShuttingDownFilteredByGroup(t *testing.T) {
+	// The shutdown event relates to every member on the host, so it's delivered to watchers of any group
+	p := newTestWatchProtocol(newTestMemberPartition(nil, 1, 1))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	watcher := p.Watch(ctx, &WatchRequest{GroupIDs: []GroupID{2}})
+
+	newEventListener(p).NodeUnloaded(raftio.NodeInfo{ClusterID: 1, NodeID: 1})
+	newEventListener(p).NodeHostShuttingDown()
+
+	events := readTestEvents(t, watcher, 1)
+	if eventType := getEventType(&events[0]); eventType != EventType_HOST_SHUTTING_DOWN {
+		t.Fatalf("expected HostShuttingDown event, got %s", eventType)
+	}
+}
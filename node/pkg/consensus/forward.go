@@ -0,0 +1,280 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/logging"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	streams "github.com/atomix/runtime/sdk/pkg/stream"
+	"github.com/gogo/protobuf/proto"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"io"
+	"sync"
+)
+
+// forwardedKey is the context key marking proposals forwarded by a follower, which must not be forwarded again
+type forwardedKey struct{}
+
+func newForwarder(certs *Certificates) *forwarder {
+	creds := insecure.NewCredentials()
+	if certs != nil {
+		creds = credentials.NewTLS(certs.ClientConfig())
+	}
+	return &forwarder{
+		creds: creds,
+		conns: make(map[string]*grpc.ClientConn),
+	}
+}
+
+// forwarder relays proposals received by followers to the node hosting the leader of the group, and resolves
+// the index applied by the leader to bound the staleness of reads served by followers
+type forwarder struct {
+	creds credentials.TransportCredentials
+	conns map[string]*grpc.ClientConn
+	mu    sync.RWMutex
+}
+
+// propose forwards the given input to the leader identified by the hint, writing the leader's outputs to the stream
+func (f *forwarder) propose(ctx context.Context, hint *LeaderHint, input *protocol.ProposalInput, stream streams.WriteStream[*protocol.ProposalOutput]) error {
+	inputBytes, err := proto.Marshal(input)
+	if err != nil {
+		return errors.NewInternal(err.Error())
+	}
+
+	conn, err := f.connect(fmt.Sprintf("%s:%d", hint.Host, hint.Port))
+	if err != nil {
+		return err
+	}
+
+	log.Debugw("Forwarding proposal",
+		logging.Uint32("GroupID", uint32(hint.GroupID)),
+		logging.Uint32("Leader", uint32(hint.Leader)))
+	client := NewForwarderClient(conn)
+	request := &ForwardProposalRequest{
+		GroupID: hint.GroupID,
+		Input:   inputBytes,
+	}
+	proposeClient, err := client.Propose(ctx, request)
+	if err != nil {
+		return errors.FromProto(err)
+	}
+
+	defer stream.Close()
+
+	// Propagate the index at which the leader applied the proposal to the caller
+	if header, err := proposeClient.Header(); err == nil {
+		if values := header.Get(AppliedIndexHeader); len(values) > 0 {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(AppliedIndexHeader, values[0]))
+		}
+	}
+
+	for {
+		response, err := proposeClient.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			stream.Error(errors.FromProto(err))
+			return nil
+		}
+		output := &protocol.ProposalOutput{}
+		if err := proto.Unmarshal(response.Output, output); err != nil {
+			stream.Error(errors.NewInternal("failed to unmarshal ProposalOutput: %v", err))
+			return nil
+		}
+		stream.Value(output)
+	}
+}
+
+// getAppliedIndex returns the index applied by the leader identified by the hint
+func (f *forwarder) getAppliedIndex(ctx context.Context, hint *LeaderHint) (Index, error) {
+	conn, err := f.connect(fmt.Sprintf("%s:%d", hint.Host, hint.Port))
+	if err != nil {
+		return 0, err
+	}
+	client := NewForwarderClient(conn)
+	response, err := client.GetAppliedIndex(ctx, &GetAppliedIndexRequest{GroupID: hint.GroupID})
+	if err != nil {
+		return 0, errors.FromProto(err)
+	}
+	return response.Index, nil
+}
+
+func (f *forwarder) connect(address string) (*grpc.ClientConn, error) {
+	f.mu.RLock()
+	conn, ok := f.conns[address]
+	f.mu.RUnlock()
+	if ok {
+		return conn, nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	conn, ok = f.conns[address]
+	if ok {
+		return conn, nil
+	}
+	conn, err := grpc.Dial(address,
+		grpc.WithTransportCredentials(f.creds),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()))
+	if err != nil {
+		return nil, errors.NewUnavailable("failed to connect to leader at %s: %v", address, err)
+	}
+	f.conns[address] = conn
+	return conn, nil
+}
+
+func (f *forwarder) close() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for address, conn := range f.conns {
+		_ = conn.Close()
+		delete(f.conns, address)
+	}
+}
+
+// newNotLeaderError returns an Unavailable error identifying the leader of the group
+func newNotLeaderError(hint *LeaderHint) error {
+	return &notLeaderError{
+		hint: hint,
+	}
+}
+
+// notLeaderError is returned by followers when forwarding is disabled. The error is converted to an
+// Unavailable status carrying the LeaderHint as a detail.
+type notLeaderError struct {
+	hint *LeaderHint
+}
+
+func (e *notLeaderError) Error() string {
+	return fmt.Sprintf("not the leader: group %d is led by member %d at %s:%d", e.hint.GroupID, e.hint.Leader, e.hint.Host, e.hint.Port)
+}
+
+// GRPCStatus returns the gRPC status for the error
+func (e *notLeaderError) GRPCStatus() *status.Status {
+	s := status.New(codes.Unavailable, e.Error())
+	if details, err := s.WithDetails(e.hint); err == nil {
+		return details
+	}
+	return s
+}
+
+var _ error = &notLeaderError{}
+
+func NewForwarderServer(protocol *Protocol) ForwarderServer {
+	return &forwarderServer{
+		protocol: protocol,
+	}
+}
+
+// forwarderServer handles proposals forwarded by followers and requests for the leader's applied index
+type forwarderServer struct {
+	protocol *Protocol
+}
+
+func (s *forwarderServer) Propose(request *ForwardProposalRequest, server Forwarder_ProposeServer) error {
+	log.Debugw("Propose",
+		logging.Stringer("ForwardProposalRequest", request))
+	partition, ok := s.protocol.Partition(protocol.PartitionID(request.GroupID))
+	if !ok {
+		err := errors.NewUnavailable("unknown group %d", request.GroupID)
+		log.Warnw("Propose",
+			logging.Stringer("ForwardProposalRequest", request),
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
+
+	input := &protocol.ProposalInput{}
+	if err := proto.Unmarshal(request.Input, input); err != nil {
+		err = errors.NewInvalid("failed to unmarshal ProposalInput: %v", err)
+		log.Warnw("Propose",
+			logging.Stringer("ForwardProposalRequest", request),
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
+
+	ctx := context.WithValue(server.Context(), forwardedKey{}, true)
+	resultCh := make(chan streams.Result[*protocol.ProposalOutput])
+	if err := partition.StreamPropose(ctx, input, streams.NewChannelStream[*protocol.ProposalOutput](resultCh)); err != nil {
+		log.Warnw("Propose",
+			logging.Stringer("ForwardProposalRequest", request),
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
+
+	// Drain the remaining results if the stream fails to avoid blocking the partition
+	defer func() {
+		go func() {
+			for range resultCh {
+			}
+		}()
+	}()
+
+	for result := range resultCh {
+		if result.Failed() {
+			log.Debugw("Propose",
+				logging.Stringer("ForwardProposalRequest", request),
+				logging.Error("Error", result.Error))
+			return errors.ToProto(result.Error)
+		}
+		outputBytes, err := proto.Marshal(result.Value)
+		if err != nil {
+			return errors.ToProto(errors.NewInternal(err.Error()))
+		}
+		if err := server.Send(&ForwardProposalResponse{Output: outputBytes}); err != nil {
+			log.Warnw("Propose",
+				logging.Stringer("ForwardProposalRequest", request),
+				logging.Error("Error", err))
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *forwarderServer) GetAppliedIndex(ctx context.Context, request *GetAppliedIndexRequest) (*GetAppliedIndexResponse, error) {
+	log.Debugw("GetAppliedIndex",
+		logging.Stringer("GetAppliedIndexRequest", request))
+	s.protocol.mu.RLock()
+	partition, ok := s.protocol.partitions[protocol.PartitionID(request.GroupID)]
+	s.protocol.mu.RUnlock()
+	if !ok {
+		err := errors.NewUnavailable("unknown group %d", request.GroupID)
+		log.Warnw("GetAppliedIndex",
+			logging.Stringer("GetAppliedIndexRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+
+	// Only the leader's applied index bounds the staleness of a follower's reads
+	if _, leader := partition.getLeader(); leader != partition.memberID {
+		err := errors.NewUnavailable("not the leader of group %d", request.GroupID)
+		log.Debugw("GetAppliedIndex",
+			logging.Stringer("GetAppliedIndexRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &GetAppliedIndexResponse{
+		Index: partition.getAppliedIndex(),
+	}
+	log.Debugw("GetAppliedIndex",
+		logging.Stringer("GetAppliedIndexRequest", request),
+		logging.Stringer("GetAppliedIndexResponse", response))
+	return response, nil
+}
+
+func isForwarded(ctx context.Context) bool {
+	forwarded, _ := ctx.Value(forwardedKey{}).(bool)
+	return forwarded
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestForwardProposals(t *testing.T) {
+	cluster := newTestCluster(t, 3, WithProposalForwarding(true))
+	leader := cluster.bootstrap(t, 1)
+	follower := cluster.partition(t, (leader+1)%len(cluster.nodes), 1)
+	ctx := context.Background()
+
+	sessionID := openTestSession(t, follower)
+	counterID := createTestCounter(t, follower, sessionID, 1)
+	if value := incrementTestCounter(t, ctx, follower, newTestIncrementInput(t, sessionID, 2, counterID)); value != 1 {
+		t.Fatalf("expected counter value 1, got %d", value)
+	}
+	value, err := getTestCounter(ctx, cluster.partition(t, leader, 1), sessionID, counterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 1 {
+		t.Fatalf("expected forwarded increment to be applied by the leader, got counter value %d", value)
+	}
+
+	// Proposals forwarded to a member that is no longer the leader are not forwarded again
+	forwardedCtx := context.WithValue(ctx, forwardedKey{}, true)
+	if _, err := follower.Propose(forwardedCtx, newOpenSessionInput()); err == nil {
+		t.Fatal("expected forwarded proposal to be rejected by a follower")
+	}
+}
+
+func TestNotLeaderHint(t *testing.T) {
+	cluster := newTestCluster(t, 3)
+	leader := cluster.bootstrap(t, 1)
+	follower := cluster.partition(t, (leader+1)%len(cluster.nodes), 1)
+
+	_, err := follower.Propose(context.Background(), newOpenSessionInput())
+	if err == nil {
+		t.Fatal("expected follower to reject proposal")
+	}
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.Unavailable {
+		t.Fatalf("expected Unavailable status, got %v", err)
+	}
+	details := s.Proto().Details
+	if len(details) != 1 {
+		t.Fatalf("expected leader hint in status details, got %v", details)
+	}
+	hint := &LeaderHint{}
+	if err := proto.Unmarshal(details[0].Value, hint); err != nil {
+		t.Fatal(err)
+	}
+	expected := LeaderHint{
+		GroupID: 1,
+		Term:    hint.Term,
+		Leader:  MemberID(leader + 1),
+		Host:    cluster.hosts[leader],
+		Port:    int32(cluster.apiPort),
+	}
+	if *hint != expected || hint.Term == 0 {
+		t.Errorf("expected leader hint %v, got %v", expected, hint)
+	}
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	raftmetrics "github.com/VictoriaMetrics/metrics"
+	"github.com/lni/dragonboat/v3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const metricsNamespace = "atomix_consensus"
+
+const (
+	partitionLabel = "partition"
+	memberLabel    = "member"
+	roleLabel      = "role"
+	forwardedLabel = "forwarded"
+	readModeLabel  = "read_mode"
+	eventTypeLabel = "type"
+	policyLabel    = "policy"
+)
+
+const (
+	leaderRole   = "leader"
+	followerRole = "follower"
+	observerRole = "observer"
+	witnessRole  = "witness"
+)
+
+// ReadMode is the mode in which a query reads the state of a partition
+type ReadMode string
+
+const (
+	// LinearizableRead is a query confirmed by a quorum of the partition
+	LinearizableRead ReadMode = "linearizable"
+	// BoundedRead is a query that waits for the partition to apply a minimum index
+	BoundedRead ReadMode = "bounded"
+	// LocalRead is a query served from the local state of the partition
+	LocalRead ReadMode = "local"
+)
+
+// NewMetrics returns a new set of node metrics
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+	metrics := &Metrics{
+		registry: registry,
+		proposalLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "proposal_latency_seconds",
+			Help:      "The time from the submission of a proposal to its first output, completion or failure",
+			Buckets:   prometheus.ExponentialBuckets(.0005, 2, 16),
+		}, []string{partitionLabel, forwardedLabel}),
+		queryLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "query_latency_seconds",
+			Help:      "The time taken to serve a query",
+			Buckets:   prometheus.ExponentialBuckets(.0001, 2, 16),
+		}, []string{partitionLabel, readModeLabel}),
+		events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "events_total",
+			Help:      "The number of Raft events observed by the node",
+		}, []string{eventTypeLabel}),
+		droppedEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "watch_dropped_events_total",
+			Help:      "The number of events not delivered to watchers that fell behind",
+		}, []string{policyLabel}),
+	}
+	registry.MustRegister(
+		metrics.proposalLatency,
+		metrics.queryLatency,
+		metrics.events,
+		metrics.droppedEvents,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	return metrics
+}
+
+// Metrics exports the metrics of a node in the Prometheus format. Dragonboat's Raft metrics are exported
+// alongside the node's metrics. Methods are safe to call on a nil Metrics, in which case metrics are not recorded.
+type Metrics struct {
+	registry        *prometheus.Registry
+	proposalLatency *prometheus.HistogramVec
+	queryLatency    *prometheus.HistogramVec
+	events          *prometheus.CounterVec
+	droppedEvents   *prometheus.CounterVec
+}
+
+// register registers the per-partition metrics of the given protocol, which are collected when scraped
+func (m *Metrics) register(protocol *Protocol) {
+	if m == nil {
+		return
+	}
+	m.registry.MustRegister(newPartitionCollector(protocol))
+}
+
+// observeProposal records the latency of a proposal to the given partition. Proposals forwarded to the leader
+// are recorded separately, and are also recorded by the leader.
+func (m *Metrics) observeProposal(partition *Partition, forwarded bool, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.proposalLatency.WithLabelValues(getPartitionLabel(partition), strconv.FormatBool(forwarded)).Observe(latency.Seconds())
+}
+
+// observeQuery records the latency of a query to the given partition
+func (m *Metrics) observeQuery(partition *Partition, mode ReadMode, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.queryLatency.WithLabelValues(getPartitionLabel(partition), string(mode)).Observe(latency.Seconds())
+}
+
+// recordEvent counts the given event by type
+func (m *Metrics) recordEvent(event *Event) {
+	if m == nil || event.Event == nil {
+		return
+	}
+	eventType := strings.TrimPrefix(reflect.TypeOf(event.Event).Elem().Name(), "Event_")
+	m.events.WithLabelValues(eventType).Inc()
+}
+
+// recordDroppedEvent counts an event not delivered to a watcher that fell behind
+func (m *Metrics) recordDroppedEvent(policy WatchOverflowPolicy) {
+	if m == nil {
+		return
+	}
+	m.droppedEvents.WithLabelValues(string(policy)).Inc()
+}
+
+// Handler returns an HTTP handler serving the node's metrics
+func (m *Metrics) Handler() http.Handler {
+	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		// Dragonboat's metrics are appended to the response, which must not be compressed
+		DisableCompression: true,
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+		raftmetrics.WritePrometheus(w, false)
+	})
+}
+
+func getPartitionLabel(partition *Partition) string {
+	return strconv.Itoa(int(partition.ID()))
+}
+
+func newPartitionCollector(protocol *Protocol) prometheus.Collector {
+	labels := []string{partitionLabel, memberLabel}
+	return &partitionCollector{
+		protocol: protocol,
+		term: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "partition", "term"),
+			"The current Raft term of the partition", labels, nil),
+		leader: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "partition", "leader"),
+			"The member ID of the leader of the partition, or 0 if no leader is known", labels, nil),
+		role: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "partition", "role"),
+			"The role of the local member of the partition", append(labels, roleLabel), nil),
+		ready: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "partition", "ready"),
+			"Whether the local member of the partition is ready", labels, nil),
+		appliedIndex: prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "partition", "applied_index"),
+			"The index of the last entry applied by the local member of the partition", labels, nil),
+	}
+}
+
+// partitionCollector collects the state of each partition hosted by the node when scraped
+type partitionCollector struct {
+	protocol     *Protocol
+	term         *prometheus.Desc
+	leader       *prometheus.Desc
+	role         *prometheus.Desc
+	ready        *prometheus.Desc
+	appliedIndex *prometheus.Desc
+}
+
+func (c *partitionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.term
+	ch <- c.leader
+	ch <- c.role
+	ch <- c.ready
+	ch <- c.appliedIndex
+}
+
+func (c *partitionCollector) Collect(ch chan<- prometheus.Metric) {
+	c.protocol.mu.RLock()
+	partitions := make([]*Partition, 0, len(c.protocol.partitions))
+	for _, partition := range c.protocol.partitions {
+		partitions = append(partitions, partition)
+	}
+	c.protocol.mu.RUnlock()
+
+	// The roles of the local members are read from the node host, since observers may be promoted
+	infos := make(map[uint64]dragonboat.ClusterInfo)
+	if c.protocol.host != nil {
+		for _, info := range c.protocol.host.GetNodeHostInfo(dragonboat.NodeHostInfoOption{SkipLogInfo: true}).ClusterInfoList {
+			infos[info.ClusterID] = info
+		}
+	}
+
+	for _, partition := range partitions {
+		partitionID := getPartitionLabel(partition)
+		memberID := strconv.Itoa(int(partition.memberID))
+		term, leader := partition.getLeader()
+		info := infos[uint64(partition.ID())]
+		role := followerRole
+		switch {
+		case leader == partition.memberID:
+			role = leaderRole
+		case info.IsObserver:
+			role = observerRole
+		case info.IsWitness:
+			role = witnessRole
+		}
+		var ready float64
+		if partition.getReady() {
+			ready = 1
+		}
+		ch <- prometheus.MustNewConstMetric(c.term, prometheus.GaugeValue, float64(term), partitionID, memberID)
+		ch <- prometheus.MustNewConstMetric(c.leader, prometheus.GaugeValue, float64(leader), partitionID, memberID)
+		ch <- prometheus.MustNewConstMetric(c.role, prometheus.GaugeValue, 1, partitionID, memberID, role)
+		ch <- prometheus.MustNewConstMetric(c.ready, prometheus.GaugeValue, ready, partitionID, memberID)
+		ch <- prometheus.MustNewConstMetric(c.appliedIndex, prometheus.GaugeValue, float64(partition.getAppliedIndex()), partitionID, memberID)
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	streams "github.com/atomix/runtime/sdk/pkg/stream"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRecordEvent(t *testing.T) {
+	metrics := NewMetrics()
+	events := []Event{
+		newTestEvent(1),
+		newTestEvent(2),
+		{
+			Event: &Event_SnapshotCreated{
+				SnapshotCreated: &SnapshotCreatedEvent{},
+			},
+		},
+		{
+			Event: &Event_ConnectionFailed{
+				ConnectionFailed: &ConnectionFailedEvent{},
+			},
+		},
+		{},
+	}
+	for _, event := range events {
+		metrics.recordEvent(&event)
+	}
+
+	tests := []struct {
+		eventType string
+		count     float64
+	}{
+		{eventType: "LeaderUpdated", count: 2},
+		{eventType: "SnapshotCreated", count: 1},
+		{eventType: "ConnectionFailed", count: 1},
+		{eventType: "SnapshotCompacted", count: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.eventType, func(t *testing.T) {
+			if count := testutil.ToFloat64(metrics.events.WithLabelValues(test.eventType)); count != test.count {
+				t.Errorf("expected %v %s events, got %v", test.count, test.eventType, count)
+			}
+		})
+	}
+}
+
+func TestLatencyStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		forwarded bool
+		send      func(stream streams.WriteStream[*protocol.ProposalOutput])
+	}{
+		{
+			name: "outputs",
+			send: func(stream streams.WriteStream[*protocol.ProposalOutput]) {
+				stream.Value(&protocol.ProposalOutput{})
+				stream.Value(&protocol.ProposalOutput{})
+				stream.Close()
+			},
+		},
+		{
+			name: "failed",
+			send: func(stream streams.WriteStream[*protocol.ProposalOutput]) {
+				stream.Error(errors.NewUnavailable("failed"))
+				stream.Close()
+			},
+		},
+		{
+			name: "no outputs",
+			send: func(stream streams.WriteStream[*protocol.ProposalOutput]) {
+				stream.Close()
+			},
+		},
+		{
+			name:      "forwarded",
+			forwarded: true,
+			send: func(stream streams.WriteStream[*protocol.ProposalOutput]) {
+				stream.Value(&protocol.ProposalOutput{})
+				stream.Close()
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metrics := NewMetrics()
+			partition := newTestMemberPartition(metrics, 1, 1)
+			stream := newLatencyStream(partition, streams.NewNilStream[*protocol.ProposalOutput]())
+			stream.forwarded = test.forwarded
+			test.send(stream)
+			// Each proposal is recorded once, however many outputs it has
+			stream.observe()
+			families, err := metrics.registry.Gather()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var count uint64
+			for _, family := range families {
+				if family.GetName() != "atomix_consensus_proposal_latency_seconds" {
+					continue
+				}
+				for _, metric := range family.GetMetric() {
+					for _, label := range metric.GetLabel() {
+						if label.GetName() == forwardedLabel && label.GetValue() != strconv.FormatBool(test.forwarded) {
+							t.Errorf("expected proposal recorded with %s=%t", forwardedLabel, test.forwarded)
+						}
+					}
+					count += metric.GetHistogram().GetSampleCount()
+				}
+			}
+			if count != 1 {
+				t.Errorf("expected 1 recorded proposal, got %d", count)
+			}
+		})
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var metrics *Metrics
+	partition := newTestMemberPartition(metrics, 1, 1)
+	event := newTestEvent(1)
+	metrics.register(&Protocol{})
+	metrics.recordEvent(&event)
+	metrics.recordDroppedEvent(DropWatchOverflow)
+	metrics.observeQuery(partition, LocalRead, 0)
+	stream := newLatencyStream(partition, streams.NewNilStream[*protocol.ProposalOutput]())
+	stream.Close()
+}
+
+func TestPartitionCollector(t *testing.T) {
+	leader := newTestMemberPartition(nil, 1, 1)
+	leader.setLeader(2, 1)
+	leader.setReady()
+	leader.setAppliedIndex(10)
+	follower := newTestMemberPartition(nil, 2, 3)
+	follower.setLeader(1, 2)
+	follower.setAppliedIndex(5)
+	p := &Protocol{
+		partitions: map[protocol.PartitionID]*Partition{
+			1: leader,
+			2: follower,
+		},
+	}
+
+	expected := `
+# HELP atomix_consensus_partition_applied_index The index of the last entry applied by the local member of the partition
+# TYPE atomix_consensus_partition_applied_index gauge
+atomix_consensus_partition_applied_index{member="1",partition="1"} 10
+atomix_consensus_partition_applied_index{member="3",partition="2"} 5
+# HELP atomix_consensus_partition_leader The member ID of the leader of the partition, or 0 if no leader is known
+# TYPE atomix_consensus_partition_leader gauge
+atomix_consensus_partition_leader{member="1",partition="1"} 1
+atomix_consensus_partition_leader{member="3",partition="2"} 2
+# HELP atomix_consensus_partition_ready Whether the local member of the partition is ready
+# TYPE atomix_consensus_partition_ready gauge
+atomix_consensus_partition_ready{member="1",partition="1"} 1
+atomix_consensus_partition_ready{member="3",partition="2"} 0
+# HELP atomix_consensus_partition_role The role of the local member of the partition
+# TYPE atomix_consensus_partition_role gauge
+atomix_consensus_partition_role{member="1",partition="1",role="leader"} 1
+atomix_consensus_partition_role{member="3",partition="2",role="follower"} 1
+# HELP atomix_consensus_partition_term The current Raft term of the partition
+# TYPE atomix_consensus_partition_term gauge
+atomix_consensus_partition_term{member="1",partition="1"} 2
+atomix_consensus_partition_term{member="3",partition="2"} 1
+`
+	if err := testutil.CollectAndCompare(newPartitionCollector(p), strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	metrics := NewMetrics()
+	event := newTestEvent(1)
+	metrics.recordEvent(&event)
+	server := httptest.NewServer(metrics.Handler())
+	defer server.Close()
+
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", response.StatusCode)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `atomix_consensus_events_total{type="LeaderUpdated"} 1`) {
+		t.Errorf("expected event counter in response, got %s", body)
+	}
+}
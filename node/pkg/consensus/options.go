@@ -5,16 +5,34 @@
 package consensus
 
 const (
-	defaultPort = 8080
+	defaultPort    = 8080
+	defaultAPIPort = 8080
 )
 
 type Options struct {
 	Host string
 	Port int
+	// APIPort is the port of the API server, used to reach the leaders of groups hosted on other nodes
+	APIPort int
+	// ForwardProposals indicates whether proposals received by followers are forwarded to the leader
+	ForwardProposals bool
+	// SyncProposals disables pipelining, blocking each proposal until it has been applied
+	SyncProposals bool
+	// Certificates enables mutual TLS for the Raft transport and for connections to other nodes
+	Certificates *Certificates
+	// Metrics records the node's metrics
+	Metrics *Metrics
+	// WatchQueueSize is the number of events queued for each watcher before the overflow policy is applied
+	WatchQueueSize int
+	// WatchOverflowPolicy is the policy applied to watchers that fall behind
+	WatchOverflowPolicy WatchOverflowPolicy
 }
 
 func (o *Options) apply(opts ...Option) {
 	o.Port = defaultPort
+	o.APIPort = defaultAPIPort
+	o.WatchQueueSize = defaultWatchQueueSize
+	o.WatchOverflowPolicy = DropWatchOverflow
 	for _, opt := range opts {
 		opt(o)
 	}
@@ -39,3 +57,45 @@ func WithPort(port int) Option {
 		options.Port = port
 	}
 }
+
+func WithAPIPort(port int) Option {
+	return func(options *Options) {
+		options.APIPort = port
+	}
+}
+
+func WithProposalForwarding(forward bool) Option {
+	return func(options *Options) {
+		options.ForwardProposals = forward
+	}
+}
+
+func WithSyncProposals(sync bool) Option {
+	return func(options *Options) {
+		options.SyncProposals = sync
+	}
+}
+
+func WithCertificates(certs *Certificates) Option {
+	return func(options *Options) {
+		options.Certificates = certs
+	}
+}
+
+func WithMetrics(metrics *Metrics) Option {
+	return func(options *Options) {
+		options.Metrics = metrics
+	}
+}
+
+func WithWatchQueueSize(size int) Option {
+	return func(options *Options) {
+		options.WatchQueueSize = size
+	}
+}
+
+func WithWatchOverflowPolicy(policy WatchOverflowPolicy) Option {
+	return func(options *Options) {
+		options.WatchOverflowPolicy = policy
+	}
+}
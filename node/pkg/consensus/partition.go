@@ -7,23 +7,55 @@ package consensus
 import (
 	"context"
 	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/logging"
 	"github.com/atomix/runtime/sdk/pkg/protocol"
 	"github.com/atomix/runtime/sdk/pkg/protocol/node"
 	streams "github.com/atomix/runtime/sdk/pkg/stream"
 	"github.com/gogo/protobuf/proto"
 	"github.com/lni/dragonboat/v3"
+	"github.com/lni/dragonboat/v3/client"
+	dbstatemachine "github.com/lni/dragonboat/v3/statemachine"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"net"
+	"strconv"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
-func newPartition(id protocol.PartitionID, memberID MemberID, host *dragonboat.NodeHost, streams *protocolContext) *Partition {
+const (
+	// AppliedIndexHeader is the response header carrying the Raft index at which a proposal was applied
+	AppliedIndexHeader = "raft-applied-index"
+	// MinIndexHeader is the request header carrying the minimum Raft index a query must observe. Queries
+	// that are not linearizable wait for the partition to apply the index before reading its state.
+	MinIndexHeader = "raft-min-index"
+	// SyncReadHeader is the request header requesting a linearizable read of the partition's state
+	SyncReadHeader = "raft-sync-read"
+	// MaxLagHeader is the request header carrying the maximum number of entries by which a query may trail the
+	// index applied by the leader. Queries served by followers wait for the partition to apply the leader's
+	// applied index, less the lag, before reading its state.
+	MaxLagHeader = "raft-max-lag"
+)
+
+func newPartition(id protocol.PartitionID, memberID MemberID, host *dragonboat.NodeHost, streams *protocolContext, config RaftConfig, options Options, forwarder *forwarder) *Partition {
 	partition := &Partition{
 		memberID: memberID,
+		metrics:  options.Metrics,
+	}
+	if !options.SyncProposals {
+		partition.proposer = newProposer(host, GroupID(id), config.GetProposalTimeout())
 	}
 	partition.Partition = node.NewPartition(id, &Executor{
 		Partition: partition,
 		host:      host,
 		streams:   streams,
+		sessions:  newSessionManager(partition, host),
+		timeout:   config.GetProposalTimeout(),
+		apiPort:   options.APIPort,
+		forwarder: forwarder,
+		forward:   options.ForwardProposals,
 	})
 	return partition
 }
@@ -34,12 +66,30 @@ type Partition struct {
 	ready    int32
 	leader   uint64
 	term     uint64
+	applied  uint64
+	snapshot uint64
+	proposer *proposer
+	metrics  *Metrics
+	// waiters are the queries waiting for the partition to apply an index
+	waiters    []*indexWaiter
+	numWaiters int32
+	waitersMu  sync.Mutex
+}
+
+// indexWaiter is a query waiting for the partition to apply an index
+type indexWaiter struct {
+	index Index
+	ch    chan struct{}
 }
 
 func (p *Partition) setReady() {
 	atomic.StoreInt32(&p.ready, 1)
 }
 
+func (p *Partition) setNotReady() {
+	atomic.StoreInt32(&p.ready, 0)
+}
+
 func (p *Partition) getReady() bool {
 	return atomic.LoadInt32(&p.ready) == 1
 }
@@ -53,17 +103,119 @@ func (p *Partition) getLeader() (Term, MemberID) {
 	return Term(atomic.LoadUint64(&p.term)), MemberID(atomic.LoadUint64(&p.leader))
 }
 
+func (p *Partition) setAppliedIndex(index Index) {
+	atomic.StoreUint64(&p.applied, uint64(index))
+	if atomic.LoadInt32(&p.numWaiters) > 0 {
+		p.notifyWaiters(index)
+	}
+}
+
+func (p *Partition) getAppliedIndex() Index {
+	return Index(atomic.LoadUint64(&p.applied))
+}
+
+// awaitAppliedIndex waits until the partition has applied the given index
+func (p *Partition) awaitAppliedIndex(ctx context.Context, index Index) error {
+	if p.getAppliedIndex() >= index {
+		return nil
+	}
+
+	waiter := &indexWaiter{
+		index: index,
+		ch:    make(chan struct{}),
+	}
+	p.waitersMu.Lock()
+	p.waiters = append(p.waiters, waiter)
+	atomic.StoreInt32(&p.numWaiters, int32(len(p.waiters)))
+	p.waitersMu.Unlock()
+
+	// The index may have been applied before the waiter was added
+	if appliedIndex := p.getAppliedIndex(); appliedIndex >= index {
+		p.notifyWaiters(appliedIndex)
+	}
+
+	select {
+	case <-waiter.ch:
+		return nil
+	case <-ctx.Done():
+		p.removeWaiter(waiter)
+		return errors.NewTimeout("timed out waiting for group %d to apply index %d", p.ID(), index)
+	}
+}
+
+func (p *Partition) notifyWaiters(index Index) {
+	p.waitersMu.Lock()
+	defer p.waitersMu.Unlock()
+	waiters := p.waiters[:0]
+	for _, waiter := range p.waiters {
+		if waiter.index <= index {
+			close(waiter.ch)
+		} else {
+			waiters = append(waiters, waiter)
+		}
+	}
+	p.waiters = waiters
+	atomic.StoreInt32(&p.numWaiters, int32(len(waiters)))
+}
+
+func (p *Partition) removeWaiter(waiter *indexWaiter) {
+	p.waitersMu.Lock()
+	defer p.waitersMu.Unlock()
+	for i, w := range p.waiters {
+		if w == waiter {
+			p.waiters = append(p.waiters[:i], p.waiters[i+1:]...)
+			break
+		}
+	}
+	atomic.StoreInt32(&p.numWaiters, int32(len(p.waiters)))
+}
+
+func (p *Partition) setSnapshotIndex(index Index) {
+	atomic.StoreUint64(&p.snapshot, uint64(index))
+}
+
+func (p *Partition) getSnapshotIndex() Index {
+	return Index(atomic.LoadUint64(&p.snapshot))
+}
+
 type Executor struct {
 	*Partition
-	host    *dragonboat.NodeHost
-	streams *protocolContext
+	host      *dragonboat.NodeHost
+	streams   *protocolContext
+	sessions  *sessionManager
+	timeout   time.Duration
+	apiPort   int
+	forwarder *forwarder
+	forward   bool
 }
 
 // Propose proposes a change to the protocol
-func (e *Executor) Propose(ctx context.Context, input *protocol.ProposalInput, stream streams.WriteStream[*protocol.ProposalOutput]) error {
+func (e *Executor) Propose(ctx context.Context, input *protocol.ProposalInput, stream streams.WriteStream[*protocol.ProposalOutput]) (err error) {
+	ctx, span := tracer.Start(ctx, "Executor.Propose", trace.WithAttributes(partitionAttribute.Int64(int64(e.ID()))))
+	latency := newLatencyStream(e.Partition, stream)
+	defer func() {
+		endSpan(span, err)
+		// Proposals that fail before their stream is used are recorded once they fail
+		if err != nil {
+			latency.observe()
+		}
+	}()
+
 	term, leader := e.getLeader()
 	if leader != e.memberID {
-		return errors.NewUnavailable("not the leader")
+		if leader == 0 {
+			return errors.NewUnavailable("no leader elected for group %d", e.ID())
+		}
+		hint, err := e.getLeaderHint(term, leader)
+		if err != nil {
+			return err
+		}
+		// Proposals are forwarded at most once to avoid loops while leadership is changing
+		if !e.forward || isForwarded(ctx) {
+			return newNotLeaderError(hint)
+		}
+		latency.forwarded = true
+		return e.forwarder.propose(ctx, hint, input, latency)
 	}
 
 	inputBytes, err := proto.Marshal(input)
@@ -71,44 +223,263 @@ func (e *Executor) Propose(ctx context.Context, input *protocol.ProposalInput, s
 		return errors.NewInternal(err.Error())
 	}
 
-	sequenceNum := e.streams.addStream(term, stream)
+	sequenceNum := e.streams.addStream(term, newAppliedIndexStream(ctx, e.Partition, latency))
 	proposal := &RaftProposal{
-		Term:        term,
-		SequenceNum: sequenceNum,
-		Data:        inputBytes,
+		Term:         term,
+		SequenceNum:  sequenceNum,
+		Data:         inputBytes,
+		TraceContext: newTraceContext(ctx),
 	}
+	span.SetAttributes(
+		termAttribute.Int64(int64(term)),
+		sequenceNumAttribute.Int64(int64(sequenceNum)))
 
 	proposalBytes, err := proto.Marshal(proposal)
 	if err != nil {
 		return errors.NewInternal("failed to marshal RaftLogEntry: %v", err)
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
-	defer cancel()
-	if _, err := e.host.SyncPropose(ctx, e.host.GetNoOPSession(uint64(e.ID())), proposalBytes); err != nil {
-		return wrapError(err)
+	session, err := e.sessions.getSession(ctx, input)
+	if err != nil {
+		e.streams.removeStream(term, sequenceNum)
+		return err
+	}
+
+	if err := e.submit(ctx, input, session, proposal, proposalBytes, true); err != nil {
+		e.streams.removeStream(term, sequenceNum)
+		return err
+	}
+	return nil
+}
+
+// submit submits the given proposal to Raft. When proposals are pipelined, submit returns once the proposal has
+// been queued and the result is handled by the partition's proposer. Failures are written to the proposal's stream.
+// If retry is true and the proposal is rejected because its client session is no longer registered, the session
+// is registered again and the proposal is resubmitted once.
+func (e *Executor) submit(ctx context.Context, input *protocol.ProposalInput, session *client.Session, proposal *RaftProposal, proposalBytes []byte, retry bool) error {
+	if e.proposer == nil {
+		ctx, cancel := context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+		ctx, span := tracer.Start(ctx, "NodeHost.SyncPropose")
+		result, err := e.host.SyncPropose(ctx, session, proposalBytes)
+		endSpan(span, err)
+		if err == dragonboat.ErrRejected && retry && !session.IsNoOPSession() {
+			return e.resubmit(ctx, input, session, proposal, proposalBytes)
+		}
+		if err != nil {
+			return wrapError(err)
+		}
+		return e.complete(ctx, input, session, proposal, proposalBytes, result)
+	}
+
+	// The span of a pipelined proposal ends once the proposal is applied or fails
+	_, span := tracer.Start(ctx, "NodeHost.Propose")
+	err := e.proposer.propose(session, proposal, proposalBytes, func(result dbstatemachine.Result, err error) {
+		endSpan(span, err)
+		if err == errProposalRejected && retry && !session.IsNoOPSession() {
+			// Pipelined results are handled by the goroutine awaiting the proposal, which must not block on the proposer
+			go func() {
+				if err := e.resubmit(context.Background(), input, session, proposal, proposalBytes); err != nil {
+					e.fail(proposal, err)
+				}
+			}()
+			return
+		}
+		if err == nil {
+			err = e.complete(context.Background(), input, session, proposal, proposalBytes, result)
+		}
+		if err != nil {
+			e.fail(proposal, err)
+		}
+	})
+	if err != nil {
+		endSpan(span, err)
+	}
+	return err
+}
+
+// resubmit registers the client session of a rejected proposal again and resubmits the proposal
+func (e *Executor) resubmit(ctx context.Context, input *protocol.ProposalInput, session *client.Session, proposal *RaftProposal, proposalBytes []byte) error {
+	e.sessions.reject(protocol.SessionID(session.ClientID))
+	session, err := e.sessions.getSession(ctx, input)
+	if err != nil {
+		return err
+	}
+	return e.submit(ctx, input, session, proposal, proposalBytes, false)
+}
+
+// complete handles the result of an applied proposal
+func (e *Executor) complete(ctx context.Context, input *protocol.ProposalInput, session *client.Session, proposal *RaftProposal, proposalBytes []byte, result dbstatemachine.Result) error {
+	switch i := input.Input.(type) {
+	case *protocol.ProposalInput_Proposal:
+		if !session.IsNoOPSession() {
+			return e.replay(ctx, input, proposal, proposalBytes, result)
+		}
+	case *protocol.ProposalInput_KeepAlive:
+		e.sessions.keepAlive(i.KeepAlive)
+	case *protocol.ProposalInput_CloseSession:
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+			defer cancel()
+			if err := e.sessions.close(ctx, i.CloseSession.SessionID); err != nil {
+				log.Warnw("Failed to close Raft client session",
+					logging.Uint64("SessionID", uint64(i.CloseSession.SessionID)),
+					logging.Error("Error", err))
+			}
+		}()
+	}
+	return nil
+}
+
+// replay returns the outputs of a proposal that was deduplicated by its Raft client session. If the proposal
+// was applied by an earlier attempt, its outputs were written to the stream of that attempt, so the outputs
+// recorded in the result are written to the stream of this attempt instead. If the proposal was still running
+// once applied, it's resubmitted without a client session for the primitive state machine to replay its outputs
+// and attach the stream to the pending proposal.
+func (e *Executor) replay(ctx context.Context, input *protocol.ProposalInput, proposal *RaftProposal, proposalBytes []byte, result dbstatemachine.Result) error {
+	proposalResult := &RaftProposalResult{}
+	if err := proto.Unmarshal(result.Data, proposalResult); err != nil {
+		return errors.NewInternal("failed to unmarshal RaftProposalResult: %v", err)
+	}
+	if proposalResult.Term == proposal.Term && proposalResult.SequenceNum == proposal.SequenceNum {
+		return nil
+	}
+
+	log.Debugw("Replaying deduplicated proposal",
+		logging.Uint64("Term", uint64(proposal.Term)),
+		logging.Uint64("SequenceNum", uint64(proposal.SequenceNum)))
+	if !proposalResult.Complete {
+		session := e.host.GetNoOPSession(uint64(e.ID()))
+		if e.proposer == nil {
+			return e.submit(ctx, input, session, proposal, proposalBytes, false)
+		}
+		// Pipelined results are handled by the goroutine awaiting the proposal, which must not block on the proposer
+		go func() {
+			if err := e.submit(ctx, input, session, proposal, proposalBytes, false); err != nil {
+				e.fail(proposal, err)
+			}
+		}()
+		return nil
+	}
+
+	stream := e.streams.getStream(proposal.Term, proposal.SequenceNum)
+	defer stream.Close()
+	for _, outputBytes := range proposalResult.Outputs {
+		output := &protocol.ProposalOutput{}
+		if err := proto.Unmarshal(outputBytes, output); err != nil {
+			return errors.NewInternal("failed to unmarshal ProposalOutput: %v", err)
+		}
+		stream.Value(output)
 	}
 	return nil
 }
 
+// fail completes the stream of the given proposal with an error
+func (e *Executor) fail(proposal *RaftProposal, err error) {
+	stream := e.streams.getStream(proposal.Term, proposal.SequenceNum)
+	stream.Error(err)
+	stream.Close()
+}
+
+// getLeaderHint returns a hint identifying the API server of the given leader
+func (e *Executor) getLeaderHint(term Term, leader MemberID) (*LeaderHint, error) {
+	for _, info := range e.host.GetNodeHostInfo(dragonboat.NodeHostInfoOption{SkipLogInfo: true}).ClusterInfoList {
+		if info.ClusterID != uint64(e.ID()) {
+			continue
+		}
+		address, ok := info.Nodes[uint64(leader)]
+		if !ok {
+			break
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, errors.NewInternal("invalid address %s for member %d: %v", address, leader, err)
+		}
+		return &LeaderHint{
+			GroupID: GroupID(e.ID()),
+			Term:    term,
+			Leader:  leader,
+			Host:    host,
+			Port:    int32(e.apiPort),
+		}, nil
+	}
+	return nil, errors.NewUnavailable("leader %d of group %d not found", leader, e.ID())
+}
+
+// getLeaderAppliedIndex returns the index applied by the leader of the partition. The leader's applied index
+// is requested after the query is received, so a query that waits for the partition to apply the index, less
+// its maximum lag, observes state no more than that many entries behind the leader's state at the time of
+// the query.
+func (e *Executor) getLeaderAppliedIndex(ctx context.Context) (Index, error) {
+	term, leader := e.getLeader()
+	if leader == e.memberID {
+		return e.getAppliedIndex(), nil
+	}
+	if leader == 0 {
+		return 0, errors.NewUnavailable("no leader elected for group %d", e.ID())
+	}
+	hint, err := e.getLeaderHint(term, leader)
+	if err != nil {
+		return 0, err
+	}
+	return e.forwarder.getAppliedIndex(ctx, hint)
+}
+
 // Query queries the state
 func (e *Executor) Query(ctx context.Context, input *protocol.QueryInput, stream streams.WriteStream[*protocol.QueryOutput]) error {
 	query := &protocolQuery{
 		input:  input,
 		stream: stream,
 	}
+	start := time.Now()
 	md, _ := metadata.FromIncomingContext(ctx)
-	sync := md["Sync"] != nil
+	sync := len(md.Get(SyncReadHeader)) > 0
 	if sync {
 		ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
 		defer cancel()
 		if _, err := e.host.SyncRead(ctx, uint64(e.ID()), query); err != nil {
 			return wrapError(err)
 		}
+		e.metrics.observeQuery(e.Partition, LinearizableRead, time.Since(start))
 	} else {
+		mode := LocalRead
+		if values := md.Get(MinIndexHeader); len(values) > 0 {
+			mode = BoundedRead
+			minIndex, err := strconv.ParseUint(values[0], 10, 64)
+			if err != nil {
+				return errors.NewInvalid("invalid %s header: %v", MinIndexHeader, err)
+			}
+			ctx, cancel := context.WithTimeout(ctx, e.timeout)
+			defer cancel()
+			if err := e.awaitAppliedIndex(ctx, Index(minIndex)); err != nil {
+				return err
+			}
+		}
+		if values := md.Get(MaxLagHeader); len(values) > 0 {
+			mode = BoundedRead
+			maxLag, err := strconv.ParseUint(values[0], 10, 64)
+			if err != nil {
+				return errors.NewInvalid("invalid %s header: %v", MaxLagHeader, err)
+			}
+			ctx, cancel := context.WithTimeout(ctx, e.timeout)
+			defer cancel()
+			leaderIndex, err := e.getLeaderAppliedIndex(ctx)
+			if err != nil {
+				return err
+			}
+			if uint64(leaderIndex) > maxLag {
+				if err := e.awaitAppliedIndex(ctx, leaderIndex-Index(maxLag)); err != nil {
+					return err
+				}
+			}
+		}
+		// Report the index of the state being read to allow clients to bound the staleness of later reads
+		index := e.getAppliedIndex()
+		_ = grpc.SetHeader(ctx, metadata.Pairs(AppliedIndexHeader, strconv.FormatUint(uint64(index), 10)))
 		if _, err := e.host.StaleRead(uint64(e.ID()), query); err != nil {
 			return wrapError(err)
 		}
+		e.metrics.observeQuery(e.Partition, mode, time.Since(start))
 	}
 	return nil
 }
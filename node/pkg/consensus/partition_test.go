@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"google.golang.org/grpc/metadata"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAwaitAppliedIndex(t *testing.T) {
+	partition := newTestMemberPartition(nil, 1, 1)
+	partition.setAppliedIndex(5)
+	if err := partition.awaitAppliedIndex(context.Background(), 5); err != nil {
+		t.Fatalf("expected applied index to be returned immediately, got %v", err)
+	}
+
+	errCh := make(chan error, 2)
+	for _, index := range []Index{7, 10} {
+		go func(index Index) {
+			errCh <- partition.awaitAppliedIndex(context.Background(), index)
+		}(index)
+	}
+	deadline := time.Now().Add(time.Minute)
+	for getNumTestWaiters(partition) != 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected queries to wait for the partition to apply the indexes")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	partition.setAppliedIndex(8)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+	if numWaiters := getNumTestWaiters(partition); numWaiters != 1 {
+		t.Fatalf("expected 1 waiting query, got %d", numWaiters)
+	}
+	partition.setAppliedIndex(10)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := partition.awaitAppliedIndex(ctx, 11); !errors.IsTimeout(err) {
+		t.Fatalf("expected Timeout error, got %v", err)
+	}
+	if numWaiters := getNumTestWaiters(partition); numWaiters != 0 {
+		t.Errorf("expected timed out query to stop waiting, got %d waiting queries", numWaiters)
+	}
+}
+
+func getNumTestWaiters(partition *Partition) int {
+	partition.waitersMu.Lock()
+	defer partition.waitersMu.Unlock()
+	return len(partition.waiters)
+}
+
+func TestBoundedReads(t *testing.T) {
+	cluster := newTestCluster(t, 3)
+	leaderNode := cluster.bootstrap(t, 1)
+	leader := cluster.partition(t, leaderNode, 1)
+	follower := cluster.partition(t, (leaderNode+1)%len(cluster.nodes), 1)
+	sessionID := openTestSession(t, leader)
+	counterID := createTestCounter(t, leader, sessionID, 1)
+
+	tests := []struct {
+		name   string
+		header func(index Index) metadata.MD
+	}{
+		{
+			name: "min index",
+			header: func(index Index) metadata.MD {
+				return metadata.Pairs(MinIndexHeader, strconv.FormatUint(uint64(index), 10))
+			},
+		},
+		{
+			name: "max lag",
+			header: func(index Index) metadata.MD {
+				return metadata.Pairs(MaxLagHeader, "0")
+			},
+		},
+	}
+	sequenceNum := 2
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Each read observes the increment applied by the leader, however far behind the follower is
+			for i := 0; i < 10; i++ {
+				value := incrementTestCounter(t, context.Background(), leader, newTestIncrementInput(t, sessionID, protocol.SequenceNum(sequenceNum), counterID))
+				sequenceNum++
+				ctx := metadata.NewIncomingContext(context.Background(), test.header(leader.getAppliedIndex()))
+				read, err := getTestCounter(ctx, follower, sessionID, counterID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if read != value {
+					t.Fatalf("expected follower to read counter value %d, got %d", value, read)
+				}
+			}
+		})
+	}
+
+	for _, header := range []string{MinIndexHeader, MaxLagHeader} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(header, "invalid"))
+		if _, err := getTestCounter(ctx, follower, sessionID, counterID); !errors.IsInvalid(err) {
+			t.Errorf("expected Invalid error for invalid %s header, got %v", header, err)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/gogo/protobuf/proto"
+	"github.com/lni/dragonboat/v3"
+	"github.com/lni/dragonboat/v3/client"
+	dbstatemachine "github.com/lni/dragonboat/v3/statemachine"
+	"sync"
+	"time"
+)
+
+const (
+	// maxInflightProposals is the maximum number of entries proposed by a partition awaiting completion
+	maxInflightProposals = 1024
+	// maxBatchSize and maxBatchBytes bound the number and size of the proposals batched into a single entry
+	maxBatchSize  = 256
+	maxBatchBytes = 1024 * 1024
+)
+
+func newProposer(host *dragonboat.NodeHost, groupID GroupID, timeout time.Duration) *proposer {
+	proposer := &proposer{
+		host:     host,
+		groupID:  groupID,
+		timeout:  timeout,
+		batchCh:  make(chan *pendingProposal, maxBatchSize),
+		inflight: make(chan struct{}, maxInflightProposals),
+		closeCh:  make(chan struct{}),
+	}
+	go proposer.batch()
+	return proposer
+}
+
+// proposer pipelines the proposals of a partition. Proposals are submitted with dragonboat's asynchronous
+// Propose API without waiting for earlier proposals to complete, and each proposal is completed as soon as
+// its own result is available, so a slow proposal never delays the proposals submitted after it.
+//
+// Proposals made without a client session are batched: proposals queued while the previous entry was being
+// submitted are submitted together as a single entry. Proposals made with a client session are deduplicated
+// by dragonboat per entry, so they're always submitted as entries of their own.
+type proposer struct {
+	host      *dragonboat.NodeHost
+	groupID   GroupID
+	timeout   time.Duration
+	batchCh   chan *pendingProposal
+	inflight  chan struct{}
+	closeCh   chan struct{}
+	closeOnce sync.Once
+}
+
+// pendingProposal is a proposal awaiting submission to be batched
+type pendingProposal struct {
+	proposal      *RaftProposal
+	proposalBytes []byte
+	callback      func(dbstatemachine.Result, error)
+}
+
+// propose submits the given proposal, calling the callback once the proposal has been applied or has failed.
+// The callback is called from the goroutine awaiting the proposal's result and must not block.
+func (p *proposer) propose(session *client.Session, proposal *RaftProposal, proposalBytes []byte, callback func(dbstatemachine.Result, error)) error {
+	if !session.IsNoOPSession() {
+		return p.submit(session, proposalBytes, callback)
+	}
+	select {
+	case p.batchCh <- &pendingProposal{proposal: proposal, proposalBytes: proposalBytes, callback: callback}:
+		return nil
+	case <-p.closeCh:
+		return errors.NewUnavailable("partition closed")
+	}
+}
+
+// submit proposes the given entry, waiting for capacity if maxInflightProposals entries are awaiting completion
+func (p *proposer) submit(session *client.Session, cmd []byte, callback func(dbstatemachine.Result, error)) error {
+	select {
+	case p.inflight <- struct{}{}:
+	case <-p.closeCh:
+		return errors.NewUnavailable("partition closed")
+	}
+	state, err := p.host.Propose(session, cmd, p.timeout)
+	if err != nil {
+		<-p.inflight
+		return wrapError(err)
+	}
+	go p.await(state, callback)
+	return nil
+}
+
+// await waits for the result of a proposed entry. Dragonboat completes every request by its timeout.
+func (p *proposer) await(state *dragonboat.RequestState, callback func(dbstatemachine.Result, error)) {
+	result := <-state.ResultC()
+	state.Release()
+	<-p.inflight
+	if result.Completed() {
+		callback(result.GetResult(), nil)
+	} else {
+		callback(dbstatemachine.Result{}, getRequestError(result))
+	}
+}
+
+// batch submits the proposals made without a client session, batching the proposals queued while the
+// previous entry was being submitted
+func (p *proposer) batch() {
+	for {
+		var batch []*pendingProposal
+		select {
+		case pending := <-p.batchCh:
+			batch = append(batch, pending)
+		case <-p.closeCh:
+			return
+		}
+
+		size := len(batch[0].proposalBytes)
+	drain:
+		for len(batch) < maxBatchSize && size < maxBatchBytes {
+			select {
+			case pending := <-p.batchCh:
+				batch = append(batch, pending)
+				size += len(pending.proposalBytes)
+			default:
+				break drain
+			}
+		}
+		p.submitBatch(batch)
+	}
+}
+
+// submitBatch submits a batch of proposals as a single entry, completing every proposal in the batch with the
+// entry's result
+func (p *proposer) submitBatch(batch []*pendingProposal) {
+	cmd := batch[0].proposalBytes
+	if len(batch) > 1 {
+		proposal := &RaftProposal{
+			Batch: make([]*RaftProposal, len(batch)),
+		}
+		for i, pending := range batch {
+			proposal.Batch[i] = pending.proposal
+		}
+		bytes, err := proto.Marshal(proposal)
+		if err != nil {
+			err = errors.NewInternal("failed to marshal RaftProposal: %v", err)
+			for _, pending := range batch {
+				pending.callback(dbstatemachine.Result{}, err)
+			}
+			return
+		}
+		cmd = bytes
+	}
+
+	session := p.host.GetNoOPSession(uint64(p.groupID))
+	err := p.submit(session, cmd, func(result dbstatemachine.Result, err error) {
+		for _, pending := range batch {
+			pending.callback(result, err)
+		}
+	})
+	if err != nil {
+		for _, pending := range batch {
+			pending.callback(dbstatemachine.Result{}, err)
+		}
+	}
+}
+
+func (p *proposer) close() {
+	p.closeOnce.Do(func() {
+		close(p.closeCh)
+	})
+}
+
+// errProposalRejected is the error for a proposal rejected by the Raft layer, e.g. because its client
+// session is not registered
+var errProposalRejected = errors.NewForbidden("proposal rejected")
+
+// getRequestError returns the error for a request that failed to complete
+func getRequestError(result dragonboat.RequestResult) error {
+	switch {
+	case result.Timeout():
+		return errors.NewTimeout("proposal timed out")
+	case result.Rejected():
+		return errProposalRejected
+	case result.Terminated():
+		return errors.NewUnavailable("partition closed")
+	case result.Dropped():
+		return errors.NewUnavailable("proposal dropped")
+	case result.Aborted():
+		return errors.NewCanceled("proposal aborted")
+	default:
+		return errors.NewUnknown("proposal failed")
+	}
+}
@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"github.com/atomix/runtime/sdk/pkg/protocol/node"
+	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testHost = "127.0.0.1"
+
+// newTestPartition starts a single member group on a new node, returning the group's partition once the
+// member has been elected leader
+func newTestPartition(tb testing.TB, syncProposals bool) node.Partition {
+	listener, err := net.Listen("tcp", net.JoinHostPort(testHost, "0"))
+	if err != nil {
+		tb.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	dataDir := tb.TempDir()
+	config := RaftConfig{
+		DataDir: &dataDir,
+	}
+	p := NewProtocol(config, statemachine.NewPrimitiveTypeRegistry(),
+		WithHost(testHost),
+		WithPort(port),
+		WithSyncProposals(syncProposals))
+	tb.Cleanup(func() {
+		p.Shutdown()
+	})
+
+	err = p.Bootstrap(GroupConfig{
+		GroupID:  1,
+		MemberID: 1,
+		Members: []MemberConfig{
+			{
+				MemberID: 1,
+				Host:     testHost,
+				Port:     int32(port),
+			},
+		},
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		p.mu.RLock()
+		partition, ok := p.partitions[1]
+		p.mu.RUnlock()
+		if ok {
+			if _, leader := partition.getLeader(); leader == 1 {
+				return partition
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	tb.Fatal("leader not elected")
+	return nil
+}
+
+func newOpenSessionInput() *protocol.ProposalInput {
+	return &protocol.ProposalInput{
+		Timestamp: time.Now(),
+		Input: &protocol.ProposalInput_OpenSession{
+			OpenSession: &protocol.OpenSessionInput{
+				Timeout: time.Minute,
+			},
+		},
+	}
+}
+
+func TestPipelinedProposals(t *testing.T) {
+	partition := newTestPartition(t, false)
+
+	// Concurrent proposals are queued while earlier entries are submitted, so most are batched. Each proposal
+	// must still receive its own output.
+	const proposals = 1000
+	wg := &sync.WaitGroup{}
+	outputCh := make(chan *protocol.ProposalOutput, proposals)
+	for i := 0; i < proposals; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			output, err := partition.Propose(ctx, newOpenSessionInput())
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			outputCh <- output
+		}()
+	}
+	wg.Wait()
+	close(outputCh)
+
+	sessions := make(map[protocol.SessionID]bool)
+	for output := range outputCh {
+		openSession := output.GetOpenSession()
+		if openSession == nil {
+			t.Fatalf("expected OpenSession output, got %v", output)
+		}
+		if sessions[openSession.SessionID] {
+			t.Fatalf("session %d opened by more than one proposal", openSession.SessionID)
+		}
+		sessions[openSession.SessionID] = true
+	}
+	if len(sessions) != proposals {
+		t.Errorf("expected %d sessions, got %d", proposals, len(sessions))
+	}
+}
+
+// BenchmarkPropose measures the throughput and latency of concurrent proposals to a single member group when
+// each proposal is submitted with SyncPropose and when proposals are pipelined and batched by the proposer.
+func BenchmarkPropose(b *testing.B) {
+	b.Run("Sync", func(b *testing.B) {
+		benchmarkPropose(b, true)
+	})
+	b.Run("Pipelined", func(b *testing.B) {
+		benchmarkPropose(b, false)
+	})
+}
+
+func benchmarkPropose(b *testing.B, syncProposals bool) {
+	partition := newTestPartition(b, syncProposals)
+	latencies := make([]time.Duration, b.N)
+	var n int64
+
+	b.SetParallelism(64)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			start := time.Now()
+			if _, err := partition.Propose(context.Background(), newOpenSessionInput()); err != nil {
+				b.Error(err)
+				return
+			}
+			latencies[atomic.AddInt64(&n, 1)-1] = time.Since(start)
+		}
+	})
+	b.StopTimer()
+
+	latencies = latencies[:n]
+	if len(latencies) == 0 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	b.ReportMetric(float64(latencies[len(latencies)/2].Nanoseconds()), "p50-ns/op")
+	b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns/op")
+}
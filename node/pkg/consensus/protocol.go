@@ -15,7 +15,13 @@ import (
 	"github.com/lni/dragonboat/v3"
 	raftconfig "github.com/lni/dragonboat/v3/config"
 	dbstatemachine "github.com/lni/dragonboat/v3/statemachine"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 )
 
 var log = logging.GetLogger()
@@ -36,9 +42,12 @@ func NewProtocol(config RaftConfig, registry *statemachine.PrimitiveTypeRegistry
 
 	protocol := &Protocol{
 		config:     config,
+		options:    options,
 		registry:   registry,
 		partitions: make(map[protocol.PartitionID]*Partition),
-		watchers:   make(map[int]chan<- Event),
+		events:     newEventBuffer(eventBufferSize),
+		watchers:   make(map[int]*Watcher),
+		forwarder:  newForwarder(options.Certificates),
 	}
 
 	listener := newEventListener(protocol)
@@ -51,6 +60,24 @@ func NewProtocol(config RaftConfig, registry *statemachine.PrimitiveTypeRegistry
 		RaftEventListener:   listener,
 		SystemEventListener: listener,
 	}
+	// Dragonboat loads the Raft transport's certificates once when the node host is created and cannot reload
+	// them, so the controller restarts the node's pod when the certificate is rotated
+	if options.Certificates != nil {
+		nodeConfig.MutualTLS = true
+		nodeConfig.CAFile = options.Certificates.config.CAFile
+		nodeConfig.CertFile = options.Certificates.config.CertFile
+		nodeConfig.KeyFile = options.Certificates.config.KeyFile
+	}
+	if options.Metrics != nil {
+		nodeConfig.EnableMetrics = true
+	}
+	if config.DeploymentID != nil {
+		deploymentID, err := getDeploymentID(config.GetDataDir(), *config.DeploymentID)
+		if err != nil {
+			panic(err)
+		}
+		nodeConfig.DeploymentID = deploymentID
+	}
 
 	host, err := dragonboat.NewNodeHost(nodeConfig)
 	if err != nil {
@@ -58,36 +85,82 @@ func NewProtocol(config RaftConfig, registry *statemachine.PrimitiveTypeRegistry
 	}
 
 	protocol.host = host
+	protocol.eventsMu.Lock()
+	protocol.hostID = host.ID()
+	protocol.eventsMu.Unlock()
+	options.Metrics.register(protocol)
 	return protocol
 }
 
 type Protocol struct {
 	host       *dragonboat.NodeHost
 	config     RaftConfig
+	options    Options
+	forwarder  *forwarder
 	registry   *statemachine.PrimitiveTypeRegistry
 	partitions map[protocol.PartitionID]*Partition
-	watchers   map[int]chan<- Event
-	watcherID  int
 	mu         sync.RWMutex
+	// startMu serializes starting members with staging imported snapshots
+	startMu sync.Mutex
+	// hostID, events and watchers are guarded by eventsMu, which orders the events queued for each watcher
+	hostID    string
+	events    *eventBuffer
+	watchers  map[int]*Watcher
+	watcherID int
+	eventsMu  sync.Mutex
 }
 
 func (n *Protocol) publish(event *Event) {
 	n.mu.RLock()
-	defer n.mu.RUnlock()
 	switch e := event.Event.(type) {
 	case *Event_MemberReady:
 		if partition, ok := n.partitions[protocol.PartitionID(e.MemberReady.GroupID)]; ok {
 			partition.setReady()
 		}
+	case *Event_MemberUnloaded:
+		if partition, ok := n.partitions[protocol.PartitionID(e.MemberUnloaded.GroupID)]; ok {
+			partition.setNotReady()
+		}
+	case *Event_HostShuttingDown:
+		for _, partition := range n.partitions {
+			partition.setNotReady()
+		}
 	case *Event_LeaderUpdated:
 		if partition, ok := n.partitions[protocol.PartitionID(e.LeaderUpdated.GroupID)]; ok {
 			partition.setLeader(e.LeaderUpdated.Term, e.LeaderUpdated.Leader)
 		}
+	case *Event_SnapshotCreated:
+		if partition, ok := n.partitions[protocol.PartitionID(e.SnapshotCreated.GroupID)]; ok {
+			partition.setSnapshotIndex(e.SnapshotCreated.Index)
+		}
+	case *Event_SnapshotRecovered:
+		if partition, ok := n.partitions[protocol.PartitionID(e.SnapshotRecovered.GroupID)]; ok {
+			partition.setSnapshotIndex(e.SnapshotRecovered.Index)
+			if e.SnapshotRecovered.Index > partition.getAppliedIndex() {
+				partition.setAppliedIndex(e.SnapshotRecovered.Index)
+			}
+		}
 	}
+	n.mu.RUnlock()
+
+	n.eventsMu.Lock()
+	defer n.eventsMu.Unlock()
+	event.HostID = n.hostID
+	n.events.append(event)
 	log.Infow("Publish Event",
 		logging.Stringer("Event", event))
-	for _, listener := range n.watchers {
-		listener <- *event
+	for id, watcher := range n.watchers {
+		dropped, ok := watcher.send(event)
+		if dropped {
+			n.options.Metrics.recordDroppedEvent(watcher.policy)
+		}
+		if !ok {
+			log.Warnw("Disconnecting watcher that fell behind",
+				logging.Int("WatcherID", id),
+				logging.Uint64("Sequence", watcher.sequence))
+			watcher.close()
+			delete(n.watchers, id)
+		}
 	}
 }
 
@@ -108,15 +181,68 @@ func (n *Protocol) Partitions() []node.Partition {
 	return partitions
 }
 
-func (n *Protocol) Watch(ctx context.Context, watcher chan<- Event) {
+// Watch queues the node's events matching the given request for a watcher until the context is canceled.
+// If the request's sequence number identifies an event still buffered by the node, the buffered events
+// following it are queued first. Otherwise, the current state of each group is queued first, preceded by
+// a full state Resync event if the watch could not be resumed from the requested sequence number.
+// The watcher's queue is closed when the context is canceled or the watcher is disconnected for falling behind.
+func (n *Protocol) Watch(ctx context.Context, request *WatchRequest) *Watcher {
+	filter := newEventFilter(request)
+	n.eventsMu.Lock()
+	defer n.eventsMu.Unlock()
+	var matches []Event
+	events, ok := n.events.since(request.FromSequence)
+	if request.FromSequence == 0 || !ok {
+		if request.FromSequence != 0 {
+			// The Resync event notifies the watcher that it missed events and is sent regardless of the filter
+			matches = append(matches, Event{
+				Timestamp: time.Now(),
+				Sequence:  n.events.sequence,
+				HostID:    n.hostID,
+				Event: &Event_Resync{
+					Resync: &ResyncEvent{
+						FullState: true,
+					},
+				},
+			})
+		}
+		events = n.getStateEvents()
+	}
+	for _, event := range events {
+		if filter.matches(&event) {
+			matches = append(matches, event)
+		}
+	}
+	watcher := newWatcher(filter, n.options.WatchQueueSize, n.options.WatchOverflowPolicy, matches)
 	n.watcherID++
 	id := n.watcherID
-	n.mu.Lock()
 	n.watchers[id] = watcher
+
+	go func() {
+		<-ctx.Done()
+		n.eventsMu.Lock()
+		defer n.eventsMu.Unlock()
+		if _, ok := n.watchers[id]; ok {
+			watcher.close()
+			delete(n.watchers, id)
+		}
+	}()
+	return watcher
+}
+
+// getStateEvents returns events describing the current state of each group. The events carry the sequence
+// number of the last event published, from which a watcher receiving them can later resume.
+func (n *Protocol) getStateEvents() []Event {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	var events []Event
 	for _, partition := range n.partitions {
 		term, leader := partition.getLeader()
 		if term > 0 {
-			watcher <- Event{
+			events = append(events, Event{
+				Timestamp: time.Now(),
+				Sequence:  n.events.sequence,
+				HostID:    n.hostID,
 				Event: &Event_LeaderUpdated{
 					LeaderUpdated: &LeaderUpdatedEvent{
 						MemberEvent: MemberEvent{
@@ -127,11 +253,14 @@ func (n *Protocol) Watch(ctx context.Context, watcher chan<- Event) {
 						Leader: leader,
 					},
 				},
-			}
+			})
 		}
 		ready := partition.getReady()
 		if ready {
-			watcher <- Event{
+			events = append(events, Event{
+				Timestamp: time.Now(),
+				Sequence:  n.events.sequence,
+				HostID:    n.hostID,
 				Event: &Event_MemberReady{
 					MemberReady: &MemberReadyEvent{
 						MemberEvent: MemberEvent{
@@ -140,42 +269,52 @@ func (n *Protocol) Watch(ctx context.Context, watcher chan<- Event) {
 						},
 					},
 				},
-			}
+			})
 		}
 	}
-	n.mu.Unlock()
-
-	go func() {
-		<-ctx.Done()
-		n.mu.Lock()
-		close(watcher)
-		delete(n.watchers, id)
-		n.mu.Unlock()
-	}()
+	return events
 }
 
 func (n *Protocol) Bootstrap(config GroupConfig) error {
+	n.startMu.Lock()
+	defer n.startMu.Unlock()
 	raftConfig := n.getRaftConfig(config)
 	members := make(map[uint64]dragonboat.Target)
-	for _, member := range config.Members {
-		members[uint64(member.MemberID)] = fmt.Sprintf("%s:%d", member.Host, member.Port)
+	// If the member has already been started on this node, restart it from its persisted state
+	if !n.host.HasNodeInfo(uint64(config.GroupID), uint64(config.MemberID)) {
+		for _, member := range config.Members {
+			members[uint64(member.MemberID)] = fmt.Sprintf("%s:%d", member.Host, member.Port)
+		}
 	}
-	if err := n.host.StartCluster(members, false, n.newStateMachine, raftConfig); err != nil {
+	if err := n.host.StartConcurrentCluster(members, false, n.newStateMachine, raftConfig); err != nil {
 		if err == dragonboat.ErrClusterAlreadyExist {
 			return nil
 		}
 		return wrapError(err)
 	}
+	if seed := getSeedPath(n.config.GetDataDir(), config.GroupID, config.MemberID); seed != "" {
+		go n.compactSeed(config.GroupID, config.MemberID, seed)
+	}
 	return nil
 }
 
 func (n *Protocol) Join(config GroupConfig) error {
+	n.startMu.Lock()
+	defer n.startMu.Unlock()
 	raftConfig := n.getRaftConfig(config)
-	members := make(map[uint64]dragonboat.Target)
-	for _, member := range config.Members {
-		members[uint64(member.MemberID)] = fmt.Sprintf("%s:%d", member.Host, member.Port)
+	// Joining members learn the group membership from the leader, so the initial members must be empty.
+	// If the member has already been started on this node, restart it from its persisted state instead.
+	join := !n.host.HasNodeInfo(uint64(config.GroupID), uint64(config.MemberID))
+	// Joining members learn the group's state from the leader, so they cannot be seeded
+	if seed := getSeedPath(n.config.GetDataDir(), config.GroupID, config.MemberID); join && seed != "" {
+		log.Warnw("Removing imported snapshot staged for a joining member",
+			logging.Uint32("GroupID", uint32(config.GroupID)),
+			logging.Uint32("MemberID", uint32(config.MemberID)))
+		if err := os.Remove(seed); err != nil {
+			return errors.NewInternal("failed to remove imported snapshot: %v", err)
+		}
 	}
-	if err := n.host.StartCluster(members, true, n.newStateMachine, raftConfig); err != nil {
+	if err := n.host.StartConcurrentCluster(map[uint64]dragonboat.Target{}, join, n.newStateMachine, raftConfig); err != nil {
 		if err == dragonboat.ErrClusterAlreadyExist {
 			return nil
 		}
@@ -188,22 +327,472 @@ func (n *Protocol) Leave(groupID GroupID) error {
 	return n.host.StopCluster(uint64(groupID))
 }
 
-func (n *Protocol) newStateMachine(clusterID, nodeID uint64) dbstatemachine.IStateMachine {
+func (n *Protocol) AddMember(ctx context.Context, groupID GroupID, member MemberConfig, role MemberRole) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
+	defer cancel()
+	address := fmt.Sprintf("%s:%d", member.Host, member.Port)
+	membership, err := n.host.SyncGetClusterMembership(ctx, uint64(groupID))
+	if err != nil {
+		return wrapError(err)
+	}
+	if _, ok := membership.Removed[uint64(member.MemberID)]; ok {
+		return errors.NewConflict("member %d has been removed from group %d", member.MemberID, groupID)
+	}
+	// Adding a member that is already present with the same role and address is a no-op
+	var members map[uint64]string
+	switch role {
+	case MemberRole_MEMBER:
+		members = membership.Nodes
+	case MemberRole_OBSERVER:
+		members = membership.Observers
+	case MemberRole_WITNESS:
+		members = membership.Witnesses
+	}
+	if target, ok := members[uint64(member.MemberID)]; ok && target == address {
+		return nil
+	}
+	switch role {
+	case MemberRole_MEMBER:
+		if err := n.host.SyncRequestAddNode(ctx, uint64(groupID), uint64(member.MemberID), address, 0); err != nil {
+			return wrapError(err)
+		}
+	case MemberRole_OBSERVER:
+		if err := n.host.SyncRequestAddObserver(ctx, uint64(groupID), uint64(member.MemberID), address, 0); err != nil {
+			return wrapError(err)
+		}
+	case MemberRole_WITNESS:
+		if err := n.host.SyncRequestAddWitness(ctx, uint64(groupID), uint64(member.MemberID), address, 0); err != nil {
+			return wrapError(err)
+		}
+	default:
+		return errors.NewInvalid("unknown member role %s", role)
+	}
+	return nil
+}
+
+func (n *Protocol) RemoveMember(ctx context.Context, groupID GroupID, memberID MemberID) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
+	defer cancel()
+	membership, err := n.host.SyncGetClusterMembership(ctx, uint64(groupID))
+	if err != nil {
+		return wrapError(err)
+	}
+	if _, ok := membership.Removed[uint64(memberID)]; ok {
+		return nil
+	}
+	if err := n.host.SyncRequestDeleteNode(ctx, uint64(groupID), uint64(memberID), 0); err != nil {
+		return wrapError(err)
+	}
+	return nil
+}
+
+func (n *Protocol) PromoteMember(ctx context.Context, groupID GroupID, memberID MemberID) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
+	defer cancel()
+	membership, err := n.host.SyncGetClusterMembership(ctx, uint64(groupID))
+	if err != nil {
+		return wrapError(err)
+	}
+	address, ok := membership.Observers[uint64(memberID)]
+	if !ok {
+		return errors.NewNotFound("observer %d not found in group %d", memberID, groupID)
+	}
+	if err := n.host.SyncRequestAddNode(ctx, uint64(groupID), uint64(memberID), address, 0); err != nil {
+		return wrapError(err)
+	}
+	return nil
+}
+
+func (n *Protocol) TransferLeadership(ctx context.Context, groupID GroupID, memberID MemberID) error {
+	if err := n.host.RequestLeaderTransfer(uint64(groupID), uint64(memberID)); err != nil {
+		return wrapError(err)
+	}
+
+	// Leadership transfers are not guaranteed to complete, so wait for the target member
+	// to be elected until the election timeout expires.
+	ctx, cancel := context.WithTimeout(ctx, n.config.GetElectionTimeout())
+	defer cancel()
+	ticker := time.NewTicker(n.config.GetHeartbeatPeriod())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			leaderID, ok, err := n.host.GetLeaderID(uint64(groupID))
+			if err != nil {
+				return wrapError(err)
+			}
+			if ok && leaderID == uint64(memberID) {
+				return nil
+			}
+		case <-ctx.Done():
+			return errors.NewTimeout("failed to transfer leadership of group %d to member %d", groupID, memberID)
+		}
+	}
+}
+
+// Resign transfers leadership of all the partitions led by this node to healthy peers
+func (n *Protocol) Resign(ctx context.Context) error {
+	n.mu.RLock()
+	partitions := make([]*Partition, 0, len(n.partitions))
+	for _, partition := range n.partitions {
+		if _, leader := partition.getLeader(); leader == partition.memberID {
+			partitions = append(partitions, partition)
+		}
+	}
+	n.mu.RUnlock()
+
+	wg := &sync.WaitGroup{}
+	errCh := make(chan error, len(partitions))
+	for _, partition := range partitions {
+		wg.Add(1)
+		go func(partition *Partition) {
+			defer wg.Done()
+			if err := n.resign(ctx, partition); err != nil {
+				errCh <- err
+			}
+		}(partition)
+	}
+	wg.Wait()
+	close(errCh)
+	return <-errCh
+}
+
+func (n *Protocol) resign(ctx context.Context, partition *Partition) error {
+	groupID := GroupID(partition.ID())
+	membershipCtx, cancel := context.WithTimeout(ctx, n.config.GetElectionTimeout())
+	membership, err := n.host.SyncGetClusterMembership(membershipCtx, uint64(groupID))
+	cancel()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	memberIDs := make([]uint64, 0, len(membership.Nodes))
+	for memberID := range membership.Nodes {
+		if memberID != uint64(partition.memberID) {
+			memberIDs = append(memberIDs, memberID)
+		}
+	}
+	sort.Slice(memberIDs, func(i, j int) bool {
+		return memberIDs[i] < memberIDs[j]
+	})
+
+	// Try each voting member in turn until one of them is able to take over leadership
+	for _, memberID := range memberIDs {
+		log.Infow("Transferring leadership",
+			logging.Uint32("GroupID", uint32(groupID)),
+			logging.Uint64("MemberID", memberID))
+		if err := n.TransferLeadership(ctx, groupID, MemberID(memberID)); err != nil {
+			log.Warnw("Failed to transfer leadership",
+				logging.Uint32("GroupID", uint32(groupID)),
+				logging.Uint64("MemberID", memberID),
+				logging.Error("Error", err))
+			if ctx.Err() != nil {
+				return err
+			}
+			continue
+		}
+		return nil
+	}
+	return errors.NewUnavailable("no healthy peers found for group %d", groupID)
+}
+
+func (n *Protocol) GetGroupStatus(ctx context.Context, groupID GroupID) (*GroupStatus, error) {
+	n.mu.RLock()
+	partition, ok := n.partitions[protocol.PartitionID(groupID)]
+	n.mu.RUnlock()
+	if !ok {
+		return nil, errors.NewNotFound("group %d not found", groupID)
+	}
+	for _, info := range n.host.GetNodeHostInfo(dragonboat.NodeHostInfoOption{SkipLogInfo: true}).ClusterInfoList {
+		if info.ClusterID == uint64(groupID) {
+			return newGroupStatus(partition, info, n.getMembership(ctx, groupID))
+		}
+	}
+	return nil, errors.NewNotFound("group %d not found", groupID)
+}
+
+func (n *Protocol) ListGroups(ctx context.Context) ([]GroupStatus, error) {
+	var groups []GroupStatus
+	for _, info := range n.host.GetNodeHostInfo(dragonboat.NodeHostInfoOption{SkipLogInfo: true}).ClusterInfoList {
+		n.mu.RLock()
+		partition, ok := n.partitions[protocol.PartitionID(info.ClusterID)]
+		n.mu.RUnlock()
+		if !ok {
+			continue
+		}
+		status, err := newGroupStatus(partition, info, n.getMembership(ctx, GroupID(info.ClusterID)))
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, *status)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].GroupID < groups[j].GroupID
+	})
+	return groups, nil
+}
+
+func (n *Protocol) GetNodeInfo() *NodeInfo {
+	info := &NodeInfo{
+		HostID: n.host.ID(),
+	}
+	for _, logInfo := range n.host.GetNodeHostInfo(dragonboat.DefaultNodeHostInfoOption).LogInfo {
+		info.Logs = append(info.Logs, MemberLogInfo{
+			GroupID:  GroupID(logInfo.ClusterID),
+			MemberID: MemberID(logInfo.NodeID),
+		})
+	}
+	return info
+}
+
+// Snapshot requests a snapshot of the given group, returning the index of the snapshot
+func (n *Protocol) Snapshot(ctx context.Context, groupID GroupID) (Index, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
+	defer cancel()
+	index, err := n.host.SyncRequestSnapshot(ctx, uint64(groupID), dragonboat.DefaultSnapshotOption)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+	return Index(index), nil
+}
+
+// ExportedSnapshot is a snapshot of a group exported to a temporary file, which is removed when closed
+type ExportedSnapshot struct {
+	io.ReadCloser
+	// Index is the index at which the snapshot was taken
+	Index Index
+	// Size is the size of the snapshot in bytes
+	Size int64
+}
+
+// ExportSnapshot exports a snapshot of the given group. The snapshot is written to a temporary file in the
+// data directory so it can be streamed to the client without holding the group's state in memory. If a cut
+// time is provided, the snapshot reflects the entries proposed at or before the time.
+func (n *Protocol) ExportSnapshot(ctx context.Context, groupID GroupID, cut *time.Time) (*ExportedSnapshot, error) {
+	file, err := newExportFile(n.config.GetDataDir())
+	if err != nil {
+		return nil, errors.NewInternal("failed to create snapshot file: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
+	defer cancel()
+	result, err := n.host.SyncRead(ctx, uint64(groupID), &snapshotQuery{
+		writer: file,
+		time:   cut,
+	})
+	if err != nil {
+		file.Close()
+		if err == dbstatemachine.ErrSnapshotAborted {
+			return nil, errors.NewUnavailable("snapshot of group %d was aborted by a concurrent snapshot", groupID)
+		}
+		if err == errSnapshotUnavailable {
+			return nil, errors.NewUnavailable("snapshot of group %d at %s is no longer available", groupID, cut)
+		}
+		return nil, wrapError(err)
+	}
+	if err := file.rewind(); err != nil {
+		file.Close()
+		return nil, errors.NewInternal("failed to write snapshot file: %v", err)
+	}
+	return &ExportedSnapshot{
+		ReadCloser: file,
+		Index:      result.(Index),
+		Size:       file.Size(),
+	}, nil
+}
+
+// ImportSnapshot stages the snapshot read from the given reader to seed the given member of a group when the
+// member is bootstrapped. Members can only be seeded before they're first started on this node, so the state
+// of a group that has applied entries can never be replaced.
+func (n *Protocol) ImportSnapshot(ctx context.Context, groupID GroupID, memberID MemberID, reader io.Reader) error {
+	if groupID == 0 || memberID == 0 {
+		return errors.NewInvalid("group and member IDs are required")
+	}
+	if n.host.HasNodeInfo(uint64(groupID), uint64(memberID)) {
+		return errors.NewConflict("member %d of group %d has already been started", memberID, groupID)
+	}
+
+	path, err := stageSnapshot(n.config.GetDataDir(), reader, n.registry)
+	if err != nil {
+		return errors.NewInvalid("failed to stage snapshot: %v", err)
+	}
+
+	// Members are started while holding startMu, so the member cannot be started between checking the member
+	// has not been started and staging the snapshot
+	n.startMu.Lock()
+	defer n.startMu.Unlock()
+	if n.host.HasNodeInfo(uint64(groupID), uint64(memberID)) {
+		os.Remove(path)
+		return errors.NewConflict("member %d of group %d has already been started", memberID, groupID)
+	}
+	if err := os.Rename(path, getImportPath(n.config.GetDataDir(), groupID, memberID)); err != nil {
+		os.Remove(path)
+		return errors.NewInternal("failed to stage snapshot: %v", err)
+	}
+	log.Infow("Staged imported snapshot",
+		logging.Uint32("GroupID", uint32(groupID)),
+		logging.Uint32("MemberID", uint32(memberID)))
+	return nil
+}
+
+// compactSeed snapshots a member seeded from an imported snapshot, compacting the member's entire log, and
+// then removes the seed. Once the log is compacted, members that join the group recover the imported state
+// from a snapshot rather than replaying the log onto an empty state, and the member itself recovers from its
+// own snapshot when restarted. Until then, the seed is kept to seed the member again if it's restarted.
+func (n *Protocol) compactSeed(groupID GroupID, memberID MemberID, path string) {
+	option := dragonboat.SnapshotOption{
+		OverrideCompactionOverhead: true,
+		CompactionOverhead:         0,
+	}
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultClientTimeout)
+		index, err := n.host.SyncRequestSnapshot(ctx, uint64(groupID), option)
+		cancel()
+		if err == nil {
+			if err := os.Remove(path); err != nil {
+				log.Warnw("Failed to remove imported snapshot",
+					logging.String("Path", path),
+					logging.Error("Error", err))
+			}
+			log.Infow("Compacted member seeded from imported snapshot",
+				logging.Uint32("GroupID", uint32(groupID)),
+				logging.Uint32("MemberID", uint32(memberID)),
+				logging.Uint64("Index", index))
+			return
+		}
+		switch err {
+		case dragonboat.ErrClusterNotFound, dragonboat.ErrClusterClosed, dragonboat.ErrClosed:
+			return
+		}
+		log.Debugw("Failed to compact member seeded from imported snapshot",
+			logging.Uint32("GroupID", uint32(groupID)),
+			logging.Uint32("MemberID", uint32(memberID)),
+			logging.Error("Error", err))
+		time.Sleep(n.config.GetElectionTimeout())
+	}
+}
+
+// getMembership reads the membership of the given group through the group, returning nil if the membership
+// cannot be read within the election timeout, e.g. because the group has lost its quorum
+func (n *Protocol) getMembership(ctx context.Context, groupID GroupID) *dragonboat.Membership {
+	ctx, cancel := context.WithTimeout(ctx, n.config.GetElectionTimeout())
+	defer cancel()
+	membership, err := n.host.SyncGetClusterMembership(ctx, uint64(groupID))
+	if err != nil {
+		log.Warnw("Failed to read group membership",
+			logging.Uint32("GroupID", uint32(groupID)),
+			logging.Error("Error", err))
+		return nil
+	}
+	return membership
+}
+
+// newGroupStatus returns the status of the given partition. Observers and witnesses are only known from the
+// group's membership; if the membership could not be read, only the voting members known to the local replica
+// are reported.
+func newGroupStatus(partition *Partition, info dragonboat.ClusterInfo, membership *dragonboat.Membership) (*GroupStatus, error) {
+	role := MemberRole_MEMBER
+	if info.Pending {
+		role = MemberRole_UNKNOWN
+	} else if info.IsObserver {
+		role = MemberRole_OBSERVER
+	} else if info.IsWitness {
+		role = MemberRole_WITNESS
+	}
+
+	nodes := info.Nodes
+	var observers, witnesses map[uint64]string
+	if membership != nil {
+		nodes = membership.Nodes
+		observers = membership.Observers
+		witnesses = membership.Witnesses
+	}
+	members, err := newMemberConfigs(nodes)
+	if err != nil {
+		return nil, err
+	}
+	observerConfigs, err := newMemberConfigs(observers)
+	if err != nil {
+		return nil, err
+	}
+	witnessConfigs, err := newMemberConfigs(witnesses)
+	if err != nil {
+		return nil, err
+	}
+
+	term, leader := partition.getLeader()
+	return &GroupStatus{
+		GroupID:       GroupID(info.ClusterID),
+		MemberID:      MemberID(info.NodeID),
+		Role:          role,
+		Term:          term,
+		Leader:        leader,
+		AppliedIndex:  partition.getAppliedIndex(),
+		SnapshotIndex: partition.getSnapshotIndex(),
+		Members:       members,
+		Observers:     observerConfigs,
+		Witnesses:     witnessConfigs,
+	}, nil
+}
+
+// newMemberConfigs returns the configurations of the members with the given Raft addresses, sorted by member ID
+func newMemberConfigs(addresses map[uint64]string) ([]MemberConfig, error) {
+	members := make([]MemberConfig, 0, len(addresses))
+	for memberID, address := range addresses {
+		host, portS, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, errors.NewInternal("invalid address %s for member %d: %v", address, memberID, err)
+		}
+		port, err := strconv.Atoi(portS)
+		if err != nil {
+			return nil, errors.NewInternal("invalid address %s for member %d: %v", address, memberID, err)
+		}
+		members = append(members, MemberConfig{
+			MemberID: MemberID(memberID),
+			Host:     host,
+			Port:     int32(port),
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].MemberID < members[j].MemberID
+	})
+	return members, nil
+}
+
+func (n *Protocol) newStateMachine(clusterID, nodeID uint64) dbstatemachine.IConcurrentStateMachine {
 	streams := newContext()
-	partition := newPartition(protocol.PartitionID(clusterID), MemberID(nodeID), n.host, streams)
+	partition := newPartition(protocol.PartitionID(clusterID), MemberID(nodeID), n.host, streams, n.config, n.options, n.forwarder)
 	n.mu.Lock()
 	n.partitions[partition.ID()] = partition
 	n.mu.Unlock()
-	return newStateMachine(streams, n.registry)
+	seed := getSeedPath(n.config.GetDataDir(), GroupID(clusterID), MemberID(nodeID))
+	return newStateMachine(partition, streams, n.registry, seed)
 }
 
 func (n *Protocol) Shutdown() error {
 	n.host.Stop()
+	n.mu.RLock()
+	for _, partition := range n.partitions {
+		if partition.proposer != nil {
+			partition.proposer.close()
+		}
+	}
+	n.mu.RUnlock()
+	n.forwarder.close()
+
+	// Close the watchers' queues so the events published while the host was stopping are delivered before
+	// the watchers' streams are closed
+	n.eventsMu.Lock()
+	for id, watcher := range n.watchers {
+		watcher.close()
+		delete(n.watchers, id)
+	}
+	n.eventsMu.Unlock()
 	return nil
 }
 
 func (n *Protocol) getRaftConfig(config GroupConfig) raftconfig.Config {
-	electionRTT := uint64(10)
+	electionRTT := uint64(defaultElectionRTT)
 	if n.config.ElectionTimeout != nil {
 		electionRTT = uint64(n.config.ElectionTimeout.Milliseconds() / n.config.GetHeartbeatPeriod().Milliseconds())
 	}
@@ -62,6 +62,80 @@ func (MemberRole) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_a7226d1cf45660e1, []int{0}
 }
 
+// EventType is the type of an Event
+type EventType int32
+
+const (
+	EventType_UNKNOWN_EVENT           EventType = 0
+	EventType_MEMBER_READY            EventType = 1
+	EventType_LEADER_UPDATED          EventType = 2
+	EventType_MEMBERSHIP_CHANGED      EventType = 3
+	EventType_SEND_SNAPSHOT_STARTED   EventType = 4
+	EventType_SEND_SNAPSHOT_COMPLETED EventType = 5
+	EventType_SEND_SNAPSHOT_ABORTED   EventType = 6
+	EventType_SNAPSHOT_RECEIVED       EventType = 7
+	EventType_SNAPSHOT_RECOVERED      EventType = 8
+	EventType_SNAPSHOT_CREATED        EventType = 9
+	EventType_SNAPSHOT_COMPACTED      EventType = 10
+	EventType_LOG_COMPACTED           EventType = 11
+	EventType_LOGDB_COMPACTED         EventType = 12
+	EventType_CONNECTION_ESTABLISHED  EventType = 13
+	EventType_CONNECTION_FAILED       EventType = 14
+	EventType_RESYNC                  EventType = 15
+	EventType_MEMBER_UNLOADED         EventType = 16
+	EventType_HOST_SHUTTING_DOWN      EventType = 17
+)
+
+var EventType_name = map[int32]string{
+	0:  "UNKNOWN_EVENT",
+	1:  "MEMBER_READY",
+	2:  "LEADER_UPDATED",
+	3:  "MEMBERSHIP_CHANGED",
+	4:  "SEND_SNAPSHOT_STARTED",
+	5:  "SEND_SNAPSHOT_COMPLETED",
+	6:  "SEND_SNAPSHOT_ABORTED",
+	7:  "SNAPSHOT_RECEIVED",
+	8:  "SNAPSHOT_RECOVERED",
+	9:  "SNAPSHOT_CREATED",
+	10: "SNAPSHOT_COMPACTED",
+	11: "LOG_COMPACTED",
+	12: "LOGDB_COMPACTED",
+	13: "CONNECTION_ESTABLISHED",
+	14: "CONNECTION_FAILED",
+	15: "RESYNC",
+	16: "MEMBER_UNLOADED",
+	17: "HOST_SHUTTING_DOWN",
+}
+
+var EventType_value = map[string]int32{
+	"UNKNOWN_EVENT":           0,
+	"MEMBER_READY":            1,
+	"LEADER_UPDATED":          2,
+	"MEMBERSHIP_CHANGED":      3,
+	"SEND_SNAPSHOT_STARTED":   4,
+	"SEND_SNAPSHOT_COMPLETED": 5,
+	"SEND_SNAPSHOT_ABORTED":   6,
+	"SNAPSHOT_RECEIVED":       7,
+	"SNAPSHOT_RECOVERED":      8,
+	"SNAPSHOT_CREATED":        9,
+	"SNAPSHOT_COMPACTED":      10,
+	"LOG_COMPACTED":           11,
+	"LOGDB_COMPACTED":         12,
+	"CONNECTION_ESTABLISHED":  13,
+	"CONNECTION_FAILED":       14,
+	"RESYNC":                  15,
+	"MEMBER_UNLOADED":         16,
+	"HOST_SHUTTING_DOWN":      17,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{1}
+}
+
 type GroupConfig struct {
 	GroupID  GroupID        `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
 	MemberID MemberID       `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
@@ -194,6 +268,11 @@ type RaftProposal struct {
 	Term        Term        `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
 	SequenceNum SequenceNum `protobuf:"varint,2,opt,name=sequence_num,json=sequenceNum,proto3,casttype=SequenceNum" json:"sequence_num,omitempty"`
 	Data        []byte      `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// trace_context is the W3C trace context of the span that made the proposal
+	TraceContext map[string]string `protobuf:"bytes,5,rep,name=trace_context,json=traceContext,proto3" json:"trace_context,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// batch is a batch of proposals made without client sessions, which are applied in order as a single entry.
+	// Batched proposals are not deduplicated, so the entry has no result.
+	Batch []*RaftProposal `protobuf:"bytes,6,rep,name=batch,proto3" json:"batch,omitempty"`
 }
 
 func (m *RaftProposal) Reset()         { *m = RaftProposal{} }
@@ -250,6 +329,359 @@ func (m *RaftProposal) GetData() []byte {
 	return nil
 }
 
+func (m *RaftProposal) GetTraceContext() map[string]string {
+	if m != nil {
+		return m.TraceContext
+	}
+	return nil
+}
+
+func (m *RaftProposal) GetBatch() []*RaftProposal {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+// RaftProposalResult is the result of applying a RaftProposal. Proposals deduplicated by their Raft client
+// session return the result of the original proposal.
+type RaftProposalResult struct {
+	Term        Term        `protobuf:"varint,1,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	SequenceNum SequenceNum `protobuf:"varint,2,opt,name=sequence_num,json=sequenceNum,proto3,casttype=SequenceNum" json:"sequence_num,omitempty"`
+	// outputs are the outputs produced by the proposal while it was applied
+	Outputs [][]byte `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	// complete indicates whether the proposal was complete once applied
+	Complete bool `protobuf:"varint,4,opt,name=complete,proto3" json:"complete,omitempty"`
+}
+
+func (m *RaftProposalResult) Reset()         { *m = RaftProposalResult{} }
+func (m *RaftProposalResult) String() string { return proto.CompactTextString(m) }
+func (*RaftProposalResult) ProtoMessage()    {}
+func (*RaftProposalResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{3}
+}
+func (m *RaftProposalResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RaftProposalResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RaftProposalResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RaftProposalResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RaftProposalResult.Merge(m, src)
+}
+func (m *RaftProposalResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *RaftProposalResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_RaftProposalResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RaftProposalResult proto.InternalMessageInfo
+
+func (m *RaftProposalResult) GetTerm() Term {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RaftProposalResult) GetSequenceNum() SequenceNum {
+	if m != nil {
+		return m.SequenceNum
+	}
+	return 0
+}
+
+func (m *RaftProposalResult) GetOutputs() [][]byte {
+	if m != nil {
+		return m.Outputs
+	}
+	return nil
+}
+
+func (m *RaftProposalResult) GetComplete() bool {
+	if m != nil {
+		return m.Complete
+	}
+	return false
+}
+
+// LeaderHint identifies the leader of a group. Followers that do not forward proposals attach the hint to
+// the Unavailable errors they return so clients can retry against the leader.
+type LeaderHint struct {
+	GroupID GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	Term    Term     `protobuf:"varint,2,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Leader  MemberID `protobuf:"varint,3,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	// host is the host of the leader's API server
+	Host string `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	// port is the port of the leader's API server
+	Port int32 `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (m *LeaderHint) Reset()         { *m = LeaderHint{} }
+func (m *LeaderHint) String() string { return proto.CompactTextString(m) }
+func (*LeaderHint) ProtoMessage()    {}
+func (*LeaderHint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{4}
+}
+func (m *LeaderHint) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaderHint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaderHint.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LeaderHint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderHint.Merge(m, src)
+}
+func (m *LeaderHint) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaderHint) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderHint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaderHint proto.InternalMessageInfo
+
+func (m *LeaderHint) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *LeaderHint) GetTerm() Term {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *LeaderHint) GetLeader() MemberID {
+	if m != nil {
+		return m.Leader
+	}
+	return 0
+}
+
+func (m *LeaderHint) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *LeaderHint) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type ForwardProposalRequest struct {
+	GroupID GroupID `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	// input is the encoded ProposalInput
+	Input []byte `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+}
+
+func (m *ForwardProposalRequest) Reset()         { *m = ForwardProposalRequest{} }
+func (m *ForwardProposalRequest) String() string { return proto.CompactTextString(m) }
+func (*ForwardProposalRequest) ProtoMessage()    {}
+func (*ForwardProposalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{5}
+}
+func (m *ForwardProposalRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForwardProposalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForwardProposalRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ForwardProposalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardProposalRequest.Merge(m, src)
+}
+func (m *ForwardProposalRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForwardProposalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardProposalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardProposalRequest proto.InternalMessageInfo
+
+func (m *ForwardProposalRequest) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+func (m *ForwardProposalRequest) GetInput() []byte {
+	if m != nil {
+		return m.Input
+	}
+	return nil
+}
+
+type ForwardProposalResponse struct {
+	// output is the encoded ProposalOutput
+	Output []byte `protobuf:"bytes,1,opt,name=output,proto3" json:"output,omitempty"`
+}
+
+func (m *ForwardProposalResponse) Reset()         { *m = ForwardProposalResponse{} }
+func (m *ForwardProposalResponse) String() string { return proto.CompactTextString(m) }
+func (*ForwardProposalResponse) ProtoMessage()    {}
+func (*ForwardProposalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{6}
+}
+func (m *ForwardProposalResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ForwardProposalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ForwardProposalResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ForwardProposalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ForwardProposalResponse.Merge(m, src)
+}
+func (m *ForwardProposalResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ForwardProposalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ForwardProposalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ForwardProposalResponse proto.InternalMessageInfo
+
+func (m *ForwardProposalResponse) GetOutput() []byte {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+type GetAppliedIndexRequest struct {
+	GroupID GroupID `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+}
+
+func (m *GetAppliedIndexRequest) Reset()         { *m = GetAppliedIndexRequest{} }
+func (m *GetAppliedIndexRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAppliedIndexRequest) ProtoMessage()    {}
+func (*GetAppliedIndexRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{7}
+}
+func (m *GetAppliedIndexRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAppliedIndexRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAppliedIndexRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetAppliedIndexRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAppliedIndexRequest.Merge(m, src)
+}
+func (m *GetAppliedIndexRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAppliedIndexRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAppliedIndexRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAppliedIndexRequest proto.InternalMessageInfo
+
+func (m *GetAppliedIndexRequest) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
+}
+
+type GetAppliedIndexResponse struct {
+	// index is the index applied by the leader of the group
+	Index Index `protobuf:"varint,1,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+}
+
+func (m *GetAppliedIndexResponse) Reset()         { *m = GetAppliedIndexResponse{} }
+func (m *GetAppliedIndexResponse) String() string { return proto.CompactTextString(m) }
+func (*GetAppliedIndexResponse) ProtoMessage()    {}
+func (*GetAppliedIndexResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{8}
+}
+func (m *GetAppliedIndexResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetAppliedIndexResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetAppliedIndexResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GetAppliedIndexResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAppliedIndexResponse.Merge(m, src)
+}
+func (m *GetAppliedIndexResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetAppliedIndexResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAppliedIndexResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetAppliedIndexResponse proto.InternalMessageInfo
+
+func (m *GetAppliedIndexResponse) GetIndex() Index {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
 type BootstrapRequest struct {
 	Group GroupConfig `protobuf:"bytes,1,opt,name=group,proto3" json:"group"`
 }
@@ -258,7 +690,7 @@ func (m *BootstrapRequest) Reset()         { *m = BootstrapRequest{} }
 func (m *BootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*BootstrapRequest) ProtoMessage()    {}
 func (*BootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{3}
+	return fileDescriptor_a7226d1cf45660e1, []int{9}
 }
 func (m *BootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -301,7 +733,7 @@ func (m *BootstrapResponse) Reset()         { *m = BootstrapResponse{} }
 func (m *BootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*BootstrapResponse) ProtoMessage()    {}
 func (*BootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{4}
+	return fileDescriptor_a7226d1cf45660e1, []int{10}
 }
 func (m *BootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -338,7 +770,7 @@ func (m *JoinRequest) Reset()         { *m = JoinRequest{} }
 func (m *JoinRequest) String() string { return proto.CompactTextString(m) }
 func (*JoinRequest) ProtoMessage()    {}
 func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{5}
+	return fileDescriptor_a7226d1cf45660e1, []int{11}
 }
 func (m *JoinRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -381,7 +813,7 @@ func (m *JoinResponse) Reset()         { *m = JoinResponse{} }
 func (m *JoinResponse) String() string { return proto.CompactTextString(m) }
 func (*JoinResponse) ProtoMessage()    {}
 func (*JoinResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{6}
+	return fileDescriptor_a7226d1cf45660e1, []int{12}
 }
 func (m *JoinResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -418,7 +850,7 @@ func (m *LeaveRequest) Reset()         { *m = LeaveRequest{} }
 func (m *LeaveRequest) String() string { return proto.CompactTextString(m) }
 func (*LeaveRequest) ProtoMessage()    {}
 func (*LeaveRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{7}
+	return fileDescriptor_a7226d1cf45660e1, []int{13}
 }
 func (m *LeaveRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -461,7 +893,7 @@ func (m *LeaveResponse) Reset()         { *m = LeaveResponse{} }
 func (m *LeaveResponse) String() string { return proto.CompactTextString(m) }
 func (*LeaveResponse) ProtoMessage()    {}
 func (*LeaveResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{8}
+	return fileDescriptor_a7226d1cf45660e1, []int{14}
 }
 func (m *LeaveResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -490,21 +922,24 @@ func (m *LeaveResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_LeaveResponse proto.InternalMessageInfo
 
-type WatchRequest struct {
+type AddMemberRequest struct {
+	GroupID GroupID      `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	Member  MemberConfig `protobuf:"bytes,2,opt,name=member,proto3" json:"member"`
+	Role    MemberRole   `protobuf:"varint,3,opt,name=role,proto3,enum=atomix.consensus.node.v1.MemberRole" json:"role,omitempty"`
 }
 
-func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
-func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
-func (*WatchRequest) ProtoMessage()    {}
-func (*WatchRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{9}
+func (m *AddMemberRequest) Reset()         { *m = AddMemberRequest{} }
+func (m *AddMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*AddMemberRequest) ProtoMessage()    {}
+func (*AddMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{15}
 }
-func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+func (m *AddMemberRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AddMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AddMemberRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -514,50 +949,54 @@ func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *WatchRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_WatchRequest.Merge(m, src)
+func (m *AddMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddMemberRequest.Merge(m, src)
 }
-func (m *WatchRequest) XXX_Size() int {
+func (m *AddMemberRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *WatchRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+func (m *AddMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddMemberRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+var xxx_messageInfo_AddMemberRequest proto.InternalMessageInfo
 
-type Event struct {
-	Timestamp time.Time `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	// Types that are valid to be assigned to Event:
-	//	*Event_MemberReady
-	//	*Event_LeaderUpdated
-	//	*Event_MembershipChanged
-	//	*Event_SendSnapshotStarted
-	//	*Event_SendSnapshotCompleted
-	//	*Event_SendSnapshotAborted
-	//	*Event_SnapshotReceived
-	//	*Event_SnapshotRecovered
-	//	*Event_SnapshotCreated
-	//	*Event_SnapshotCompacted
-	//	*Event_LogCompacted
-	//	*Event_LogdbCompacted
-	//	*Event_ConnectionEstablished
-	//	*Event_ConnectionFailed
-	Event isEvent_Event `protobuf_oneof:"event"`
+func (m *AddMemberRequest) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
 }
 
-func (m *Event) Reset()         { *m = Event{} }
-func (m *Event) String() string { return proto.CompactTextString(m) }
-func (*Event) ProtoMessage()    {}
-func (*Event) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{10}
+func (m *AddMemberRequest) GetMember() MemberConfig {
+	if m != nil {
+		return m.Member
+	}
+	return MemberConfig{}
 }
-func (m *Event) XXX_Unmarshal(b []byte) error {
+
+func (m *AddMemberRequest) GetRole() MemberRole {
+	if m != nil {
+		return m.Role
+	}
+	return MemberRole_UNKNOWN
+}
+
+type AddMemberResponse struct {
+}
+
+func (m *AddMemberResponse) Reset()         { *m = AddMemberResponse{} }
+func (m *AddMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*AddMemberResponse) ProtoMessage()    {}
+func (*AddMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{16}
+}
+func (m *AddMemberResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *AddMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+		return xxx_messageInfo_AddMemberResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -567,231 +1006,298 @@ func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Event) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Event.Merge(m, src)
+func (m *AddMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddMemberResponse.Merge(m, src)
 }
-func (m *Event) XXX_Size() int {
+func (m *AddMemberResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *Event) XXX_DiscardUnknown() {
-	xxx_messageInfo_Event.DiscardUnknown(m)
+func (m *AddMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddMemberResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Event proto.InternalMessageInfo
+var xxx_messageInfo_AddMemberResponse proto.InternalMessageInfo
 
-type isEvent_Event interface {
-	isEvent_Event()
-	MarshalTo([]byte) (int, error)
-	Size() int
+type RemoveMemberRequest struct {
+	GroupID  GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	MemberID MemberID `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
 }
 
-type Event_MemberReady struct {
-	MemberReady *MemberReadyEvent `protobuf:"bytes,2,opt,name=member_ready,json=memberReady,proto3,oneof" json:"member_ready,omitempty"`
+func (m *RemoveMemberRequest) Reset()         { *m = RemoveMemberRequest{} }
+func (m *RemoveMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveMemberRequest) ProtoMessage()    {}
+func (*RemoveMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{17}
 }
-type Event_LeaderUpdated struct {
-	LeaderUpdated *LeaderUpdatedEvent `protobuf:"bytes,3,opt,name=leader_updated,json=leaderUpdated,proto3,oneof" json:"leader_updated,omitempty"`
+func (m *RemoveMemberRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-type Event_MembershipChanged struct {
-	MembershipChanged *MembershipChangedEvent `protobuf:"bytes,4,opt,name=membership_changed,json=membershipChanged,proto3,oneof" json:"membership_changed,omitempty"`
+func (m *RemoveMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveMemberRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-type Event_SendSnapshotStarted struct {
-	SendSnapshotStarted *SendSnapshotStartedEvent `protobuf:"bytes,5,opt,name=send_snapshot_started,json=sendSnapshotStarted,proto3,oneof" json:"send_snapshot_started,omitempty"`
+func (m *RemoveMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveMemberRequest.Merge(m, src)
 }
-type Event_SendSnapshotCompleted struct {
-	SendSnapshotCompleted *SendSnapshotCompletedEvent `protobuf:"bytes,6,opt,name=send_snapshot_completed,json=sendSnapshotCompleted,proto3,oneof" json:"send_snapshot_completed,omitempty"`
+func (m *RemoveMemberRequest) XXX_Size() int {
+	return m.Size()
 }
-type Event_SendSnapshotAborted struct {
-	SendSnapshotAborted *SendSnapshotAbortedEvent `protobuf:"bytes,7,opt,name=send_snapshot_aborted,json=sendSnapshotAborted,proto3,oneof" json:"send_snapshot_aborted,omitempty"`
+func (m *RemoveMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveMemberRequest.DiscardUnknown(m)
 }
-type Event_SnapshotReceived struct {
-	SnapshotReceived *SnapshotReceivedEvent `protobuf:"bytes,8,opt,name=snapshot_received,json=snapshotReceived,proto3,oneof" json:"snapshot_received,omitempty"`
+
+var xxx_messageInfo_RemoveMemberRequest proto.InternalMessageInfo
+
+func (m *RemoveMemberRequest) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
+	}
+	return 0
 }
-type Event_SnapshotRecovered struct {
-	SnapshotRecovered *SnapshotRecoveredEvent `protobuf:"bytes,9,opt,name=snapshot_recovered,json=snapshotRecovered,proto3,oneof" json:"snapshot_recovered,omitempty"`
+
+func (m *RemoveMemberRequest) GetMemberID() MemberID {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
 }
-type Event_SnapshotCreated struct {
-	SnapshotCreated *SnapshotCreatedEvent `protobuf:"bytes,10,opt,name=snapshot_created,json=snapshotCreated,proto3,oneof" json:"snapshot_created,omitempty"`
+
+type RemoveMemberResponse struct {
 }
-type Event_SnapshotCompacted struct {
-	SnapshotCompacted *SnapshotCompactedEvent `protobuf:"bytes,11,opt,name=snapshot_compacted,json=snapshotCompacted,proto3,oneof" json:"snapshot_compacted,omitempty"`
+
+func (m *RemoveMemberResponse) Reset()         { *m = RemoveMemberResponse{} }
+func (m *RemoveMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*RemoveMemberResponse) ProtoMessage()    {}
+func (*RemoveMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{18}
 }
-type Event_LogCompacted struct {
-	LogCompacted *LogCompactedEvent `protobuf:"bytes,12,opt,name=log_compacted,json=logCompacted,proto3,oneof" json:"log_compacted,omitempty"`
+func (m *RemoveMemberResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-type Event_LogdbCompacted struct {
-	LogdbCompacted *LogDBCompactedEvent `protobuf:"bytes,13,opt,name=logdb_compacted,json=logdbCompacted,proto3,oneof" json:"logdb_compacted,omitempty"`
+func (m *RemoveMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RemoveMemberResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-type Event_ConnectionEstablished struct {
-	ConnectionEstablished *ConnectionEstablishedEvent `protobuf:"bytes,14,opt,name=connection_established,json=connectionEstablished,proto3,oneof" json:"connection_established,omitempty"`
+func (m *RemoveMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveMemberResponse.Merge(m, src)
 }
-type Event_ConnectionFailed struct {
-	ConnectionFailed *ConnectionFailedEvent `protobuf:"bytes,15,opt,name=connection_failed,json=connectionFailed,proto3,oneof" json:"connection_failed,omitempty"`
+func (m *RemoveMemberResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RemoveMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveMemberResponse.DiscardUnknown(m)
 }
 
-func (*Event_MemberReady) isEvent_Event()           {}
-func (*Event_LeaderUpdated) isEvent_Event()         {}
-func (*Event_MembershipChanged) isEvent_Event()     {}
-func (*Event_SendSnapshotStarted) isEvent_Event()   {}
-func (*Event_SendSnapshotCompleted) isEvent_Event() {}
-func (*Event_SendSnapshotAborted) isEvent_Event()   {}
-func (*Event_SnapshotReceived) isEvent_Event()      {}
-func (*Event_SnapshotRecovered) isEvent_Event()     {}
-func (*Event_SnapshotCreated) isEvent_Event()       {}
-func (*Event_SnapshotCompacted) isEvent_Event()     {}
-func (*Event_LogCompacted) isEvent_Event()          {}
-func (*Event_LogdbCompacted) isEvent_Event()        {}
-func (*Event_ConnectionEstablished) isEvent_Event() {}
-func (*Event_ConnectionFailed) isEvent_Event()      {}
+var xxx_messageInfo_RemoveMemberResponse proto.InternalMessageInfo
 
-func (m *Event) GetEvent() isEvent_Event {
-	if m != nil {
-		return m.Event
-	}
-	return nil
+type PromoteMemberRequest struct {
+	GroupID  GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	MemberID MemberID `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
 }
 
-func (m *Event) GetTimestamp() time.Time {
-	if m != nil {
-		return m.Timestamp
+func (m *PromoteMemberRequest) Reset()         { *m = PromoteMemberRequest{} }
+func (m *PromoteMemberRequest) String() string { return proto.CompactTextString(m) }
+func (*PromoteMemberRequest) ProtoMessage()    {}
+func (*PromoteMemberRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{19}
+}
+func (m *PromoteMemberRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PromoteMemberRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PromoteMemberRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return time.Time{}
+}
+func (m *PromoteMemberRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteMemberRequest.Merge(m, src)
+}
+func (m *PromoteMemberRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PromoteMemberRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteMemberRequest.DiscardUnknown(m)
 }
 
-func (m *Event) GetMemberReady() *MemberReadyEvent {
-	if x, ok := m.GetEvent().(*Event_MemberReady); ok {
-		return x.MemberReady
+var xxx_messageInfo_PromoteMemberRequest proto.InternalMessageInfo
+
+func (m *PromoteMemberRequest) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
 	}
-	return nil
+	return 0
 }
 
-func (m *Event) GetLeaderUpdated() *LeaderUpdatedEvent {
-	if x, ok := m.GetEvent().(*Event_LeaderUpdated); ok {
-		return x.LeaderUpdated
+func (m *PromoteMemberRequest) GetMemberID() MemberID {
+	if m != nil {
+		return m.MemberID
 	}
-	return nil
+	return 0
 }
 
-func (m *Event) GetMembershipChanged() *MembershipChangedEvent {
-	if x, ok := m.GetEvent().(*Event_MembershipChanged); ok {
-		return x.MembershipChanged
-	}
-	return nil
+type PromoteMemberResponse struct {
 }
 
-func (m *Event) GetSendSnapshotStarted() *SendSnapshotStartedEvent {
-	if x, ok := m.GetEvent().(*Event_SendSnapshotStarted); ok {
-		return x.SendSnapshotStarted
+func (m *PromoteMemberResponse) Reset()         { *m = PromoteMemberResponse{} }
+func (m *PromoteMemberResponse) String() string { return proto.CompactTextString(m) }
+func (*PromoteMemberResponse) ProtoMessage()    {}
+func (*PromoteMemberResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{20}
+}
+func (m *PromoteMemberResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PromoteMemberResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PromoteMemberResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *PromoteMemberResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteMemberResponse.Merge(m, src)
+}
+func (m *PromoteMemberResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PromoteMemberResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteMemberResponse.DiscardUnknown(m)
 }
 
-func (m *Event) GetSendSnapshotCompleted() *SendSnapshotCompletedEvent {
-	if x, ok := m.GetEvent().(*Event_SendSnapshotCompleted); ok {
-		return x.SendSnapshotCompleted
-	}
-	return nil
+var xxx_messageInfo_PromoteMemberResponse proto.InternalMessageInfo
+
+type TransferLeadershipRequest struct {
+	GroupID        GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	TargetMemberID MemberID `protobuf:"varint,2,opt,name=target_member_id,json=targetMemberId,proto3,casttype=MemberID" json:"target_member_id,omitempty"`
 }
 
-func (m *Event) GetSendSnapshotAborted() *SendSnapshotAbortedEvent {
-	if x, ok := m.GetEvent().(*Event_SendSnapshotAborted); ok {
-		return x.SendSnapshotAborted
+func (m *TransferLeadershipRequest) Reset()         { *m = TransferLeadershipRequest{} }
+func (m *TransferLeadershipRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipRequest) ProtoMessage()    {}
+func (*TransferLeadershipRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{21}
+}
+func (m *TransferLeadershipRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferLeadershipRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferLeadershipRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *TransferLeadershipRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipRequest.Merge(m, src)
+}
+func (m *TransferLeadershipRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferLeadershipRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipRequest.DiscardUnknown(m)
 }
 
-func (m *Event) GetSnapshotReceived() *SnapshotReceivedEvent {
-	if x, ok := m.GetEvent().(*Event_SnapshotReceived); ok {
-		return x.SnapshotReceived
+var xxx_messageInfo_TransferLeadershipRequest proto.InternalMessageInfo
+
+func (m *TransferLeadershipRequest) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
 	}
-	return nil
+	return 0
 }
 
-func (m *Event) GetSnapshotRecovered() *SnapshotRecoveredEvent {
-	if x, ok := m.GetEvent().(*Event_SnapshotRecovered); ok {
-		return x.SnapshotRecovered
+func (m *TransferLeadershipRequest) GetTargetMemberID() MemberID {
+	if m != nil {
+		return m.TargetMemberID
 	}
-	return nil
+	return 0
 }
 
-func (m *Event) GetSnapshotCreated() *SnapshotCreatedEvent {
-	if x, ok := m.GetEvent().(*Event_SnapshotCreated); ok {
-		return x.SnapshotCreated
-	}
-	return nil
+type TransferLeadershipResponse struct {
 }
 
-func (m *Event) GetSnapshotCompacted() *SnapshotCompactedEvent {
-	if x, ok := m.GetEvent().(*Event_SnapshotCompacted); ok {
-		return x.SnapshotCompacted
-	}
-	return nil
+func (m *TransferLeadershipResponse) Reset()         { *m = TransferLeadershipResponse{} }
+func (m *TransferLeadershipResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferLeadershipResponse) ProtoMessage()    {}
+func (*TransferLeadershipResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{22}
 }
-
-func (m *Event) GetLogCompacted() *LogCompactedEvent {
-	if x, ok := m.GetEvent().(*Event_LogCompacted); ok {
-		return x.LogCompacted
-	}
-	return nil
+func (m *TransferLeadershipResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (m *Event) GetLogdbCompacted() *LogDBCompactedEvent {
-	if x, ok := m.GetEvent().(*Event_LogdbCompacted); ok {
-		return x.LogdbCompacted
+func (m *TransferLeadershipResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferLeadershipResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
 }
-
-func (m *Event) GetConnectionEstablished() *ConnectionEstablishedEvent {
-	if x, ok := m.GetEvent().(*Event_ConnectionEstablished); ok {
-		return x.ConnectionEstablished
-	}
-	return nil
+func (m *TransferLeadershipResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferLeadershipResponse.Merge(m, src)
 }
-
-func (m *Event) GetConnectionFailed() *ConnectionFailedEvent {
-	if x, ok := m.GetEvent().(*Event_ConnectionFailed); ok {
-		return x.ConnectionFailed
-	}
-	return nil
+func (m *TransferLeadershipResponse) XXX_Size() int {
+	return m.Size()
 }
-
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*Event) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*Event_MemberReady)(nil),
-		(*Event_LeaderUpdated)(nil),
-		(*Event_MembershipChanged)(nil),
-		(*Event_SendSnapshotStarted)(nil),
-		(*Event_SendSnapshotCompleted)(nil),
-		(*Event_SendSnapshotAborted)(nil),
-		(*Event_SnapshotReceived)(nil),
-		(*Event_SnapshotRecovered)(nil),
-		(*Event_SnapshotCreated)(nil),
-		(*Event_SnapshotCompacted)(nil),
-		(*Event_LogCompacted)(nil),
-		(*Event_LogdbCompacted)(nil),
-		(*Event_ConnectionEstablished)(nil),
-		(*Event_ConnectionFailed)(nil),
-	}
+func (m *TransferLeadershipResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferLeadershipResponse.DiscardUnknown(m)
 }
 
-type ConnectionInfo struct {
-	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Snapshot bool   `protobuf:"varint,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+var xxx_messageInfo_TransferLeadershipResponse proto.InternalMessageInfo
+
+type GetGroupStatusRequest struct {
+	GroupID GroupID `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
 }
 
-func (m *ConnectionInfo) Reset()         { *m = ConnectionInfo{} }
-func (m *ConnectionInfo) String() string { return proto.CompactTextString(m) }
-func (*ConnectionInfo) ProtoMessage()    {}
-func (*ConnectionInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{11}
+func (m *GetGroupStatusRequest) Reset()         { *m = GetGroupStatusRequest{} }
+func (m *GetGroupStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetGroupStatusRequest) ProtoMessage()    {}
+func (*GetGroupStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{23}
 }
-func (m *ConnectionInfo) XXX_Unmarshal(b []byte) error {
+func (m *GetGroupStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ConnectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetGroupStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ConnectionInfo.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetGroupStatusRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -801,49 +1307,41 @@ func (m *ConnectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *ConnectionInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectionInfo.Merge(m, src)
+func (m *GetGroupStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGroupStatusRequest.Merge(m, src)
 }
-func (m *ConnectionInfo) XXX_Size() int {
+func (m *GetGroupStatusRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ConnectionInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectionInfo.DiscardUnknown(m)
+func (m *GetGroupStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGroupStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectionInfo proto.InternalMessageInfo
-
-func (m *ConnectionInfo) GetAddress() string {
-	if m != nil {
-		return m.Address
-	}
-	return ""
-}
+var xxx_messageInfo_GetGroupStatusRequest proto.InternalMessageInfo
 
-func (m *ConnectionInfo) GetSnapshot() bool {
+func (m *GetGroupStatusRequest) GetGroupID() GroupID {
 	if m != nil {
-		return m.Snapshot
+		return m.GroupID
 	}
-	return false
+	return 0
 }
 
-type MemberEvent struct {
-	GroupID  GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
-	MemberID MemberID `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
+type GetGroupStatusResponse struct {
+	Group GroupStatus `protobuf:"bytes,1,opt,name=group,proto3" json:"group"`
 }
 
-func (m *MemberEvent) Reset()         { *m = MemberEvent{} }
-func (m *MemberEvent) String() string { return proto.CompactTextString(m) }
-func (*MemberEvent) ProtoMessage()    {}
-func (*MemberEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{12}
+func (m *GetGroupStatusResponse) Reset()         { *m = GetGroupStatusResponse{} }
+func (m *GetGroupStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetGroupStatusResponse) ProtoMessage()    {}
+func (*GetGroupStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{24}
 }
-func (m *MemberEvent) XXX_Unmarshal(b []byte) error {
+func (m *GetGroupStatusResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MemberEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetGroupStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MemberEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetGroupStatusResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -853,48 +1351,40 @@ func (m *MemberEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *MemberEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberEvent.Merge(m, src)
+func (m *GetGroupStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetGroupStatusResponse.Merge(m, src)
 }
-func (m *MemberEvent) XXX_Size() int {
+func (m *GetGroupStatusResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MemberEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberEvent.DiscardUnknown(m)
+func (m *GetGroupStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetGroupStatusResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberEvent proto.InternalMessageInfo
-
-func (m *MemberEvent) GetGroupID() GroupID {
-	if m != nil {
-		return m.GroupID
-	}
-	return 0
-}
+var xxx_messageInfo_GetGroupStatusResponse proto.InternalMessageInfo
 
-func (m *MemberEvent) GetMemberID() MemberID {
+func (m *GetGroupStatusResponse) GetGroup() GroupStatus {
 	if m != nil {
-		return m.MemberID
+		return m.Group
 	}
-	return 0
+	return GroupStatus{}
 }
 
-type MemberReadyEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+type ListGroupsRequest struct {
 }
 
-func (m *MemberReadyEvent) Reset()         { *m = MemberReadyEvent{} }
-func (m *MemberReadyEvent) String() string { return proto.CompactTextString(m) }
-func (*MemberReadyEvent) ProtoMessage()    {}
-func (*MemberReadyEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{13}
+func (m *ListGroupsRequest) Reset()         { *m = ListGroupsRequest{} }
+func (m *ListGroupsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListGroupsRequest) ProtoMessage()    {}
+func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{25}
 }
-func (m *MemberReadyEvent) XXX_Unmarshal(b []byte) error {
+func (m *ListGroupsRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MemberReadyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListGroupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MemberReadyEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListGroupsRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -904,34 +1394,34 @@ func (m *MemberReadyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *MemberReadyEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MemberReadyEvent.Merge(m, src)
+func (m *ListGroupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGroupsRequest.Merge(m, src)
 }
-func (m *MemberReadyEvent) XXX_Size() int {
+func (m *ListGroupsRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MemberReadyEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_MemberReadyEvent.DiscardUnknown(m)
+func (m *ListGroupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGroupsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MemberReadyEvent proto.InternalMessageInfo
+var xxx_messageInfo_ListGroupsRequest proto.InternalMessageInfo
 
-type MembershipChangedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+type ListGroupsResponse struct {
+	Groups []GroupStatus `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups"`
 }
 
-func (m *MembershipChangedEvent) Reset()         { *m = MembershipChangedEvent{} }
-func (m *MembershipChangedEvent) String() string { return proto.CompactTextString(m) }
-func (*MembershipChangedEvent) ProtoMessage()    {}
-func (*MembershipChangedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{14}
+func (m *ListGroupsResponse) Reset()         { *m = ListGroupsResponse{} }
+func (m *ListGroupsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListGroupsResponse) ProtoMessage()    {}
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{26}
 }
-func (m *MembershipChangedEvent) XXX_Unmarshal(b []byte) error {
+func (m *ListGroupsResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MembershipChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ListGroupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MembershipChangedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ListGroupsResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -941,36 +1431,53 @@ func (m *MembershipChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *MembershipChangedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MembershipChangedEvent.Merge(m, src)
+func (m *ListGroupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListGroupsResponse.Merge(m, src)
 }
-func (m *MembershipChangedEvent) XXX_Size() int {
+func (m *ListGroupsResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *MembershipChangedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_MembershipChangedEvent.DiscardUnknown(m)
+func (m *ListGroupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListGroupsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MembershipChangedEvent proto.InternalMessageInfo
+var xxx_messageInfo_ListGroupsResponse proto.InternalMessageInfo
 
-type LeaderUpdatedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Term        Term     `protobuf:"varint,2,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
-	Leader      MemberID `protobuf:"varint,3,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+func (m *ListGroupsResponse) GetGroups() []GroupStatus {
+	if m != nil {
+		return m.Groups
+	}
+	return nil
 }
 
-func (m *LeaderUpdatedEvent) Reset()         { *m = LeaderUpdatedEvent{} }
-func (m *LeaderUpdatedEvent) String() string { return proto.CompactTextString(m) }
-func (*LeaderUpdatedEvent) ProtoMessage()    {}
-func (*LeaderUpdatedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{15}
+type GroupStatus struct {
+	GroupID       GroupID    `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	MemberID      MemberID   `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
+	Role          MemberRole `protobuf:"varint,3,opt,name=role,proto3,enum=atomix.consensus.node.v1.MemberRole" json:"role,omitempty"`
+	Term          Term       `protobuf:"varint,4,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Leader        MemberID   `protobuf:"varint,5,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
+	AppliedIndex  Index      `protobuf:"varint,7,opt,name=applied_index,json=appliedIndex,proto3,casttype=Index" json:"applied_index,omitempty"`
+	SnapshotIndex Index      `protobuf:"varint,8,opt,name=snapshot_index,json=snapshotIndex,proto3,casttype=Index" json:"snapshot_index,omitempty"`
+	// members is the set of voting members of the group
+	Members []MemberConfig `protobuf:"bytes,9,rep,name=members,proto3" json:"members"`
+	// observers is the set of non-voting members of the group
+	Observers []MemberConfig `protobuf:"bytes,10,rep,name=observers,proto3" json:"observers"`
+	// witnesses is the set of witnesses of the group
+	Witnesses []MemberConfig `protobuf:"bytes,11,rep,name=witnesses,proto3" json:"witnesses"`
+}
+
+func (m *GroupStatus) Reset()         { *m = GroupStatus{} }
+func (m *GroupStatus) String() string { return proto.CompactTextString(m) }
+func (*GroupStatus) ProtoMessage()    {}
+func (*GroupStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{27}
 }
-func (m *LeaderUpdatedEvent) XXX_Unmarshal(b []byte) error {
+func (m *GroupStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LeaderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GroupStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LeaderUpdatedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GroupStatus.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -980,103 +1487,103 @@ func (m *LeaderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LeaderUpdatedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LeaderUpdatedEvent.Merge(m, src)
+func (m *GroupStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GroupStatus.Merge(m, src)
 }
-func (m *LeaderUpdatedEvent) XXX_Size() int {
+func (m *GroupStatus) XXX_Size() int {
 	return m.Size()
 }
-func (m *LeaderUpdatedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LeaderUpdatedEvent.DiscardUnknown(m)
+func (m *GroupStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_GroupStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LeaderUpdatedEvent proto.InternalMessageInfo
+var xxx_messageInfo_GroupStatus proto.InternalMessageInfo
 
-func (m *LeaderUpdatedEvent) GetTerm() Term {
+func (m *GroupStatus) GetGroupID() GroupID {
 	if m != nil {
-		return m.Term
+		return m.GroupID
 	}
 	return 0
 }
 
-func (m *LeaderUpdatedEvent) GetLeader() MemberID {
+func (m *GroupStatus) GetMemberID() MemberID {
 	if m != nil {
-		return m.Leader
+		return m.MemberID
 	}
 	return 0
 }
 
-type SendSnapshotStartedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	To          MemberID `protobuf:"varint,3,opt,name=to,proto3,casttype=MemberID" json:"to,omitempty"`
+func (m *GroupStatus) GetRole() MemberRole {
+	if m != nil {
+		return m.Role
+	}
+	return MemberRole_UNKNOWN
 }
 
-func (m *SendSnapshotStartedEvent) Reset()         { *m = SendSnapshotStartedEvent{} }
-func (m *SendSnapshotStartedEvent) String() string { return proto.CompactTextString(m) }
-func (*SendSnapshotStartedEvent) ProtoMessage()    {}
-func (*SendSnapshotStartedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{16}
-}
-func (m *SendSnapshotStartedEvent) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *SendSnapshotStartedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_SendSnapshotStartedEvent.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *GroupStatus) GetTerm() Term {
+	if m != nil {
+		return m.Term
 	}
+	return 0
 }
-func (m *SendSnapshotStartedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendSnapshotStartedEvent.Merge(m, src)
+
+func (m *GroupStatus) GetLeader() MemberID {
+	if m != nil {
+		return m.Leader
+	}
+	return 0
 }
-func (m *SendSnapshotStartedEvent) XXX_Size() int {
-	return m.Size()
+
+func (m *GroupStatus) GetAppliedIndex() Index {
+	if m != nil {
+		return m.AppliedIndex
+	}
+	return 0
 }
-func (m *SendSnapshotStartedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendSnapshotStartedEvent.DiscardUnknown(m)
+
+func (m *GroupStatus) GetSnapshotIndex() Index {
+	if m != nil {
+		return m.SnapshotIndex
+	}
+	return 0
 }
 
-var xxx_messageInfo_SendSnapshotStartedEvent proto.InternalMessageInfo
+func (m *GroupStatus) GetMembers() []MemberConfig {
+	if m != nil {
+		return m.Members
+	}
+	return nil
+}
 
-func (m *SendSnapshotStartedEvent) GetIndex() Index {
+func (m *GroupStatus) GetObservers() []MemberConfig {
 	if m != nil {
-		return m.Index
+		return m.Observers
 	}
-	return 0
+	return nil
 }
 
-func (m *SendSnapshotStartedEvent) GetTo() MemberID {
+func (m *GroupStatus) GetWitnesses() []MemberConfig {
 	if m != nil {
-		return m.To
+		return m.Witnesses
 	}
-	return 0
+	return nil
 }
 
-type SendSnapshotCompletedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	To          MemberID `protobuf:"varint,3,opt,name=to,proto3,casttype=MemberID" json:"to,omitempty"`
+type GetNodeInfoRequest struct {
 }
 
-func (m *SendSnapshotCompletedEvent) Reset()         { *m = SendSnapshotCompletedEvent{} }
-func (m *SendSnapshotCompletedEvent) String() string { return proto.CompactTextString(m) }
-func (*SendSnapshotCompletedEvent) ProtoMessage()    {}
-func (*SendSnapshotCompletedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{17}
+func (m *GetNodeInfoRequest) Reset()         { *m = GetNodeInfoRequest{} }
+func (m *GetNodeInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeInfoRequest) ProtoMessage()    {}
+func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{28}
 }
-func (m *SendSnapshotCompletedEvent) XXX_Unmarshal(b []byte) error {
+func (m *GetNodeInfoRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SendSnapshotCompletedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetNodeInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SendSnapshotCompletedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetNodeInfoRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1086,50 +1593,34 @@ func (m *SendSnapshotCompletedEvent) XXX_Marshal(b []byte, deterministic bool) (
 		return b[:n], nil
 	}
 }
-func (m *SendSnapshotCompletedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendSnapshotCompletedEvent.Merge(m, src)
+func (m *GetNodeInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeInfoRequest.Merge(m, src)
 }
-func (m *SendSnapshotCompletedEvent) XXX_Size() int {
+func (m *GetNodeInfoRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SendSnapshotCompletedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendSnapshotCompletedEvent.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_SendSnapshotCompletedEvent proto.InternalMessageInfo
-
-func (m *SendSnapshotCompletedEvent) GetIndex() Index {
-	if m != nil {
-		return m.Index
-	}
-	return 0
+func (m *GetNodeInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeInfoRequest.DiscardUnknown(m)
 }
 
-func (m *SendSnapshotCompletedEvent) GetTo() MemberID {
-	if m != nil {
-		return m.To
-	}
-	return 0
-}
+var xxx_messageInfo_GetNodeInfoRequest proto.InternalMessageInfo
 
-type SendSnapshotAbortedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	To          MemberID `protobuf:"varint,3,opt,name=to,proto3,casttype=MemberID" json:"to,omitempty"`
+type GetNodeInfoResponse struct {
+	Node NodeInfo `protobuf:"bytes,1,opt,name=node,proto3" json:"node"`
 }
 
-func (m *SendSnapshotAbortedEvent) Reset()         { *m = SendSnapshotAbortedEvent{} }
-func (m *SendSnapshotAbortedEvent) String() string { return proto.CompactTextString(m) }
-func (*SendSnapshotAbortedEvent) ProtoMessage()    {}
-func (*SendSnapshotAbortedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{18}
+func (m *GetNodeInfoResponse) Reset()         { *m = GetNodeInfoResponse{} }
+func (m *GetNodeInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeInfoResponse) ProtoMessage()    {}
+func (*GetNodeInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{29}
 }
-func (m *SendSnapshotAbortedEvent) XXX_Unmarshal(b []byte) error {
+func (m *GetNodeInfoResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SendSnapshotAbortedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *GetNodeInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SendSnapshotAbortedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_GetNodeInfoResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1139,50 +1630,44 @@ func (m *SendSnapshotAbortedEvent) XXX_Marshal(b []byte, deterministic bool) ([]
 		return b[:n], nil
 	}
 }
-func (m *SendSnapshotAbortedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SendSnapshotAbortedEvent.Merge(m, src)
+func (m *GetNodeInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeInfoResponse.Merge(m, src)
 }
-func (m *SendSnapshotAbortedEvent) XXX_Size() int {
+func (m *GetNodeInfoResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SendSnapshotAbortedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SendSnapshotAbortedEvent.DiscardUnknown(m)
+func (m *GetNodeInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeInfoResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SendSnapshotAbortedEvent proto.InternalMessageInfo
-
-func (m *SendSnapshotAbortedEvent) GetIndex() Index {
-	if m != nil {
-		return m.Index
-	}
-	return 0
-}
+var xxx_messageInfo_GetNodeInfoResponse proto.InternalMessageInfo
 
-func (m *SendSnapshotAbortedEvent) GetTo() MemberID {
+func (m *GetNodeInfoResponse) GetNode() NodeInfo {
 	if m != nil {
-		return m.To
+		return m.Node
 	}
-	return 0
+	return NodeInfo{}
 }
 
-type SnapshotReceivedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
-	From        MemberID `protobuf:"varint,3,opt,name=from,proto3,casttype=MemberID" json:"from,omitempty"`
+type NodeInfo struct {
+	// host_id is the identity of the node's Raft data directory, which changes when the data is lost
+	HostID string `protobuf:"bytes,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	// logs is the set of members for which a Raft log is stored on the node
+	Logs []MemberLogInfo `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs"`
 }
 
-func (m *SnapshotReceivedEvent) Reset()         { *m = SnapshotReceivedEvent{} }
-func (m *SnapshotReceivedEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotReceivedEvent) ProtoMessage()    {}
-func (*SnapshotReceivedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{19}
+func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
+func (m *NodeInfo) String() string { return proto.CompactTextString(m) }
+func (*NodeInfo) ProtoMessage()    {}
+func (*NodeInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{30}
 }
-func (m *SnapshotReceivedEvent) XXX_Unmarshal(b []byte) error {
+func (m *NodeInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotReceivedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NodeInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotReceivedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NodeInfo.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1192,49 +1677,49 @@ func (m *SnapshotReceivedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byt
 		return b[:n], nil
 	}
 }
-func (m *SnapshotReceivedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotReceivedEvent.Merge(m, src)
+func (m *NodeInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeInfo.Merge(m, src)
 }
-func (m *SnapshotReceivedEvent) XXX_Size() int {
+func (m *NodeInfo) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotReceivedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotReceivedEvent.DiscardUnknown(m)
+func (m *NodeInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotReceivedEvent proto.InternalMessageInfo
+var xxx_messageInfo_NodeInfo proto.InternalMessageInfo
 
-func (m *SnapshotReceivedEvent) GetIndex() Index {
+func (m *NodeInfo) GetHostID() string {
 	if m != nil {
-		return m.Index
+		return m.HostID
 	}
-	return 0
+	return ""
 }
 
-func (m *SnapshotReceivedEvent) GetFrom() MemberID {
+func (m *NodeInfo) GetLogs() []MemberLogInfo {
 	if m != nil {
-		return m.From
+		return m.Logs
 	}
-	return 0
+	return nil
 }
 
-type SnapshotRecoveredEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+type MemberLogInfo struct {
+	GroupID  GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	MemberID MemberID `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
 }
 
-func (m *SnapshotRecoveredEvent) Reset()         { *m = SnapshotRecoveredEvent{} }
-func (m *SnapshotRecoveredEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotRecoveredEvent) ProtoMessage()    {}
-func (*SnapshotRecoveredEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{20}
+func (m *MemberLogInfo) Reset()         { *m = MemberLogInfo{} }
+func (m *MemberLogInfo) String() string { return proto.CompactTextString(m) }
+func (*MemberLogInfo) ProtoMessage()    {}
+func (*MemberLogInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{31}
 }
-func (m *SnapshotRecoveredEvent) XXX_Unmarshal(b []byte) error {
+func (m *MemberLogInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotRecoveredEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MemberLogInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotRecoveredEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MemberLogInfo.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1244,42 +1729,52 @@ func (m *SnapshotRecoveredEvent) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *SnapshotRecoveredEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotRecoveredEvent.Merge(m, src)
+func (m *MemberLogInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberLogInfo.Merge(m, src)
 }
-func (m *SnapshotRecoveredEvent) XXX_Size() int {
+func (m *MemberLogInfo) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotRecoveredEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotRecoveredEvent.DiscardUnknown(m)
+func (m *MemberLogInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberLogInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotRecoveredEvent proto.InternalMessageInfo
+var xxx_messageInfo_MemberLogInfo proto.InternalMessageInfo
 
-func (m *SnapshotRecoveredEvent) GetIndex() Index {
+func (m *MemberLogInfo) GetGroupID() GroupID {
 	if m != nil {
-		return m.Index
+		return m.GroupID
 	}
 	return 0
 }
 
-type SnapshotCreatedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+func (m *MemberLogInfo) GetMemberID() MemberID {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
 }
 
-func (m *SnapshotCreatedEvent) Reset()         { *m = SnapshotCreatedEvent{} }
-func (m *SnapshotCreatedEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotCreatedEvent) ProtoMessage()    {}
-func (*SnapshotCreatedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{21}
+type ExportSnapshotRequest struct {
+	GroupID GroupID `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	// time is the point in time at which to cut the snapshot. If set, the snapshot reflects the entries proposed
+	// at or before the time and none proposed after it, so snapshots of different groups exported with the same
+	// time form a consistent cut. If the group's state at the time is no longer available, the export fails.
+	Time *time.Time `protobuf:"bytes,2,opt,name=time,proto3,stdtime" json:"time,omitempty"`
 }
-func (m *SnapshotCreatedEvent) XXX_Unmarshal(b []byte) error {
+
+func (m *ExportSnapshotRequest) Reset()         { *m = ExportSnapshotRequest{} }
+func (m *ExportSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportSnapshotRequest) ProtoMessage()    {}
+func (*ExportSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{32}
+}
+func (m *ExportSnapshotRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExportSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotCreatedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ExportSnapshotRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1289,42 +1784,51 @@ func (m *SnapshotCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *SnapshotCreatedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotCreatedEvent.Merge(m, src)
+func (m *ExportSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportSnapshotRequest.Merge(m, src)
 }
-func (m *SnapshotCreatedEvent) XXX_Size() int {
+func (m *ExportSnapshotRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotCreatedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotCreatedEvent.DiscardUnknown(m)
+func (m *ExportSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportSnapshotRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotCreatedEvent proto.InternalMessageInfo
+var xxx_messageInfo_ExportSnapshotRequest proto.InternalMessageInfo
 
-func (m *SnapshotCreatedEvent) GetIndex() Index {
+func (m *ExportSnapshotRequest) GetGroupID() GroupID {
 	if m != nil {
-		return m.Index
+		return m.GroupID
 	}
 	return 0
 }
 
-type SnapshotCompactedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+func (m *ExportSnapshotRequest) GetTime() *time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return nil
 }
 
-func (m *SnapshotCompactedEvent) Reset()         { *m = SnapshotCompactedEvent{} }
-func (m *SnapshotCompactedEvent) String() string { return proto.CompactTextString(m) }
-func (*SnapshotCompactedEvent) ProtoMessage()    {}
-func (*SnapshotCompactedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{22}
+type ExportSnapshotResponse struct {
+	Index Index  `protobuf:"varint,1,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// total_size is the size of the entire snapshot in bytes
+	TotalSize uint64 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 }
-func (m *SnapshotCompactedEvent) XXX_Unmarshal(b []byte) error {
+
+func (m *ExportSnapshotResponse) Reset()         { *m = ExportSnapshotResponse{} }
+func (m *ExportSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportSnapshotResponse) ProtoMessage()    {}
+func (*ExportSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{33}
+}
+func (m *ExportSnapshotResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *SnapshotCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExportSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_SnapshotCompactedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ExportSnapshotResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1334,42 +1838,60 @@ func (m *SnapshotCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *SnapshotCompactedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SnapshotCompactedEvent.Merge(m, src)
+func (m *ExportSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportSnapshotResponse.Merge(m, src)
 }
-func (m *SnapshotCompactedEvent) XXX_Size() int {
+func (m *ExportSnapshotResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *SnapshotCompactedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_SnapshotCompactedEvent.DiscardUnknown(m)
+func (m *ExportSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportSnapshotResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SnapshotCompactedEvent proto.InternalMessageInfo
+var xxx_messageInfo_ExportSnapshotResponse proto.InternalMessageInfo
 
-func (m *SnapshotCompactedEvent) GetIndex() Index {
+func (m *ExportSnapshotResponse) GetIndex() Index {
 	if m != nil {
 		return m.Index
 	}
 	return 0
 }
 
-type LogEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+func (m *ExportSnapshotResponse) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-func (m *LogEvent) Reset()         { *m = LogEvent{} }
-func (m *LogEvent) String() string { return proto.CompactTextString(m) }
-func (*LogEvent) ProtoMessage()    {}
-func (*LogEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{23}
+func (m *ExportSnapshotResponse) GetTotalSize() uint64 {
+	if m != nil {
+		return m.TotalSize
+	}
+	return 0
 }
-func (m *LogEvent) XXX_Unmarshal(b []byte) error {
+
+// ImportSnapshotRequest is a chunk of a snapshot with which to seed a member of a group. The snapshot is staged
+// on the node and seeds the member's state when the member is bootstrapped, so it must be imported before the
+// member is first started. Every initial member of the group must be seeded with the same snapshot.
+type ImportSnapshotRequest struct {
+	GroupID  GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	Data     []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	MemberID MemberID `protobuf:"varint,3,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
+}
+
+func (m *ImportSnapshotRequest) Reset()         { *m = ImportSnapshotRequest{} }
+func (m *ImportSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportSnapshotRequest) ProtoMessage()    {}
+func (*ImportSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{34}
+}
+func (m *ImportSnapshotRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ImportSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ImportSnapshotRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1379,42 +1901,54 @@ func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *LogEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogEvent.Merge(m, src)
+func (m *ImportSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportSnapshotRequest.Merge(m, src)
 }
-func (m *LogEvent) XXX_Size() int {
+func (m *ImportSnapshotRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogEvent.DiscardUnknown(m)
+func (m *ImportSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportSnapshotRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogEvent proto.InternalMessageInfo
+var xxx_messageInfo_ImportSnapshotRequest proto.InternalMessageInfo
 
-func (m *LogEvent) GetIndex() Index {
+func (m *ImportSnapshotRequest) GetGroupID() GroupID {
 	if m != nil {
-		return m.Index
+		return m.GroupID
 	}
 	return 0
 }
 
-type LogCompactedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+func (m *ImportSnapshotRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-func (m *LogCompactedEvent) Reset()         { *m = LogCompactedEvent{} }
-func (m *LogCompactedEvent) String() string { return proto.CompactTextString(m) }
-func (*LogCompactedEvent) ProtoMessage()    {}
-func (*LogCompactedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{24}
+func (m *ImportSnapshotRequest) GetMemberID() MemberID {
+	if m != nil {
+		return m.MemberID
+	}
+	return 0
 }
-func (m *LogCompactedEvent) XXX_Unmarshal(b []byte) error {
+
+type ImportSnapshotResponse struct {
+}
+
+func (m *ImportSnapshotResponse) Reset()         { *m = ImportSnapshotResponse{} }
+func (m *ImportSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportSnapshotResponse) ProtoMessage()    {}
+func (*ImportSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{35}
+}
+func (m *ImportSnapshotResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ImportSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogCompactedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ImportSnapshotResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1424,42 +1958,43 @@ func (m *LogCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *LogCompactedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogCompactedEvent.Merge(m, src)
+func (m *ImportSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportSnapshotResponse.Merge(m, src)
 }
-func (m *LogCompactedEvent) XXX_Size() int {
+func (m *ImportSnapshotResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogCompactedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogCompactedEvent.DiscardUnknown(m)
+func (m *ImportSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportSnapshotResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogCompactedEvent proto.InternalMessageInfo
-
-func (m *LogCompactedEvent) GetIndex() Index {
-	if m != nil {
-		return m.Index
-	}
-	return 0
-}
+var xxx_messageInfo_ImportSnapshotResponse proto.InternalMessageInfo
 
-type LogDBCompactedEvent struct {
-	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
-	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+type WatchRequest struct {
+	// group_ids filters the events to the given groups. Events not related to a group, such as connection
+	// events, are not filtered by group. If empty, events for all groups are sent.
+	GroupIDs []GroupID `protobuf:"varint,1,rep,packed,name=group_ids,json=groupIds,proto3,casttype=GroupID" json:"group_ids,omitempty"`
+	// event_types filters the events to the given types. If empty, events of all types are sent.
+	EventTypes []EventType `protobuf:"varint,2,rep,packed,name=event_types,json=eventTypes,proto3,enum=atomix.consensus.node.v1.EventType" json:"event_types,omitempty"`
+	// from_sequence resumes a watch following the event with the given sequence number. If the sequence number
+	// is zero, the current state of each group is sent before new events instead. If the node no longer buffers
+	// the events following the sequence number, a ResyncEvent is sent followed by the current state of each
+	// group.
+	FromSequence uint64 `protobuf:"varint,3,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
 }
 
-func (m *LogDBCompactedEvent) Reset()         { *m = LogDBCompactedEvent{} }
-func (m *LogDBCompactedEvent) String() string { return proto.CompactTextString(m) }
-func (*LogDBCompactedEvent) ProtoMessage()    {}
-func (*LogDBCompactedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{25}
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{36}
 }
-func (m *LogDBCompactedEvent) XXX_Unmarshal(b []byte) error {
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LogDBCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LogDBCompactedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1469,41 +2004,81 @@ func (m *LogDBCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LogDBCompactedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogDBCompactedEvent.Merge(m, src)
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
 }
-func (m *LogDBCompactedEvent) XXX_Size() int {
+func (m *WatchRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LogDBCompactedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogDBCompactedEvent.DiscardUnknown(m)
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogDBCompactedEvent proto.InternalMessageInfo
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
 
-func (m *LogDBCompactedEvent) GetIndex() Index {
+func (m *WatchRequest) GetGroupIDs() []GroupID {
 	if m != nil {
-		return m.Index
+		return m.GroupIDs
 	}
-	return 0
+	return nil
 }
 
-type ConnectionEstablishedEvent struct {
-	ConnectionInfo `protobuf:"bytes,1,opt,name=info,proto3,embedded=info" json:"info"`
+func (m *WatchRequest) GetEventTypes() []EventType {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
 }
 
-func (m *ConnectionEstablishedEvent) Reset()         { *m = ConnectionEstablishedEvent{} }
-func (m *ConnectionEstablishedEvent) String() string { return proto.CompactTextString(m) }
-func (*ConnectionEstablishedEvent) ProtoMessage()    {}
-func (*ConnectionEstablishedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{26}
-}
-func (m *ConnectionEstablishedEvent) XXX_Unmarshal(b []byte) error {
+func (m *WatchRequest) GetFromSequence() uint64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+type Event struct {
+	Timestamp time.Time `protobuf:"bytes,1,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	// sequence is the sequence number of the event, which increases monotonically for the events published by
+	// the node's host while it's running. Sequence numbers are not ordered across restarts of the node, and
+	// a watch cannot be resumed from an event published before the node was restarted. Events sent to describe
+	// the current state of a group carry the sequence number of the last event published before they were sent.
+	Sequence uint64 `protobuf:"varint,16,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// host_id is the identity of the node that published the event
+	HostID string `protobuf:"bytes,17,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//	*Event_MemberReady
+	//	*Event_LeaderUpdated
+	//	*Event_MembershipChanged
+	//	*Event_SendSnapshotStarted
+	//	*Event_SendSnapshotCompleted
+	//	*Event_SendSnapshotAborted
+	//	*Event_SnapshotReceived
+	//	*Event_SnapshotRecovered
+	//	*Event_SnapshotCreated
+	//	*Event_SnapshotCompacted
+	//	*Event_LogCompacted
+	//	*Event_LogdbCompacted
+	//	*Event_ConnectionEstablished
+	//	*Event_ConnectionFailed
+	//	*Event_Resync
+	//	*Event_MemberUnloaded
+	//	*Event_HostShuttingDown
+	Event isEvent_Event `protobuf_oneof:"event"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{37}
+}
+func (m *Event) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ConnectionEstablishedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ConnectionEstablishedEvent.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Event.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1513,2300 +2088,8304 @@ func (m *ConnectionEstablishedEvent) XXX_Marshal(b []byte, deterministic bool) (
 		return b[:n], nil
 	}
 }
-func (m *ConnectionEstablishedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectionEstablishedEvent.Merge(m, src)
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
 }
-func (m *ConnectionEstablishedEvent) XXX_Size() int {
+func (m *Event) XXX_Size() int {
 	return m.Size()
 }
-func (m *ConnectionEstablishedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectionEstablishedEvent.DiscardUnknown(m)
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ConnectionEstablishedEvent proto.InternalMessageInfo
+var xxx_messageInfo_Event proto.InternalMessageInfo
 
-type ConnectionFailedEvent struct {
-	ConnectionInfo `protobuf:"bytes,1,opt,name=info,proto3,embedded=info" json:"info"`
+type isEvent_Event interface {
+	isEvent_Event()
+	MarshalTo([]byte) (int, error)
+	Size() int
 }
 
-func (m *ConnectionFailedEvent) Reset()         { *m = ConnectionFailedEvent{} }
-func (m *ConnectionFailedEvent) String() string { return proto.CompactTextString(m) }
-func (*ConnectionFailedEvent) ProtoMessage()    {}
-func (*ConnectionFailedEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a7226d1cf45660e1, []int{27}
+type Event_MemberReady struct {
+	MemberReady *MemberReadyEvent `protobuf:"bytes,2,opt,name=member_ready,json=memberReady,proto3,oneof" json:"member_ready,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+type Event_LeaderUpdated struct {
+	LeaderUpdated *LeaderUpdatedEvent `protobuf:"bytes,3,opt,name=leader_updated,json=leaderUpdated,proto3,oneof" json:"leader_updated,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ConnectionFailedEvent.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
+type Event_MembershipChanged struct {
+	MembershipChanged *MembershipChangedEvent `protobuf:"bytes,4,opt,name=membership_changed,json=membershipChanged,proto3,oneof" json:"membership_changed,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ConnectionFailedEvent.Merge(m, src)
+type Event_SendSnapshotStarted struct {
+	SendSnapshotStarted *SendSnapshotStartedEvent `protobuf:"bytes,5,opt,name=send_snapshot_started,json=sendSnapshotStarted,proto3,oneof" json:"send_snapshot_started,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_Size() int {
-	return m.Size()
+type Event_SendSnapshotCompleted struct {
+	SendSnapshotCompleted *SendSnapshotCompletedEvent `protobuf:"bytes,6,opt,name=send_snapshot_completed,json=sendSnapshotCompleted,proto3,oneof" json:"send_snapshot_completed,omitempty"`
 }
-func (m *ConnectionFailedEvent) XXX_DiscardUnknown() {
-	xxx_messageInfo_ConnectionFailedEvent.DiscardUnknown(m)
+type Event_SendSnapshotAborted struct {
+	SendSnapshotAborted *SendSnapshotAbortedEvent `protobuf:"bytes,7,opt,name=send_snapshot_aborted,json=sendSnapshotAborted,proto3,oneof" json:"send_snapshot_aborted,omitempty"`
 }
-
-var xxx_messageInfo_ConnectionFailedEvent proto.InternalMessageInfo
-
-func init() {
-	proto.RegisterEnum("atomix.consensus.node.v1.MemberRole", MemberRole_name, MemberRole_value)
-	proto.RegisterType((*GroupConfig)(nil), "atomix.consensus.node.v1.GroupConfig")
-	proto.RegisterType((*MemberConfig)(nil), "atomix.consensus.node.v1.MemberConfig")
-	proto.RegisterType((*RaftProposal)(nil), "atomix.consensus.node.v1.RaftProposal")
-	proto.RegisterType((*BootstrapRequest)(nil), "atomix.consensus.node.v1.BootstrapRequest")
-	proto.RegisterType((*BootstrapResponse)(nil), "atomix.consensus.node.v1.BootstrapResponse")
-	proto.RegisterType((*JoinRequest)(nil), "atomix.consensus.node.v1.JoinRequest")
-	proto.RegisterType((*JoinResponse)(nil), "atomix.consensus.node.v1.JoinResponse")
-	proto.RegisterType((*LeaveRequest)(nil), "atomix.consensus.node.v1.LeaveRequest")
-	proto.RegisterType((*LeaveResponse)(nil), "atomix.consensus.node.v1.LeaveResponse")
-	proto.RegisterType((*WatchRequest)(nil), "atomix.consensus.node.v1.WatchRequest")
-	proto.RegisterType((*Event)(nil), "atomix.consensus.node.v1.Event")
-	proto.RegisterType((*ConnectionInfo)(nil), "atomix.consensus.node.v1.ConnectionInfo")
-	proto.RegisterType((*MemberEvent)(nil), "atomix.consensus.node.v1.MemberEvent")
-	proto.RegisterType((*MemberReadyEvent)(nil), "atomix.consensus.node.v1.MemberReadyEvent")
-	proto.RegisterType((*MembershipChangedEvent)(nil), "atomix.consensus.node.v1.MembershipChangedEvent")
-	proto.RegisterType((*LeaderUpdatedEvent)(nil), "atomix.consensus.node.v1.LeaderUpdatedEvent")
-	proto.RegisterType((*SendSnapshotStartedEvent)(nil), "atomix.consensus.node.v1.SendSnapshotStartedEvent")
-	proto.RegisterType((*SendSnapshotCompletedEvent)(nil), "atomix.consensus.node.v1.SendSnapshotCompletedEvent")
-	proto.RegisterType((*SendSnapshotAbortedEvent)(nil), "atomix.consensus.node.v1.SendSnapshotAbortedEvent")
-	proto.RegisterType((*SnapshotReceivedEvent)(nil), "atomix.consensus.node.v1.SnapshotReceivedEvent")
-	proto.RegisterType((*SnapshotRecoveredEvent)(nil), "atomix.consensus.node.v1.SnapshotRecoveredEvent")
-	proto.RegisterType((*SnapshotCreatedEvent)(nil), "atomix.consensus.node.v1.SnapshotCreatedEvent")
-	proto.RegisterType((*SnapshotCompactedEvent)(nil), "atomix.consensus.node.v1.SnapshotCompactedEvent")
-	proto.RegisterType((*LogEvent)(nil), "atomix.consensus.node.v1.LogEvent")
-	proto.RegisterType((*LogCompactedEvent)(nil), "atomix.consensus.node.v1.LogCompactedEvent")
-	proto.RegisterType((*LogDBCompactedEvent)(nil), "atomix.consensus.node.v1.LogDBCompactedEvent")
-	proto.RegisterType((*ConnectionEstablishedEvent)(nil), "atomix.consensus.node.v1.ConnectionEstablishedEvent")
-	proto.RegisterType((*ConnectionFailedEvent)(nil), "atomix.consensus.node.v1.ConnectionFailedEvent")
+type Event_SnapshotReceived struct {
+	SnapshotReceived *SnapshotReceivedEvent `protobuf:"bytes,8,opt,name=snapshot_received,json=snapshotReceived,proto3,oneof" json:"snapshot_received,omitempty"`
 }
-
-func init() { proto.RegisterFile("consensus/protocol.proto", fileDescriptor_a7226d1cf45660e1) }
-
-var fileDescriptor_a7226d1cf45660e1 = []byte{
-	// 1297 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0x4d, 0x6f, 0xdb, 0x46,
-	0x13, 0x36, 0x1d, 0xca, 0x92, 0x86, 0xb4, 0x2c, 0x6f, 0x62, 0xbf, 0x84, 0x10, 0x58, 0x06, 0x91,
-	0x37, 0x35, 0x92, 0x56, 0x4e, 0xd4, 0x16, 0xe8, 0xb1, 0xa1, 0x23, 0xa7, 0x6e, 0x13, 0x27, 0x58,
-	0xc5, 0x4d, 0x80, 0x00, 0x15, 0x68, 0x72, 0x25, 0x11, 0x20, 0xb9, 0x2a, 0x97, 0x72, 0xd3, 0x43,
-	0xd1, 0xa2, 0xbf, 0x20, 0x7f, 0xa1, 0x4d, 0x7f, 0x4c, 0x8e, 0x39, 0xf6, 0xa4, 0x16, 0xce, 0x2f,
-	0xe8, 0xb1, 0x3e, 0x15, 0x5c, 0x7e, 0x88, 0x96, 0xa8, 0x8f, 0x00, 0x82, 0x90, 0xdb, 0x6a, 0x67,
-	0x9e, 0xe7, 0x99, 0xe1, 0x7e, 0xcc, 0x8e, 0x40, 0x31, 0xa8, 0xcb, 0x88, 0xcb, 0xfa, 0x6c, 0xbf,
-	0xe7, 0x51, 0x9f, 0x1a, 0xd4, 0xae, 0xf1, 0x01, 0x52, 0x74, 0x9f, 0x3a, 0xd6, 0xcb, 0x5a, 0xe2,
-	0x50, 0x73, 0xa9, 0x49, 0x6a, 0x67, 0x77, 0x2b, 0xd5, 0x0e, 0xa5, 0x1d, 0x9b, 0x84, 0x80, 0xd3,
-	0x7e, 0x7b, 0xdf, 0xb7, 0x1c, 0xc2, 0x7c, 0xdd, 0xe9, 0x85, 0xd0, 0xca, 0xb5, 0x0e, 0xed, 0x50,
-	0x3e, 0xdc, 0x0f, 0x46, 0xe1, 0xac, 0xfa, 0xaf, 0x00, 0xd2, 0x03, 0x8f, 0xf6, 0x7b, 0x07, 0xd4,
-	0x6d, 0x5b, 0x1d, 0x74, 0x17, 0x0a, 0x9d, 0xe0, 0x67, 0xcb, 0x32, 0x15, 0x61, 0x57, 0xd8, 0x5b,
-	0xd7, 0xb6, 0xcf, 0x07, 0xd5, 0x3c, 0x77, 0x39, 0xba, 0x7f, 0x31, 0x1c, 0xe2, 0x3c, 0xf7, 0x3b,
-	0x32, 0xd1, 0xe7, 0x50, 0x74, 0x88, 0x73, 0x4a, 0xbc, 0x00, 0xb3, 0xca, 0x31, 0xca, 0xf9, 0xa0,
-	0x5a, 0x78, 0xc4, 0x27, 0x39, 0x28, 0x19, 0xe3, 0x42, 0xe8, 0x7a, 0x64, 0xa2, 0x2f, 0x40, 0xf4,
-	0xa8, 0x4d, 0x94, 0x2b, 0xbb, 0xc2, 0x5e, 0xa9, 0x7e, 0xa3, 0x36, 0x29, 0xb3, 0x5a, 0x88, 0xc5,
-	0xd4, 0x26, 0x98, 0x23, 0xd0, 0x21, 0xe4, 0x43, 0x16, 0xa6, 0x88, 0xbb, 0x57, 0xf6, 0xa4, 0xfa,
-	0xcd, 0x59, 0xe0, 0x30, 0x39, 0x4d, 0x7c, 0x33, 0xa8, 0xae, 0xe0, 0x18, 0xac, 0x3a, 0x20, 0xa7,
-	0xcd, 0x97, 0x13, 0x11, 0xe6, 0x4e, 0x04, 0x81, 0xd8, 0xa5, 0xcc, 0xe7, 0xa9, 0x17, 0x31, 0x1f,
-	0x07, 0x73, 0x3d, 0xea, 0xf9, 0x3c, 0xb9, 0x1c, 0xe6, 0x63, 0xd5, 0x07, 0x19, 0xeb, 0x6d, 0xff,
-	0x89, 0x47, 0x7b, 0x94, 0xe9, 0x36, 0xba, 0x0e, 0xa2, 0x4f, 0x3c, 0x87, 0x2b, 0x89, 0x5a, 0xe1,
-	0x62, 0x50, 0x15, 0x9f, 0x12, 0xcf, 0xc1, 0x7c, 0x16, 0xd5, 0x41, 0x66, 0xe4, 0xfb, 0x3e, 0x71,
-	0x0d, 0xd2, 0x72, 0xfb, 0x0e, 0x67, 0x17, 0xb5, 0x8d, 0x8b, 0x41, 0x55, 0x6a, 0x46, 0xf3, 0xc7,
-	0x7d, 0x07, 0x4b, 0x6c, 0xf8, 0x23, 0x50, 0x35, 0x75, 0x5f, 0xe7, 0xaa, 0x32, 0xe6, 0x63, 0xf5,
-	0x04, 0xca, 0x1a, 0xa5, 0x3e, 0xf3, 0x3d, 0xbd, 0x87, 0x03, 0x5f, 0xe6, 0xa3, 0x7b, 0x90, 0xe3,
-	0x8b, 0xc7, 0xa5, 0xa5, 0xfa, 0xff, 0x27, 0x7f, 0xbe, 0xd4, 0xd6, 0x88, 0xbe, 0x5e, 0x88, 0x54,
-	0xaf, 0xc2, 0x66, 0x8a, 0x96, 0xf5, 0x02, 0xa4, 0xfa, 0x04, 0xa4, 0xaf, 0xa9, 0xe5, 0x2e, 0x50,
-	0xa6, 0x04, 0x72, 0xc8, 0x18, 0x29, 0xdc, 0x03, 0xf9, 0x21, 0xd1, 0xcf, 0x48, 0x2c, 0xf1, 0xfe,
-	0xdb, 0x55, 0xdd, 0x80, 0xf5, 0x88, 0x22, 0xe2, 0x2c, 0x81, 0xfc, 0x4c, 0xf7, 0x8d, 0x6e, 0xc4,
-	0xa9, 0xbe, 0x96, 0x20, 0xd7, 0x38, 0x23, 0xae, 0x8f, 0x34, 0x28, 0x26, 0xa7, 0x28, 0x4a, 0xa2,
-	0x52, 0x0b, 0xcf, 0x59, 0x2d, 0x3e, 0x67, 0xb5, 0xa7, 0xb1, 0x87, 0x56, 0x08, 0x22, 0x7f, 0xf5,
-	0x57, 0x55, 0xc0, 0x43, 0x18, 0x7a, 0x0c, 0x72, 0xb4, 0xa9, 0x3c, 0xa2, 0x9b, 0x3f, 0xf2, 0x75,
-	0x94, 0xea, 0xb7, 0x66, 0x6e, 0xf7, 0xc0, 0x99, 0x47, 0xf1, 0xd5, 0x0a, 0x96, 0x9c, 0xe1, 0x1c,
-	0x3a, 0x81, 0x92, 0x4d, 0x74, 0x93, 0x78, 0xad, 0x7e, 0xcf, 0xd4, 0x7d, 0x62, 0xf2, 0xe5, 0x96,
-	0xea, 0x1f, 0x4f, 0xa6, 0x7c, 0xc8, 0xfd, 0x4f, 0x42, 0xf7, 0x98, 0x74, 0xdd, 0x4e, 0xcf, 0x22,
-	0x1d, 0x50, 0x74, 0x2e, 0xba, 0x56, 0xaf, 0x65, 0x74, 0x75, 0xb7, 0x43, 0x4c, 0x45, 0xe4, 0xd4,
-	0x77, 0x66, 0x45, 0x1b, 0x60, 0x0e, 0x42, 0x48, 0x4c, 0xbf, 0xe9, 0x8c, 0x5a, 0x50, 0x17, 0xb6,
-	0x18, 0x71, 0xcd, 0x16, 0x73, 0xf5, 0x1e, 0xeb, 0x52, 0xbf, 0xc5, 0x7c, 0xdd, 0x0b, 0x12, 0xc8,
-	0x71, 0x95, 0xfa, 0x64, 0x95, 0x26, 0x71, 0xcd, 0x66, 0x84, 0x6a, 0x86, 0xa0, 0x58, 0xe7, 0x2a,
-	0x1b, 0xb7, 0x21, 0x17, 0xfe, 0x77, 0x59, 0xc9, 0xa0, 0x4e, 0xcf, 0x26, 0x81, 0xd6, 0x1a, 0xd7,
-	0xfa, 0x6c, 0x3e, 0xad, 0x83, 0x18, 0x16, 0xab, 0x6d, 0xb1, 0x2c, 0xeb, 0x78, 0x66, 0xfa, 0x29,
-	0xe5, 0x99, 0xe5, 0xdf, 0x27, 0xb3, 0x7b, 0x21, 0x28, 0x33, 0xb3, 0xc8, 0x86, 0xbe, 0x83, 0xcd,
-	0x44, 0xc4, 0x23, 0x06, 0xb1, 0xce, 0x88, 0xa9, 0x14, 0xb8, 0xca, 0xfe, 0x14, 0x95, 0x08, 0x82,
-	0x23, 0x44, 0x2c, 0x51, 0x66, 0x23, 0x86, 0x60, 0x1b, 0xa4, 0xf9, 0xe9, 0x19, 0xf1, 0x88, 0xa9,
-	0x14, 0x67, 0x6d, 0x83, 0x94, 0x40, 0x08, 0x49, 0xb6, 0x01, 0x1b, 0xb5, 0xa0, 0x17, 0x50, 0x1e,
-	0xae, 0x8b, 0x47, 0xf8, 0x16, 0x06, 0x2e, 0x50, 0x9b, 0x2d, 0x70, 0x10, 0x02, 0x62, 0xfa, 0x0d,
-	0x76, 0x79, 0xfe, 0x52, 0xfc, 0xc1, 0xa2, 0xeb, 0x46, 0x40, 0x2f, 0xcd, 0x1b, 0xff, 0x41, 0x0c,
-	0x19, 0x8b, 0x3f, 0xb1, 0x20, 0x0c, 0xeb, 0x36, 0xed, 0xa4, 0xd8, 0x65, 0xce, 0x7e, 0x7b, 0xca,
-	0xf9, 0xa3, 0x9d, 0x31, 0x62, 0xd9, 0x4e, 0x4d, 0xa2, 0xe7, 0xb0, 0x61, 0xd3, 0x8e, 0x79, 0x9a,
-	0x62, 0x5d, 0xe7, 0xac, 0x9f, 0x4c, 0x65, 0xbd, 0xaf, 0x8d, 0xf1, 0x96, 0x38, 0xcf, 0x90, 0xd9,
-	0x81, 0x6d, 0x83, 0xba, 0x2e, 0x31, 0x7c, 0x8b, 0xba, 0xad, 0xe0, 0x52, 0x3a, 0xb5, 0x2d, 0xd6,
-	0x25, 0xa6, 0x52, 0x9a, 0x75, 0x12, 0x0e, 0x12, 0x5c, 0x63, 0x08, 0x4b, 0x4e, 0x82, 0x91, 0x65,
-	0x0d, 0xf6, 0x67, 0x4a, 0xae, 0xad, 0x5b, 0x36, 0x31, 0x95, 0x8d, 0x59, 0xfb, 0x73, 0xa8, 0x74,
-	0xc8, 0x11, 0xc9, 0xfe, 0x34, 0x46, 0x0c, 0x5a, 0x1e, 0x72, 0x24, 0x30, 0xaa, 0x87, 0x50, 0x1a,
-	0xa2, 0x8e, 0xdc, 0x36, 0x45, 0x0a, 0xe4, 0x75, 0xd3, 0xf4, 0x08, 0x63, 0xfc, 0xae, 0x2e, 0xe2,
-	0xf8, 0x27, 0xaa, 0x40, 0x21, 0x5e, 0x46, 0x7e, 0xff, 0x16, 0x70, 0xf2, 0x5b, 0xfd, 0x01, 0xa4,
-	0xf0, 0x0e, 0x0b, 0xaf, 0xfc, 0x45, 0xbc, 0x7f, 0xc4, 0x79, 0x9e, 0x0d, 0xea, 0x0b, 0x28, 0x8f,
-	0x5e, 0xf5, 0xe8, 0x01, 0xac, 0x85, 0xf6, 0xd9, 0x25, 0x33, 0x15, 0x74, 0x58, 0x78, 0xde, 0x0e,
-	0xaa, 0x02, 0x8e, 0xe0, 0xaa, 0x0e, 0xdb, 0xd9, 0x37, 0xf3, 0xe2, 0x24, 0x7e, 0x13, 0x00, 0x8d,
-	0x17, 0x96, 0x85, 0xf1, 0x27, 0xcf, 0xa3, 0xd5, 0xcc, 0xe7, 0xd1, 0x0d, 0x58, 0x0b, 0xeb, 0x17,
-	0xaf, 0x7e, 0xa2, 0x26, 0x5f, 0xfa, 0xca, 0x91, 0x4d, 0xfd, 0x5d, 0x00, 0x65, 0x52, 0xed, 0x58,
-	0x5c, 0xa4, 0x55, 0xc8, 0x59, 0xae, 0x49, 0x5e, 0x46, 0xa1, 0x16, 0x2f, 0x06, 0xd5, 0xdc, 0x51,
-	0x30, 0x81, 0xc3, 0x79, 0x74, 0x1d, 0x56, 0x7d, 0x9a, 0x19, 0xe8, 0xaa, 0x4f, 0xd5, 0x3f, 0x04,
-	0xa8, 0x4c, 0x2e, 0x3a, 0x1f, 0x4c, 0x98, 0xa3, 0xdf, 0x32, 0x5d, 0xad, 0x3e, 0x98, 0x20, 0x5f,
-	0x0b, 0xb0, 0x95, 0x59, 0xec, 0x96, 0x18, 0xe1, 0x2e, 0x88, 0x6d, 0x8f, 0x3a, 0x99, 0x31, 0x72,
-	0x8b, 0xfa, 0xab, 0x00, 0xdb, 0xd9, 0x15, 0x73, 0x79, 0x61, 0xaa, 0xbf, 0x08, 0x70, 0x2d, 0xab,
-	0xaa, 0x2e, 0x31, 0x84, 0xf4, 0x77, 0xb8, 0x5c, 0xc8, 0x96, 0x18, 0x84, 0x0f, 0x85, 0x87, 0xb4,
-	0xb3, 0x6c, 0xd5, 0x9f, 0x60, 0x73, 0xec, 0x55, 0xb0, 0x44, 0xf9, 0x9f, 0xe1, 0x6a, 0xc6, 0xf3,
-	0x61, 0x89, 0x01, 0x98, 0x50, 0x99, 0xfc, 0xbc, 0x40, 0x87, 0x20, 0x5a, 0x6e, 0x9b, 0x46, 0x51,
-	0xec, 0xcd, 0xf3, 0x70, 0x08, 0x9e, 0x00, 0xa9, 0x40, 0x38, 0x5e, 0x6d, 0xc1, 0x56, 0xe6, 0xd3,
-	0x62, 0x51, 0x02, 0xb7, 0xbe, 0x04, 0x18, 0xfe, 0x3d, 0x81, 0x24, 0xc8, 0x9f, 0x1c, 0x7f, 0x73,
-	0xfc, 0xf8, 0xd9, 0x71, 0x79, 0x05, 0x01, 0xac, 0x3d, 0x6a, 0x3c, 0xd2, 0x1a, 0xb8, 0x2c, 0x20,
-	0x19, 0x0a, 0x8f, 0xb5, 0x66, 0x03, 0x7f, 0xdb, 0xc0, 0xe5, 0xd5, 0xc0, 0xed, 0xd9, 0xd1, 0xd3,
-	0xe3, 0x46, 0xb3, 0x59, 0xbe, 0x52, 0xff, 0x67, 0x15, 0xc4, 0x63, 0x6a, 0x12, 0x64, 0x42, 0x31,
-	0xe9, 0xa8, 0xd1, 0x94, 0xfe, 0x70, 0xb4, 0x9b, 0xaf, 0xdc, 0x9e, 0xcb, 0x37, 0x6c, 0x76, 0xd1,
-	0x09, 0x88, 0x41, 0x43, 0x8d, 0xa6, 0xac, 0x6c, 0xaa, 0x85, 0xaf, 0xdc, 0x9c, 0xe5, 0x16, 0xd1,
-	0x3e, 0x87, 0x1c, 0x6f, 0xaa, 0xd1, 0xcd, 0xa9, 0x5d, 0x68, 0xd2, 0xb8, 0x57, 0x3e, 0x9a, 0xe9,
-	0x17, 0x31, 0x63, 0xc8, 0xf1, 0xee, 0x7c, 0x1a, 0x73, 0xba, 0x7d, 0xaf, 0x54, 0x27, 0xfb, 0xf1,
-	0xb5, 0xbf, 0x23, 0x68, 0xca, 0x9b, 0xf3, 0x1d, 0xe1, 0xed, 0xf9, 0x8e, 0xf0, 0xf7, 0xf9, 0x8e,
-	0xf0, 0xea, 0xdd, 0xce, 0xca, 0xdb, 0x77, 0x3b, 0x2b, 0x7f, 0xbe, 0xdb, 0x59, 0x39, 0x5d, 0xe3,
-	0x6d, 0xfd, 0xa7, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff, 0xfa, 0x10, 0x6c, 0x77, 0x82, 0x13, 0x00,
-	0x00,
+type Event_SnapshotRecovered struct {
+	SnapshotRecovered *SnapshotRecoveredEvent `protobuf:"bytes,9,opt,name=snapshot_recovered,json=snapshotRecovered,proto3,oneof" json:"snapshot_recovered,omitempty"`
+}
+type Event_SnapshotCreated struct {
+	SnapshotCreated *SnapshotCreatedEvent `protobuf:"bytes,10,opt,name=snapshot_created,json=snapshotCreated,proto3,oneof" json:"snapshot_created,omitempty"`
+}
+type Event_SnapshotCompacted struct {
+	SnapshotCompacted *SnapshotCompactedEvent `protobuf:"bytes,11,opt,name=snapshot_compacted,json=snapshotCompacted,proto3,oneof" json:"snapshot_compacted,omitempty"`
+}
+type Event_LogCompacted struct {
+	LogCompacted *LogCompactedEvent `protobuf:"bytes,12,opt,name=log_compacted,json=logCompacted,proto3,oneof" json:"log_compacted,omitempty"`
+}
+type Event_LogdbCompacted struct {
+	LogdbCompacted *LogDBCompactedEvent `protobuf:"bytes,13,opt,name=logdb_compacted,json=logdbCompacted,proto3,oneof" json:"logdb_compacted,omitempty"`
+}
+type Event_ConnectionEstablished struct {
+	ConnectionEstablished *ConnectionEstablishedEvent `protobuf:"bytes,14,opt,name=connection_established,json=connectionEstablished,proto3,oneof" json:"connection_established,omitempty"`
+}
+type Event_ConnectionFailed struct {
+	ConnectionFailed *ConnectionFailedEvent `protobuf:"bytes,15,opt,name=connection_failed,json=connectionFailed,proto3,oneof" json:"connection_failed,omitempty"`
+}
+type Event_Resync struct {
+	Resync *ResyncEvent `protobuf:"bytes,18,opt,name=resync,proto3,oneof" json:"resync,omitempty"`
+}
+type Event_MemberUnloaded struct {
+	MemberUnloaded *MemberUnloadedEvent `protobuf:"bytes,19,opt,name=member_unloaded,json=memberUnloaded,proto3,oneof" json:"member_unloaded,omitempty"`
+}
+type Event_HostShuttingDown struct {
+	HostShuttingDown *HostShuttingDownEvent `protobuf:"bytes,20,opt,name=host_shutting_down,json=hostShuttingDown,proto3,oneof" json:"host_shutting_down,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (*Event_MemberReady) isEvent_Event()           {}
+func (*Event_LeaderUpdated) isEvent_Event()         {}
+func (*Event_MembershipChanged) isEvent_Event()     {}
+func (*Event_SendSnapshotStarted) isEvent_Event()   {}
+func (*Event_SendSnapshotCompleted) isEvent_Event() {}
+func (*Event_SendSnapshotAborted) isEvent_Event()   {}
+func (*Event_SnapshotReceived) isEvent_Event()      {}
+func (*Event_SnapshotRecovered) isEvent_Event()     {}
+func (*Event_SnapshotCreated) isEvent_Event()       {}
+func (*Event_SnapshotCompacted) isEvent_Event()     {}
+func (*Event_LogCompacted) isEvent_Event()          {}
+func (*Event_LogdbCompacted) isEvent_Event()        {}
+func (*Event_ConnectionEstablished) isEvent_Event() {}
+func (*Event_ConnectionFailed) isEvent_Event()      {}
+func (*Event_Resync) isEvent_Event()                {}
+func (*Event_MemberUnloaded) isEvent_Event()        {}
+func (*Event_HostShuttingDown) isEvent_Event()      {}
 
-// NodeClient is the client API for Node service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type NodeClient interface {
-	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
-	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
-	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
-	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Node_WatchClient, error)
+func (m *Event) GetEvent() isEvent_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
 }
 
-type nodeClient struct {
-	cc *grpc.ClientConn
+func (m *Event) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
 }
 
-func NewNodeClient(cc *grpc.ClientConn) NodeClient {
-	return &nodeClient{cc}
+func (m *Event) GetSequence() uint64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
 }
 
-func (c *nodeClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
-	out := new(BootstrapResponse)
-	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/Bootstrap", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Event) GetHostID() string {
+	if m != nil {
+		return m.HostID
 	}
-	return out, nil
+	return ""
 }
 
-func (c *nodeClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
-	out := new(JoinResponse)
-	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/Join", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Event) GetMemberReady() *MemberReadyEvent {
+	if x, ok := m.GetEvent().(*Event_MemberReady); ok {
+		return x.MemberReady
 	}
-	return out, nil
+	return nil
 }
 
-func (c *nodeClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
-	out := new(LeaveResponse)
-	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/Leave", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *Event) GetLeaderUpdated() *LeaderUpdatedEvent {
+	if x, ok := m.GetEvent().(*Event_LeaderUpdated); ok {
+		return x.LeaderUpdated
 	}
-	return out, nil
+	return nil
 }
 
-func (c *nodeClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Node_WatchClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[0], "/atomix.consensus.node.v1.Node/Watch", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &nodeWatchClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *Event) GetMembershipChanged() *MembershipChangedEvent {
+	if x, ok := m.GetEvent().(*Event_MembershipChanged); ok {
+		return x.MembershipChanged
 	}
-	return x, nil
+	return nil
 }
 
-type Node_WatchClient interface {
-	Recv() (*Event, error)
-	grpc.ClientStream
+func (m *Event) GetSendSnapshotStarted() *SendSnapshotStartedEvent {
+	if x, ok := m.GetEvent().(*Event_SendSnapshotStarted); ok {
+		return x.SendSnapshotStarted
+	}
+	return nil
 }
 
-type nodeWatchClient struct {
-	grpc.ClientStream
+func (m *Event) GetSendSnapshotCompleted() *SendSnapshotCompletedEvent {
+	if x, ok := m.GetEvent().(*Event_SendSnapshotCompleted); ok {
+		return x.SendSnapshotCompleted
+	}
+	return nil
 }
 
-func (x *nodeWatchClient) Recv() (*Event, error) {
-	m := new(Event)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *Event) GetSendSnapshotAborted() *SendSnapshotAbortedEvent {
+	if x, ok := m.GetEvent().(*Event_SendSnapshotAborted); ok {
+		return x.SendSnapshotAborted
 	}
-	return m, nil
-}
-
-// NodeServer is the server API for Node service.
-type NodeServer interface {
-	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
-	Join(context.Context, *JoinRequest) (*JoinResponse, error)
-	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
-	Watch(*WatchRequest, Node_WatchServer) error
+	return nil
 }
 
-// UnimplementedNodeServer can be embedded to have forward compatible implementations.
-type UnimplementedNodeServer struct {
+func (m *Event) GetSnapshotReceived() *SnapshotReceivedEvent {
+	if x, ok := m.GetEvent().(*Event_SnapshotReceived); ok {
+		return x.SnapshotReceived
+	}
+	return nil
 }
 
-func (*UnimplementedNodeServer) Bootstrap(ctx context.Context, req *BootstrapRequest) (*BootstrapResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Bootstrap not implemented")
-}
-func (*UnimplementedNodeServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
-}
-func (*UnimplementedNodeServer) Leave(ctx context.Context, req *LeaveRequest) (*LeaveResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
-}
-func (*UnimplementedNodeServer) Watch(req *WatchRequest, srv Node_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+func (m *Event) GetSnapshotRecovered() *SnapshotRecoveredEvent {
+	if x, ok := m.GetEvent().(*Event_SnapshotRecovered); ok {
+		return x.SnapshotRecovered
+	}
+	return nil
 }
 
-func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
-	s.RegisterService(&_Node_serviceDesc, srv)
+func (m *Event) GetSnapshotCreated() *SnapshotCreatedEvent {
+	if x, ok := m.GetEvent().(*Event_SnapshotCreated); ok {
+		return x.SnapshotCreated
+	}
+	return nil
 }
 
-func _Node_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BootstrapRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(NodeServer).Bootstrap(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.consensus.node.v1.Node/Bootstrap",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Bootstrap(ctx, req.(*BootstrapRequest))
+func (m *Event) GetSnapshotCompacted() *SnapshotCompactedEvent {
+	if x, ok := m.GetEvent().(*Event_SnapshotCompacted); ok {
+		return x.SnapshotCompacted
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Node_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(JoinRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(NodeServer).Join(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.consensus.node.v1.Node/Join",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Join(ctx, req.(*JoinRequest))
+func (m *Event) GetLogCompacted() *LogCompactedEvent {
+	if x, ok := m.GetEvent().(*Event_LogCompacted); ok {
+		return x.LogCompacted
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Node_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LeaveRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(NodeServer).Leave(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/atomix.consensus.node.v1.Node/Leave",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NodeServer).Leave(ctx, req.(*LeaveRequest))
+func (m *Event) GetLogdbCompacted() *LogDBCompactedEvent {
+	if x, ok := m.GetEvent().(*Event_LogdbCompacted); ok {
+		return x.LogdbCompacted
 	}
-	return interceptor(ctx, in, info, handler)
+	return nil
 }
 
-func _Node_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(WatchRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func (m *Event) GetConnectionEstablished() *ConnectionEstablishedEvent {
+	if x, ok := m.GetEvent().(*Event_ConnectionEstablished); ok {
+		return x.ConnectionEstablished
 	}
-	return srv.(NodeServer).Watch(m, &nodeWatchServer{stream})
+	return nil
 }
 
-type Node_WatchServer interface {
-	Send(*Event) error
-	grpc.ServerStream
+func (m *Event) GetConnectionFailed() *ConnectionFailedEvent {
+	if x, ok := m.GetEvent().(*Event_ConnectionFailed); ok {
+		return x.ConnectionFailed
+	}
+	return nil
 }
 
-type nodeWatchServer struct {
-	grpc.ServerStream
+func (m *Event) GetResync() *ResyncEvent {
+	if x, ok := m.GetEvent().(*Event_Resync); ok {
+		return x.Resync
+	}
+	return nil
 }
 
-func (x *nodeWatchServer) Send(m *Event) error {
-	return x.ServerStream.SendMsg(m)
+func (m *Event) GetMemberUnloaded() *MemberUnloadedEvent {
+	if x, ok := m.GetEvent().(*Event_MemberUnloaded); ok {
+		return x.MemberUnloaded
+	}
+	return nil
 }
 
-var _Node_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "atomix.consensus.node.v1.Node",
-	HandlerType: (*NodeServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Bootstrap",
-			Handler:    _Node_Bootstrap_Handler,
-		},
-		{
-			MethodName: "Join",
-			Handler:    _Node_Join_Handler,
-		},
-		{
-			MethodName: "Leave",
-			Handler:    _Node_Leave_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Watch",
-			Handler:       _Node_Watch_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "consensus/protocol.proto",
+func (m *Event) GetHostShuttingDown() *HostShuttingDownEvent {
+	if x, ok := m.GetEvent().(*Event_HostShuttingDown); ok {
+		return x.HostShuttingDown
+	}
+	return nil
 }
 
-func (m *GroupConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Event) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Event_MemberReady)(nil),
+		(*Event_LeaderUpdated)(nil),
+		(*Event_MembershipChanged)(nil),
+		(*Event_SendSnapshotStarted)(nil),
+		(*Event_SendSnapshotCompleted)(nil),
+		(*Event_SendSnapshotAborted)(nil),
+		(*Event_SnapshotReceived)(nil),
+		(*Event_SnapshotRecovered)(nil),
+		(*Event_SnapshotCreated)(nil),
+		(*Event_SnapshotCompacted)(nil),
+		(*Event_LogCompacted)(nil),
+		(*Event_LogdbCompacted)(nil),
+		(*Event_ConnectionEstablished)(nil),
+		(*Event_ConnectionFailed)(nil),
+		(*Event_Resync)(nil),
+		(*Event_MemberUnloaded)(nil),
+		(*Event_HostShuttingDown)(nil),
 	}
-	return dAtA[:n], nil
 }
 
-func (m *GroupConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ConnectionInfo struct {
+	Address  string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Snapshot bool   `protobuf:"varint,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
 }
 
-func (m *GroupConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Members) > 0 {
-		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintProtocol(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
+func (m *ConnectionInfo) Reset()         { *m = ConnectionInfo{} }
+func (m *ConnectionInfo) String() string { return proto.CompactTextString(m) }
+func (*ConnectionInfo) ProtoMessage()    {}
+func (*ConnectionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{38}
+}
+func (m *ConnectionInfo) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionInfo.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Role != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Role))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.MemberID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.GroupID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
 }
-
-func (m *MemberConfig) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ConnectionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionInfo.Merge(m, src)
 }
-
-func (m *MemberConfig) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ConnectionInfo) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionInfo.DiscardUnknown(m)
 }
 
-func (m *MemberConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Port != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Port))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Host) > 0 {
-		i -= len(m.Host)
-		copy(dAtA[i:], m.Host)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Host)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.MemberID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
-		i--
-		dAtA[i] = 0x8
+var xxx_messageInfo_ConnectionInfo proto.InternalMessageInfo
+
+func (m *ConnectionInfo) GetAddress() string {
+	if m != nil {
+		return m.Address
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *RaftProposal) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ConnectionInfo) GetSnapshot() bool {
+	if m != nil {
+		return m.Snapshot
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *RaftProposal) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type MemberEvent struct {
+	GroupID  GroupID  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3,casttype=GroupID" json:"group_id,omitempty"`
+	MemberID MemberID `protobuf:"varint,2,opt,name=member_id,json=memberId,proto3,casttype=MemberID" json:"member_id,omitempty"`
 }
 
-func (m *RaftProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.SequenceNum != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.SequenceNum))
-		i--
-		dAtA[i] = 0x10
+func (m *MemberEvent) Reset()         { *m = MemberEvent{} }
+func (m *MemberEvent) String() string { return proto.CompactTextString(m) }
+func (*MemberEvent) ProtoMessage()    {}
+func (*MemberEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{39}
+}
+func (m *MemberEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x8
+}
+func (m *MemberEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberEvent.Merge(m, src)
+}
+func (m *MemberEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MemberEvent proto.InternalMessageInfo
+
+func (m *MemberEvent) GetGroupID() GroupID {
+	if m != nil {
+		return m.GroupID
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *MemberEvent) GetMemberID() MemberID {
+	if m != nil {
+		return m.MemberID
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *BootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type MemberReadyEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
 }
 
-func (m *BootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+func (m *MemberReadyEvent) Reset()         { *m = MemberReadyEvent{} }
+func (m *MemberReadyEvent) String() string { return proto.CompactTextString(m) }
+func (*MemberReadyEvent) ProtoMessage()    {}
+func (*MemberReadyEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{40}
+}
+func (m *MemberReadyEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberReadyEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberReadyEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *MemberReadyEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberReadyEvent.Merge(m, src)
 }
-
-func (m *BootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MemberReadyEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberReadyEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberReadyEvent.DiscardUnknown(m)
 }
 
-func (m *BootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+var xxx_messageInfo_MemberReadyEvent proto.InternalMessageInfo
+
+// MemberUnloadedEvent is published when a member is stopped on the node
+type MemberUnloadedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
 }
 
-func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *MemberUnloadedEvent) Reset()         { *m = MemberUnloadedEvent{} }
+func (m *MemberUnloadedEvent) String() string { return proto.CompactTextString(m) }
+func (*MemberUnloadedEvent) ProtoMessage()    {}
+func (*MemberUnloadedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{41}
+}
+func (m *MemberUnloadedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MemberUnloadedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MemberUnloadedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *MemberUnloadedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MemberUnloadedEvent.Merge(m, src)
+}
+func (m *MemberUnloadedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *MemberUnloadedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MemberUnloadedEvent.DiscardUnknown(m)
 }
 
-func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_MemberUnloadedEvent proto.InternalMessageInfo
+
+// HostShuttingDownEvent is published when the node begins shutting down
+type HostShuttingDownEvent struct {
+	// members are the members hosted by the node when it began shutting down
+	Members []MemberEvent `protobuf:"bytes,1,rep,name=members,proto3" json:"members"`
 }
 
-func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+func (m *HostShuttingDownEvent) Reset()         { *m = HostShuttingDownEvent{} }
+func (m *HostShuttingDownEvent) String() string { return proto.CompactTextString(m) }
+func (*HostShuttingDownEvent) ProtoMessage()    {}
+func (*HostShuttingDownEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{42}
+}
+func (m *HostShuttingDownEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HostShuttingDownEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HostShuttingDownEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *JoinResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *HostShuttingDownEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HostShuttingDownEvent.Merge(m, src)
 }
-
-func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *HostShuttingDownEvent) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *JoinResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+func (m *HostShuttingDownEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_HostShuttingDownEvent.DiscardUnknown(m)
 }
 
-func (m *LeaveRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_HostShuttingDownEvent proto.InternalMessageInfo
+
+func (m *HostShuttingDownEvent) GetMembers() []MemberEvent {
+	if m != nil {
+		return m.Members
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *LeaveRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type MembershipChangedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
 }
 
-func (m *LeaveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.GroupID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+func (m *MembershipChangedEvent) Reset()         { *m = MembershipChangedEvent{} }
+func (m *MembershipChangedEvent) String() string { return proto.CompactTextString(m) }
+func (*MembershipChangedEvent) ProtoMessage()    {}
+func (*MembershipChangedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{43}
 }
-
-func (m *LeaveResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *MembershipChangedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MembershipChangedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MembershipChangedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *LeaveResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *MembershipChangedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MembershipChangedEvent.Merge(m, src)
+}
+func (m *MembershipChangedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *MembershipChangedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_MembershipChangedEvent.DiscardUnknown(m)
 }
 
-func (m *LeaveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+var xxx_messageInfo_MembershipChangedEvent proto.InternalMessageInfo
+
+type LeaderUpdatedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Term        Term     `protobuf:"varint,2,opt,name=term,proto3,casttype=Term" json:"term,omitempty"`
+	Leader      MemberID `protobuf:"varint,3,opt,name=leader,proto3,casttype=MemberID" json:"leader,omitempty"`
 }
 
-func (m *WatchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LeaderUpdatedEvent) Reset()         { *m = LeaderUpdatedEvent{} }
+func (m *LeaderUpdatedEvent) String() string { return proto.CompactTextString(m) }
+func (*LeaderUpdatedEvent) ProtoMessage()    {}
+func (*LeaderUpdatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{44}
+}
+func (m *LeaderUpdatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaderUpdatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaderUpdatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *WatchRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LeaderUpdatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaderUpdatedEvent.Merge(m, src)
+}
+func (m *LeaderUpdatedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaderUpdatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaderUpdatedEvent.DiscardUnknown(m)
 }
 
-func (m *WatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+var xxx_messageInfo_LeaderUpdatedEvent proto.InternalMessageInfo
+
+func (m *LeaderUpdatedEvent) GetTerm() Term {
+	if m != nil {
+		return m.Term
+	}
+	return 0
 }
 
-func (m *Event) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LeaderUpdatedEvent) GetLeader() MemberID {
+	if m != nil {
+		return m.Leader
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Event) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SendSnapshotStartedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	To          MemberID `protobuf:"varint,3,opt,name=to,proto3,casttype=MemberID" json:"to,omitempty"`
 }
 
-func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Event != nil {
-		{
-			size := m.Event.Size()
-			i -= size
-			if _, err := m.Event.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
+func (m *SendSnapshotStartedEvent) Reset()         { *m = SendSnapshotStartedEvent{} }
+func (m *SendSnapshotStartedEvent) String() string { return proto.CompactTextString(m) }
+func (*SendSnapshotStartedEvent) ProtoMessage()    {}
+func (*SendSnapshotStartedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{45}
+}
+func (m *SendSnapshotStartedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendSnapshotStartedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendSnapshotStartedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
-	if err3 != nil {
-		return 0, err3
-	}
-	i -= n3
-	i = encodeVarintProtocol(dAtA, i, uint64(n3))
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *SendSnapshotStartedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSnapshotStartedEvent.Merge(m, src)
+}
+func (m *SendSnapshotStartedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SendSnapshotStartedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSnapshotStartedEvent.DiscardUnknown(m)
 }
 
-func (m *Event_MemberReady) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_SendSnapshotStartedEvent proto.InternalMessageInfo
+
+func (m *SendSnapshotStartedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
+	}
+	return 0
 }
 
-func (m *Event_MemberReady) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.MemberReady != nil {
-		{
-			size, err := m.MemberReady.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (m *SendSnapshotStartedEvent) GetTo() MemberID {
+	if m != nil {
+		return m.To
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Event_LeaderUpdated) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type SendSnapshotCompletedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	To          MemberID `protobuf:"varint,3,opt,name=to,proto3,casttype=MemberID" json:"to,omitempty"`
 }
 
-func (m *Event_LeaderUpdated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.LeaderUpdated != nil {
-		{
-			size, err := m.LeaderUpdated.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *SendSnapshotCompletedEvent) Reset()         { *m = SendSnapshotCompletedEvent{} }
+func (m *SendSnapshotCompletedEvent) String() string { return proto.CompactTextString(m) }
+func (*SendSnapshotCompletedEvent) ProtoMessage()    {}
+func (*SendSnapshotCompletedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{46}
+}
+func (m *SendSnapshotCompletedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendSnapshotCompletedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendSnapshotCompletedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x1a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Event_MembershipChanged) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SendSnapshotCompletedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSnapshotCompletedEvent.Merge(m, src)
+}
+func (m *SendSnapshotCompletedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SendSnapshotCompletedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSnapshotCompletedEvent.DiscardUnknown(m)
 }
 
-func (m *Event_MembershipChanged) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.MembershipChanged != nil {
-		{
-			size, err := m.MembershipChanged.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
+var xxx_messageInfo_SendSnapshotCompletedEvent proto.InternalMessageInfo
+
+func (m *SendSnapshotCompletedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Event_SendSnapshotStarted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return 0
 }
 
-func (m *Event_SendSnapshotStarted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SendSnapshotStarted != nil {
-		{
-			size, err := m.SendSnapshotStarted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+func (m *SendSnapshotCompletedEvent) GetTo() MemberID {
+	if m != nil {
+		return m.To
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Event_SendSnapshotCompleted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type SendSnapshotAbortedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	To          MemberID `protobuf:"varint,3,opt,name=to,proto3,casttype=MemberID" json:"to,omitempty"`
 }
 
-func (m *Event_SendSnapshotCompleted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SendSnapshotCompleted != nil {
-		{
-			size, err := m.SendSnapshotCompleted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *SendSnapshotAbortedEvent) Reset()         { *m = SendSnapshotAbortedEvent{} }
+func (m *SendSnapshotAbortedEvent) String() string { return proto.CompactTextString(m) }
+func (*SendSnapshotAbortedEvent) ProtoMessage()    {}
+func (*SendSnapshotAbortedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{47}
+}
+func (m *SendSnapshotAbortedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SendSnapshotAbortedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SendSnapshotAbortedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x32
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Event_SendSnapshotAborted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SendSnapshotAbortedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendSnapshotAbortedEvent.Merge(m, src)
+}
+func (m *SendSnapshotAbortedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SendSnapshotAbortedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendSnapshotAbortedEvent.DiscardUnknown(m)
 }
 
-func (m *Event_SendSnapshotAborted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SendSnapshotAborted != nil {
-		{
-			size, err := m.SendSnapshotAborted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
+var xxx_messageInfo_SendSnapshotAbortedEvent proto.InternalMessageInfo
+
+func (m *SendSnapshotAbortedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Event_SnapshotReceived) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return 0
 }
 
-func (m *Event_SnapshotReceived) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotReceived != nil {
-		{
-			size, err := m.SnapshotReceived.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
+func (m *SendSnapshotAbortedEvent) GetTo() MemberID {
+	if m != nil {
+		return m.To
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Event_SnapshotRecovered) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type SnapshotReceivedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index    `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
+	From        MemberID `protobuf:"varint,3,opt,name=from,proto3,casttype=MemberID" json:"from,omitempty"`
 }
 
-func (m *Event_SnapshotRecovered) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotRecovered != nil {
-		{
-			size, err := m.SnapshotRecovered.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *SnapshotReceivedEvent) Reset()         { *m = SnapshotReceivedEvent{} }
+func (m *SnapshotReceivedEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReceivedEvent) ProtoMessage()    {}
+func (*SnapshotReceivedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{48}
+}
+func (m *SnapshotReceivedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotReceivedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotReceivedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x4a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Event_SnapshotCreated) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SnapshotReceivedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReceivedEvent.Merge(m, src)
+}
+func (m *SnapshotReceivedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotReceivedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReceivedEvent.DiscardUnknown(m)
 }
 
-func (m *Event_SnapshotCreated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotCreated != nil {
-		{
-			size, err := m.SnapshotCreated.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x52
+var xxx_messageInfo_SnapshotReceivedEvent proto.InternalMessageInfo
+
+func (m *SnapshotReceivedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Event_SnapshotCompacted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return 0
 }
 
-func (m *Event_SnapshotCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.SnapshotCompacted != nil {
-		{
-			size, err := m.SnapshotCompacted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x5a
+func (m *SnapshotReceivedEvent) GetFrom() MemberID {
+	if m != nil {
+		return m.From
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Event_LogCompacted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type SnapshotRecoveredEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
 }
 
-func (m *Event_LogCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.LogCompacted != nil {
-		{
-			size, err := m.LogCompacted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *SnapshotRecoveredEvent) Reset()         { *m = SnapshotRecoveredEvent{} }
+func (m *SnapshotRecoveredEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotRecoveredEvent) ProtoMessage()    {}
+func (*SnapshotRecoveredEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{49}
+}
+func (m *SnapshotRecoveredEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotRecoveredEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotRecoveredEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x62
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Event_LogdbCompacted) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SnapshotRecoveredEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotRecoveredEvent.Merge(m, src)
 }
-
-func (m *Event_LogdbCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.LogdbCompacted != nil {
-		{
-			size, err := m.LogdbCompacted.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x6a
-	}
-	return len(dAtA) - i, nil
+func (m *SnapshotRecoveredEvent) XXX_Size() int {
+	return m.Size()
 }
-func (m *Event_ConnectionEstablished) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SnapshotRecoveredEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotRecoveredEvent.DiscardUnknown(m)
 }
 
-func (m *Event_ConnectionEstablished) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.ConnectionEstablished != nil {
-		{
-			size, err := m.ConnectionEstablished.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x72
+var xxx_messageInfo_SnapshotRecoveredEvent proto.InternalMessageInfo
+
+func (m *SnapshotRecoveredEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Event_ConnectionFailed) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+type SnapshotCreatedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
 }
 
-func (m *Event_ConnectionFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.ConnectionFailed != nil {
-		{
-			size, err := m.ConnectionFailed.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (m *SnapshotCreatedEvent) Reset()         { *m = SnapshotCreatedEvent{} }
+func (m *SnapshotCreatedEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotCreatedEvent) ProtoMessage()    {}
+func (*SnapshotCreatedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{50}
+}
+func (m *SnapshotCreatedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotCreatedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotCreatedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x7a
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *ConnectionInfo) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *SnapshotCreatedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotCreatedEvent.Merge(m, src)
 }
-
-func (m *ConnectionInfo) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *SnapshotCreatedEvent) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *ConnectionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Snapshot {
-		i--
-		if m.Snapshot {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x10
-	}
-	if len(m.Address) > 0 {
-		i -= len(m.Address)
-		copy(dAtA[i:], m.Address)
-		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Address)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+func (m *SnapshotCreatedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotCreatedEvent.DiscardUnknown(m)
 }
 
-func (m *MemberEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SnapshotCreatedEvent proto.InternalMessageInfo
+
+func (m *SnapshotCreatedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *MemberEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SnapshotCompactedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
 }
 
-func (m *MemberEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.MemberID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.GroupID != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
-		i--
-		dAtA[i] = 0x8
+func (m *SnapshotCompactedEvent) Reset()         { *m = SnapshotCompactedEvent{} }
+func (m *SnapshotCompactedEvent) String() string { return proto.CompactTextString(m) }
+func (*SnapshotCompactedEvent) ProtoMessage()    {}
+func (*SnapshotCompactedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{51}
+}
+func (m *SnapshotCompactedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SnapshotCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SnapshotCompactedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SnapshotCompactedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotCompactedEvent.Merge(m, src)
+}
+func (m *SnapshotCompactedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SnapshotCompactedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotCompactedEvent.DiscardUnknown(m)
 }
 
-func (m *MemberReadyEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SnapshotCompactedEvent proto.InternalMessageInfo
+
+func (m *SnapshotCompactedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *MemberReadyEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
 }
 
-func (m *MemberReadyEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *LogEvent) Reset()         { *m = LogEvent{} }
+func (m *LogEvent) String() string { return proto.CompactTextString(m) }
+func (*LogEvent) ProtoMessage()    {}
+func (*LogEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{52}
+}
+func (m *LogEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *MembershipChangedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *LogEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogEvent.Merge(m, src)
 }
-
-func (m *MembershipChangedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogEvent.DiscardUnknown(m)
 }
 
-func (m *MembershipChangedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
-}
+var xxx_messageInfo_LogEvent proto.InternalMessageInfo
 
-func (m *LeaderUpdatedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *LogEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *LeaderUpdatedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogCompactedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
 }
 
-func (m *LeaderUpdatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Leader != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Leader))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Term != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *LogCompactedEvent) Reset()         { *m = LogCompactedEvent{} }
+func (m *LogCompactedEvent) String() string { return proto.CompactTextString(m) }
+func (*LogCompactedEvent) ProtoMessage()    {}
+func (*LogCompactedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{53}
+}
+func (m *LogCompactedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogCompactedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SendSnapshotStartedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *LogCompactedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCompactedEvent.Merge(m, src)
 }
-
-func (m *SendSnapshotStartedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *LogCompactedEvent) XXX_Size() int {
+	return m.Size()
 }
-
-func (m *SendSnapshotStartedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.To != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.To))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+func (m *LogCompactedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCompactedEvent.DiscardUnknown(m)
 }
 
-func (m *SendSnapshotCompletedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LogCompactedEvent proto.InternalMessageInfo
+
+func (m *LogCompactedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SendSnapshotCompletedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type LogDBCompactedEvent struct {
+	MemberEvent `protobuf:"bytes,1,opt,name=member,proto3,embedded=member" json:"member"`
+	Index       Index `protobuf:"varint,2,opt,name=index,proto3,casttype=Index" json:"index,omitempty"`
 }
 
-func (m *SendSnapshotCompletedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.To != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.To))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *LogDBCompactedEvent) Reset()         { *m = LogDBCompactedEvent{} }
+func (m *LogDBCompactedEvent) String() string { return proto.CompactTextString(m) }
+func (*LogDBCompactedEvent) ProtoMessage()    {}
+func (*LogDBCompactedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{54}
+}
+func (m *LogDBCompactedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogDBCompactedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogDBCompactedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *LogDBCompactedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogDBCompactedEvent.Merge(m, src)
+}
+func (m *LogDBCompactedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogDBCompactedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogDBCompactedEvent.DiscardUnknown(m)
 }
 
-func (m *SendSnapshotAbortedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LogDBCompactedEvent proto.InternalMessageInfo
+
+func (m *LogDBCompactedEvent) GetIndex() Index {
+	if m != nil {
+		return m.Index
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SendSnapshotAbortedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ConnectionEstablishedEvent struct {
+	ConnectionInfo `protobuf:"bytes,1,opt,name=info,proto3,embedded=info" json:"info"`
 }
 
-func (m *SendSnapshotAbortedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.To != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.To))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *ConnectionEstablishedEvent) Reset()         { *m = ConnectionEstablishedEvent{} }
+func (m *ConnectionEstablishedEvent) String() string { return proto.CompactTextString(m) }
+func (*ConnectionEstablishedEvent) ProtoMessage()    {}
+func (*ConnectionEstablishedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{55}
+}
+func (m *ConnectionEstablishedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionEstablishedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionEstablishedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SnapshotReceivedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ConnectionEstablishedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionEstablishedEvent.Merge(m, src)
 }
-
-func (m *SnapshotReceivedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ConnectionEstablishedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionEstablishedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionEstablishedEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotReceivedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.From != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.From))
-		i--
-		dAtA[i] = 0x18
-	}
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+var xxx_messageInfo_ConnectionEstablishedEvent proto.InternalMessageInfo
+
+type ConnectionFailedEvent struct {
+	ConnectionInfo `protobuf:"bytes,1,opt,name=info,proto3,embedded=info" json:"info"`
+}
+
+func (m *ConnectionFailedEvent) Reset()         { *m = ConnectionFailedEvent{} }
+func (m *ConnectionFailedEvent) String() string { return proto.CompactTextString(m) }
+func (*ConnectionFailedEvent) ProtoMessage()    {}
+func (*ConnectionFailedEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{56}
+}
+func (m *ConnectionFailedEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConnectionFailedEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConnectionFailedEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
 }
-
-func (m *SnapshotRecoveredEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *ConnectionFailedEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConnectionFailedEvent.Merge(m, src)
+}
+func (m *ConnectionFailedEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConnectionFailedEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConnectionFailedEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotRecoveredEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+var xxx_messageInfo_ConnectionFailedEvent proto.InternalMessageInfo
+
+// ResyncEvent is sent when a watcher has missed events. When a watcher falls behind, the event is sent in place
+// of the events dropped from its queue and carries the sequence number of the last event queued before the first
+// event was dropped, from which the watcher can resume to recover the dropped events. When a watch cannot be
+// resumed from the requested sequence number, the event is sent with full_state set followed by the current state
+// of each group.
+type ResyncEvent struct {
+	// full_state indicates the missed events cannot be recovered and the current state of each group follows
+	FullState bool `protobuf:"varint,1,opt,name=full_state,json=fullState,proto3" json:"full_state,omitempty"`
 }
 
-func (m *SnapshotRecoveredEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+func (m *ResyncEvent) Reset()         { *m = ResyncEvent{} }
+func (m *ResyncEvent) String() string { return proto.CompactTextString(m) }
+func (*ResyncEvent) ProtoMessage()    {}
+func (*ResyncEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a7226d1cf45660e1, []int{57}
+}
+func (m *ResyncEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResyncEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResyncEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+		return b[:n], nil
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+}
+func (m *ResyncEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResyncEvent.Merge(m, src)
+}
+func (m *ResyncEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResyncEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResyncEvent.DiscardUnknown(m)
 }
 
-func (m *SnapshotCreatedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ResyncEvent proto.InternalMessageInfo
+
+func (m *ResyncEvent) GetFullState() bool {
+	if m != nil {
+		return m.FullState
 	}
-	return dAtA[:n], nil
+	return false
 }
 
-func (m *SnapshotCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func init() {
+	proto.RegisterEnum("atomix.consensus.node.v1.MemberRole", MemberRole_name, MemberRole_value)
+	proto.RegisterEnum("atomix.consensus.node.v1.EventType", EventType_name, EventType_value)
+	proto.RegisterType((*GroupConfig)(nil), "atomix.consensus.node.v1.GroupConfig")
+	proto.RegisterType((*MemberConfig)(nil), "atomix.consensus.node.v1.MemberConfig")
+	proto.RegisterType((*RaftProposal)(nil), "atomix.consensus.node.v1.RaftProposal")
+	proto.RegisterMapType((map[string]string)(nil), "atomix.consensus.node.v1.RaftProposal.TraceContextEntry")
+	proto.RegisterType((*RaftProposalResult)(nil), "atomix.consensus.node.v1.RaftProposalResult")
+	proto.RegisterType((*LeaderHint)(nil), "atomix.consensus.node.v1.LeaderHint")
+	proto.RegisterType((*ForwardProposalRequest)(nil), "atomix.consensus.node.v1.ForwardProposalRequest")
+	proto.RegisterType((*ForwardProposalResponse)(nil), "atomix.consensus.node.v1.ForwardProposalResponse")
+	proto.RegisterType((*GetAppliedIndexRequest)(nil), "atomix.consensus.node.v1.GetAppliedIndexRequest")
+	proto.RegisterType((*GetAppliedIndexResponse)(nil), "atomix.consensus.node.v1.GetAppliedIndexResponse")
+	proto.RegisterType((*BootstrapRequest)(nil), "atomix.consensus.node.v1.BootstrapRequest")
+	proto.RegisterType((*BootstrapResponse)(nil), "atomix.consensus.node.v1.BootstrapResponse")
+	proto.RegisterType((*JoinRequest)(nil), "atomix.consensus.node.v1.JoinRequest")
+	proto.RegisterType((*JoinResponse)(nil), "atomix.consensus.node.v1.JoinResponse")
+	proto.RegisterType((*LeaveRequest)(nil), "atomix.consensus.node.v1.LeaveRequest")
+	proto.RegisterType((*LeaveResponse)(nil), "atomix.consensus.node.v1.LeaveResponse")
+	proto.RegisterType((*AddMemberRequest)(nil), "atomix.consensus.node.v1.AddMemberRequest")
+	proto.RegisterType((*AddMemberResponse)(nil), "atomix.consensus.node.v1.AddMemberResponse")
+	proto.RegisterType((*RemoveMemberRequest)(nil), "atomix.consensus.node.v1.RemoveMemberRequest")
+	proto.RegisterType((*RemoveMemberResponse)(nil), "atomix.consensus.node.v1.RemoveMemberResponse")
+	proto.RegisterType((*PromoteMemberRequest)(nil), "atomix.consensus.node.v1.PromoteMemberRequest")
+	proto.RegisterType((*PromoteMemberResponse)(nil), "atomix.consensus.node.v1.PromoteMemberResponse")
+	proto.RegisterType((*TransferLeadershipRequest)(nil), "atomix.consensus.node.v1.TransferLeadershipRequest")
+	proto.RegisterType((*TransferLeadershipResponse)(nil), "atomix.consensus.node.v1.TransferLeadershipResponse")
+	proto.RegisterType((*GetGroupStatusRequest)(nil), "atomix.consensus.node.v1.GetGroupStatusRequest")
+	proto.RegisterType((*GetGroupStatusResponse)(nil), "atomix.consensus.node.v1.GetGroupStatusResponse")
+	proto.RegisterType((*ListGroupsRequest)(nil), "atomix.consensus.node.v1.ListGroupsRequest")
+	proto.RegisterType((*ListGroupsResponse)(nil), "atomix.consensus.node.v1.ListGroupsResponse")
+	proto.RegisterType((*GroupStatus)(nil), "atomix.consensus.node.v1.GroupStatus")
+	proto.RegisterType((*GetNodeInfoRequest)(nil), "atomix.consensus.node.v1.GetNodeInfoRequest")
+	proto.RegisterType((*GetNodeInfoResponse)(nil), "atomix.consensus.node.v1.GetNodeInfoResponse")
+	proto.RegisterType((*NodeInfo)(nil), "atomix.consensus.node.v1.NodeInfo")
+	proto.RegisterType((*MemberLogInfo)(nil), "atomix.consensus.node.v1.MemberLogInfo")
+	proto.RegisterType((*ExportSnapshotRequest)(nil), "atomix.consensus.node.v1.ExportSnapshotRequest")
+	proto.RegisterType((*ExportSnapshotResponse)(nil), "atomix.consensus.node.v1.ExportSnapshotResponse")
+	proto.RegisterType((*ImportSnapshotRequest)(nil), "atomix.consensus.node.v1.ImportSnapshotRequest")
+	proto.RegisterType((*ImportSnapshotResponse)(nil), "atomix.consensus.node.v1.ImportSnapshotResponse")
+	proto.RegisterType((*WatchRequest)(nil), "atomix.consensus.node.v1.WatchRequest")
+	proto.RegisterType((*Event)(nil), "atomix.consensus.node.v1.Event")
+	proto.RegisterType((*ConnectionInfo)(nil), "atomix.consensus.node.v1.ConnectionInfo")
+	proto.RegisterType((*MemberEvent)(nil), "atomix.consensus.node.v1.MemberEvent")
+	proto.RegisterType((*MemberReadyEvent)(nil), "atomix.consensus.node.v1.MemberReadyEvent")
+	proto.RegisterType((*MemberUnloadedEvent)(nil), "atomix.consensus.node.v1.MemberUnloadedEvent")
+	proto.RegisterType((*HostShuttingDownEvent)(nil), "atomix.consensus.node.v1.HostShuttingDownEvent")
+	proto.RegisterType((*MembershipChangedEvent)(nil), "atomix.consensus.node.v1.MembershipChangedEvent")
+	proto.RegisterType((*LeaderUpdatedEvent)(nil), "atomix.consensus.node.v1.LeaderUpdatedEvent")
+	proto.RegisterType((*SendSnapshotStartedEvent)(nil), "atomix.consensus.node.v1.SendSnapshotStartedEvent")
+	proto.RegisterType((*SendSnapshotCompletedEvent)(nil), "atomix.consensus.node.v1.SendSnapshotCompletedEvent")
+	proto.RegisterType((*SendSnapshotAbortedEvent)(nil), "atomix.consensus.node.v1.SendSnapshotAbortedEvent")
+	proto.RegisterType((*SnapshotReceivedEvent)(nil), "atomix.consensus.node.v1.SnapshotReceivedEvent")
+	proto.RegisterType((*SnapshotRecoveredEvent)(nil), "atomix.consensus.node.v1.SnapshotRecoveredEvent")
+	proto.RegisterType((*SnapshotCreatedEvent)(nil), "atomix.consensus.node.v1.SnapshotCreatedEvent")
+	proto.RegisterType((*SnapshotCompactedEvent)(nil), "atomix.consensus.node.v1.SnapshotCompactedEvent")
+	proto.RegisterType((*LogEvent)(nil), "atomix.consensus.node.v1.LogEvent")
+	proto.RegisterType((*LogCompactedEvent)(nil), "atomix.consensus.node.v1.LogCompactedEvent")
+	proto.RegisterType((*LogDBCompactedEvent)(nil), "atomix.consensus.node.v1.LogDBCompactedEvent")
+	proto.RegisterType((*ConnectionEstablishedEvent)(nil), "atomix.consensus.node.v1.ConnectionEstablishedEvent")
+	proto.RegisterType((*ConnectionFailedEvent)(nil), "atomix.consensus.node.v1.ConnectionFailedEvent")
+	proto.RegisterType((*ResyncEvent)(nil), "atomix.consensus.node.v1.ResyncEvent")
 }
 
-func (m *SnapshotCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+func init() { proto.RegisterFile("consensus/protocol.proto", fileDescriptor_a7226d1cf45660e1) }
+
+var fileDescriptor_a7226d1cf45660e1 = []byte{
+	// 2655 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0x4b, 0x6f, 0x23, 0xc7,
+	0xb5, 0x56, 0x4b, 0x4d, 0x89, 0x3c, 0x7c, 0xa8, 0x55, 0x7a, 0x4c, 0x9b, 0xd7, 0x57, 0x12, 0xda,
+	0xbe, 0xb6, 0x60, 0x8f, 0x39, 0x33, 0xf2, 0x5c, 0xc0, 0x30, 0x0c, 0xf8, 0xf2, 0xd1, 0x23, 0x71,
+	0xac, 0x21, 0x85, 0x26, 0x35, 0xb6, 0x61, 0x5c, 0x13, 0x2d, 0x76, 0x89, 0x62, 0x42, 0x76, 0xd1,
+	0xdd, 0x4d, 0xcd, 0xc8, 0x40, 0x60, 0x23, 0xbf, 0xc0, 0x9b, 0x00, 0x59, 0x64, 0x91, 0xd7, 0x2e,
+	0x8b, 0xfc, 0x8a, 0x00, 0x06, 0xb2, 0xf1, 0x32, 0x2b, 0xc5, 0x90, 0x7f, 0x41, 0x16, 0x59, 0x64,
+	0x56, 0x41, 0x3d, 0xba, 0xd9, 0x7c, 0x53, 0x1a, 0x45, 0x71, 0x76, 0xdd, 0x55, 0xf5, 0x9d, 0xef,
+	0xab, 0xaa, 0x53, 0x55, 0xa7, 0xea, 0x80, 0x5a, 0x27, 0xb6, 0x8b, 0x6d, 0xb7, 0xeb, 0xde, 0xeb,
+	0x38, 0xc4, 0x23, 0x75, 0xd2, 0xca, 0xb0, 0x0f, 0xa4, 0x9a, 0x1e, 0x69, 0x37, 0x9f, 0x67, 0x82,
+	0x06, 0x19, 0x9b, 0x58, 0x38, 0x73, 0xf6, 0x20, 0xbd, 0xd5, 0x20, 0xa4, 0xd1, 0xc2, 0x1c, 0x70,
+	0xdc, 0x3d, 0xb9, 0xe7, 0x35, 0xdb, 0xd8, 0xf5, 0xcc, 0x76, 0x87, 0x43, 0xd3, 0x6b, 0x0d, 0xd2,
+	0x20, 0xec, 0xf3, 0x1e, 0xfd, 0xe2, 0xa5, 0xda, 0x3f, 0x24, 0x88, 0xef, 0x39, 0xa4, 0xdb, 0xc9,
+	0x13, 0xfb, 0xa4, 0xd9, 0x40, 0x0f, 0x20, 0xda, 0xa0, 0xbf, 0xb5, 0xa6, 0xa5, 0x4a, 0xdb, 0xd2,
+	0x4e, 0x32, 0xb7, 0x71, 0x79, 0xb1, 0xb5, 0xc4, 0x9a, 0x14, 0x0b, 0x2f, 0x7a, 0x9f, 0xc6, 0x12,
+	0x6b, 0x57, 0xb4, 0xd0, 0xff, 0x42, 0xac, 0x8d, 0xdb, 0xc7, 0xd8, 0xa1, 0x98, 0x79, 0x86, 0x51,
+	0x2f, 0x2f, 0xb6, 0xa2, 0x4f, 0x58, 0x21, 0x03, 0x05, 0xdf, 0x46, 0x94, 0x37, 0x2d, 0x5a, 0xe8,
+	0x3d, 0x90, 0x1d, 0xd2, 0xc2, 0xea, 0xc2, 0xb6, 0xb4, 0x93, 0xda, 0x7d, 0x3d, 0x33, 0xae, 0x67,
+	0x19, 0x8e, 0x35, 0x48, 0x0b, 0x1b, 0x0c, 0x81, 0x1e, 0xc1, 0x12, 0xb7, 0xe2, 0xaa, 0xf2, 0xf6,
+	0xc2, 0x4e, 0x7c, 0xf7, 0x8d, 0x69, 0x60, 0xde, 0xb9, 0x9c, 0xfc, 0xed, 0xc5, 0xd6, 0x9c, 0xe1,
+	0x83, 0xb5, 0x36, 0x24, 0xc2, 0xd5, 0xfd, 0x1d, 0x91, 0x66, 0xee, 0x08, 0x02, 0xf9, 0x94, 0xb8,
+	0x1e, 0xeb, 0x7a, 0xcc, 0x60, 0xdf, 0xb4, 0xac, 0x43, 0x1c, 0x8f, 0x75, 0x2e, 0x62, 0xb0, 0x6f,
+	0xed, 0x72, 0x1e, 0x12, 0x86, 0x79, 0xe2, 0x1d, 0x3a, 0xa4, 0x43, 0x5c, 0xb3, 0x85, 0x5e, 0x05,
+	0xd9, 0xc3, 0x4e, 0x9b, 0x51, 0xc9, 0xb9, 0xe8, 0x8b, 0x8b, 0x2d, 0xb9, 0x8a, 0x9d, 0xb6, 0xc1,
+	0x4a, 0xd1, 0x2e, 0x24, 0x5c, 0xfc, 0x45, 0x17, 0xdb, 0x75, 0x5c, 0xb3, 0xbb, 0x6d, 0x66, 0x5e,
+	0xce, 0x2d, 0xbf, 0xb8, 0xd8, 0x8a, 0x57, 0x44, 0x79, 0xa9, 0xdb, 0x36, 0xe2, 0x6e, 0xef, 0x87,
+	0xd2, 0x5a, 0xa6, 0x67, 0x32, 0xda, 0x84, 0xc1, 0xbe, 0xd1, 0xff, 0x43, 0xd2, 0x73, 0xcc, 0x3a,
+	0xae, 0xd5, 0x89, 0xed, 0xe1, 0xe7, 0x9e, 0x1a, 0x61, 0x63, 0xf6, 0xde, 0xf8, 0x31, 0x0b, 0x8b,
+	0xcc, 0x54, 0x29, 0x36, 0xcf, 0xa1, 0xba, 0xed, 0x39, 0xe7, 0x46, 0xc2, 0x0b, 0x15, 0xa1, 0x0f,
+	0x20, 0x72, 0x6c, 0x7a, 0xf5, 0x53, 0x75, 0x71, 0xda, 0x54, 0x84, 0xcd, 0x1a, 0x1c, 0x94, 0xfe,
+	0x10, 0x56, 0x86, 0x08, 0x90, 0x02, 0x0b, 0x3f, 0xc5, 0xe7, 0x6c, 0x58, 0x62, 0x06, 0xfd, 0x44,
+	0x6b, 0x10, 0x39, 0x33, 0x5b, 0x5d, 0x2c, 0xc6, 0x98, 0xff, 0xbc, 0x3f, 0xff, 0x9e, 0xf4, 0x58,
+	0x8e, 0xca, 0x4a, 0xc4, 0x88, 0xba, 0xb6, 0xd9, 0x71, 0x4f, 0x89, 0xa7, 0xfd, 0x4a, 0x02, 0xd4,
+	0x47, 0x84, 0xdd, 0x6e, 0xcb, 0xfb, 0x17, 0x0c, 0xb5, 0x0a, 0x4b, 0xa4, 0xeb, 0x75, 0xba, 0x9e,
+	0xab, 0x2e, 0x6c, 0x2f, 0xec, 0x24, 0x0c, 0xff, 0x17, 0xa5, 0x21, 0x5a, 0x27, 0xed, 0x4e, 0x0b,
+	0x7b, 0x58, 0x95, 0xb7, 0xa5, 0x9d, 0xa8, 0x11, 0xfc, 0x6b, 0x7f, 0x90, 0x00, 0x0e, 0xb0, 0x69,
+	0x61, 0x67, 0xbf, 0x69, 0x7b, 0xd7, 0x59, 0x6d, 0x7e, 0x4f, 0xe6, 0x47, 0xf6, 0xe4, 0x75, 0x58,
+	0x6c, 0x31, 0xf3, 0xcc, 0x05, 0x92, 0xb9, 0x44, 0x9f, 0xcf, 0x8a, 0xba, 0xc0, 0x63, 0xe5, 0x11,
+	0x1e, 0x1b, 0x09, 0x79, 0xac, 0x09, 0x1b, 0x8f, 0x88, 0xf3, 0xcc, 0x74, 0xac, 0xde, 0x70, 0x7e,
+	0xd1, 0xc5, 0xee, 0xb5, 0x84, 0xaf, 0x41, 0xa4, 0x69, 0x77, 0xba, 0x7c, 0x9d, 0x24, 0x0c, 0xfe,
+	0xa3, 0x3d, 0x80, 0x3b, 0x43, 0x14, 0x6e, 0x87, 0xfa, 0x0e, 0xda, 0x80, 0x45, 0x3e, 0xa4, 0x8c,
+	0x21, 0x61, 0x88, 0x3f, 0xed, 0x23, 0xd8, 0xd8, 0xc3, 0x5e, 0xb6, 0xd3, 0x69, 0x35, 0xb1, 0x55,
+	0xb4, 0x2d, 0xfc, 0xfc, 0xfa, 0xaa, 0xb4, 0xf7, 0xe1, 0xce, 0x90, 0x31, 0xc1, 0xbf, 0x45, 0x05,
+	0x5b, 0xf8, 0xb9, 0x70, 0x9a, 0xd8, 0x8b, 0x8b, 0xad, 0x08, 0x6f, 0xc1, 0xcb, 0xb5, 0x23, 0x50,
+	0x72, 0x84, 0x78, 0xae, 0xe7, 0x98, 0x1d, 0x5f, 0x42, 0x16, 0x22, 0xcc, 0x34, 0x03, 0xc5, 0x77,
+	0xff, 0x67, 0xfc, 0x72, 0x08, 0xed, 0xba, 0x62, 0x63, 0xe2, 0x48, 0x6d, 0x15, 0x56, 0x42, 0x66,
+	0xb9, 0x18, 0xed, 0x10, 0xe2, 0x8f, 0x49, 0xd3, 0xbe, 0x41, 0x9a, 0x14, 0x24, 0xb8, 0x45, 0xc1,
+	0x90, 0x85, 0xc4, 0x01, 0x36, 0xcf, 0xf0, 0x4b, 0x0c, 0xe6, 0x32, 0x24, 0x85, 0x09, 0x61, 0xf3,
+	0x4f, 0x12, 0x28, 0x59, 0xcb, 0x12, 0x3b, 0xf8, 0xf5, 0x7d, 0xa7, 0x00, 0x8b, 0x7c, 0xbb, 0x65,
+	0xce, 0x73, 0xd5, 0x0d, 0x5f, 0x60, 0xaf, 0x7f, 0xe2, 0xd0, 0x29, 0x09, 0x75, 0x43, 0x74, 0xee,
+	0x2b, 0x58, 0x35, 0x70, 0x9b, 0x9c, 0xe1, 0x97, 0xee, 0xde, 0xf5, 0x4e, 0x50, 0x6d, 0x03, 0xd6,
+	0xfa, 0x05, 0x08, 0x61, 0x5f, 0x4b, 0xb0, 0x76, 0xe8, 0x90, 0x36, 0xf1, 0xfe, 0x6d, 0xd2, 0xee,
+	0xc0, 0xfa, 0x80, 0x02, 0xa1, 0xed, 0x97, 0x12, 0xbc, 0x52, 0x75, 0x4c, 0xdb, 0x3d, 0xc1, 0x0e,
+	0xdf, 0x08, 0xdd, 0xd3, 0x66, 0xe7, 0x25, 0x04, 0xee, 0x83, 0xe2, 0x99, 0x4e, 0x03, 0x7b, 0xb5,
+	0x41, 0x9d, 0x9b, 0x97, 0x17, 0x5b, 0xa9, 0x2a, 0xab, 0x1b, 0xa9, 0x36, 0xe5, 0x85, 0xeb, 0x2c,
+	0xed, 0x55, 0x48, 0x8f, 0x52, 0x26, 0x84, 0x3f, 0x86, 0xf5, 0x3d, 0xec, 0x31, 0xfa, 0x8a, 0x67,
+	0x7a, 0x5d, 0xf7, 0x25, 0xd6, 0xc9, 0x67, 0x6c, 0x07, 0xeb, 0xb3, 0x25, 0xf6, 0x9c, 0x2b, 0xae,
+	0x6b, 0x8e, 0x1e, 0xda, 0x3e, 0x0e, 0x9a, 0x2e, 0xb7, 0xee, 0x8b, 0xd4, 0x3e, 0x05, 0x14, 0x2e,
+	0x14, 0x6c, 0x79, 0x58, 0x64, 0x18, 0x57, 0x95, 0xb6, 0x17, 0xae, 0x4a, 0x27, 0xa0, 0xda, 0x9f,
+	0x65, 0x88, 0x87, 0x6a, 0xff, 0x23, 0x22, 0x48, 0xff, 0x10, 0x95, 0xa7, 0x1c, 0xa2, 0x91, 0x09,
+	0x87, 0x68, 0x06, 0x92, 0x26, 0x3f, 0x36, 0x6a, 0xfc, 0x98, 0x58, 0x1a, 0x3c, 0x26, 0x12, 0x66,
+	0xe8, 0x58, 0x41, 0xf7, 0x21, 0xe5, 0x47, 0x29, 0x02, 0x10, 0x1d, 0x04, 0x24, 0xfd, 0x06, 0x1c,
+	0x11, 0x8a, 0x73, 0x63, 0x2f, 0x11, 0xe7, 0xa2, 0xc7, 0x10, 0x23, 0xc7, 0x2e, 0x76, 0xce, 0xa8,
+	0x25, 0xb8, 0x86, 0xa5, 0x1e, 0x9c, 0xda, 0x7a, 0xd6, 0xf4, 0x6c, 0xec, 0xba, 0xd8, 0x55, 0xe3,
+	0xd7, 0xb1, 0x15, 0xc0, 0x1f, 0xcb, 0xd1, 0x45, 0x65, 0xc9, 0x48, 0xd4, 0x49, 0xbb, 0xdd, 0x14,
+	0x63, 0xa2, 0xad, 0x01, 0xda, 0xc3, 0x5e, 0x89, 0x58, 0xb8, 0x68, 0x9f, 0x10, 0xdf, 0x7d, 0x2b,
+	0xb0, 0xda, 0x57, 0x2a, 0xfc, 0xf7, 0x03, 0x90, 0x29, 0x93, 0x58, 0x2c, 0xda, 0x78, 0x1d, 0x3e,
+	0x52, 0x68, 0x60, 0x28, 0xcd, 0x81, 0xa8, 0x5f, 0x8e, 0x5e, 0x83, 0x25, 0x1a, 0x05, 0xf9, 0x3e,
+	0x1b, 0xcb, 0xc1, 0xe5, 0xc5, 0xd6, 0xe2, 0x3e, 0x71, 0x3d, 0x3a, 0xe3, 0xb4, 0xaa, 0x68, 0xa1,
+	0x2c, 0xc8, 0x2d, 0xd2, 0x70, 0xd5, 0x79, 0xd6, 0xed, 0x37, 0xa7, 0x75, 0xfb, 0x80, 0x34, 0xc2,
+	0x9c, 0x14, 0xaa, 0x9d, 0x43, 0xb2, 0xaf, 0xf2, 0x16, 0xb7, 0xe4, 0xaf, 0x25, 0x58, 0xd7, 0x9f,
+	0xd3, 0xb8, 0xae, 0x22, 0xbc, 0xec, 0x25, 0x76, 0xdd, 0x87, 0x20, 0xd3, 0xfb, 0xa5, 0x38, 0x8e,
+	0xd3, 0x19, 0x7e, 0xf9, 0xcc, 0xf8, 0x97, 0xcf, 0x4c, 0xd5, 0xbf, 0x7c, 0xe6, 0xe4, 0x6f, 0xfe,
+	0xba, 0x25, 0x19, 0xac, 0xb5, 0xd6, 0x82, 0x8d, 0x41, 0x05, 0x33, 0xc6, 0x5a, 0xc1, 0xcd, 0x66,
+	0x3e, 0x74, 0xb3, 0xf9, 0x6f, 0x00, 0x8f, 0x78, 0x66, 0xab, 0xe6, 0x36, 0xbf, 0xe4, 0xbb, 0x80,
+	0x6c, 0xc4, 0x58, 0x49, 0xa5, 0xf9, 0x25, 0xd6, 0x7e, 0x21, 0xc1, 0x7a, 0xb1, 0x7d, 0x43, 0x1d,
+	0x1e, 0xc5, 0xdf, 0x37, 0x11, 0x0b, 0x33, 0x4f, 0x84, 0x0a, 0x1b, 0xc5, 0xf6, 0xa8, 0x51, 0xd0,
+	0xfe, 0x28, 0x41, 0xe2, 0x63, 0x7a, 0x2f, 0xf2, 0x85, 0x3e, 0x84, 0x98, 0x2f, 0x94, 0xef, 0xd1,
+	0xc9, 0xdc, 0x1d, 0xca, 0x20, 0xe4, 0xb9, 0x61, 0xa9, 0x51, 0x21, 0xd5, 0x45, 0x05, 0x88, 0xe3,
+	0x33, 0x6c, 0x7b, 0x35, 0xef, 0xbc, 0x83, 0xb9, 0xbb, 0xa6, 0x76, 0x5f, 0x1b, 0xef, 0xae, 0x3a,
+	0x6d, 0x5c, 0x3d, 0xef, 0x60, 0x03, 0xb0, 0xff, 0xe9, 0xa2, 0xd7, 0x20, 0x79, 0xe2, 0x90, 0x76,
+	0xcd, 0xbf, 0xf4, 0x88, 0x01, 0x4e, 0xd0, 0x42, 0xff, 0x56, 0xa4, 0x7d, 0x9f, 0x84, 0x08, 0x83,
+	0xa3, 0x1c, 0xc4, 0x82, 0x17, 0x07, 0x55, 0x9a, 0xea, 0x16, 0x51, 0xba, 0x28, 0x98, 0x6b, 0xf4,
+	0x60, 0xf4, 0xe6, 0x14, 0xb0, 0x29, 0x8c, 0x2d, 0xf8, 0x0f, 0xaf, 0xd0, 0x95, 0xb1, 0x2b, 0xb4,
+	0x0c, 0x09, 0x31, 0x23, 0x0e, 0x36, 0xad, 0x73, 0xe1, 0x9e, 0x6f, 0x4d, 0x3d, 0x19, 0x68, 0x63,
+	0xd6, 0x8d, 0xfd, 0x39, 0x23, 0xde, 0xee, 0x95, 0xa1, 0x23, 0x48, 0xf1, 0xed, 0xbe, 0xd6, 0xed,
+	0x58, 0xa6, 0x87, 0xf9, 0x3c, 0xc7, 0x77, 0xef, 0x8e, 0x37, 0xc9, 0x63, 0x87, 0x23, 0xde, 0xdc,
+	0x37, 0x9a, 0x6c, 0x85, 0x4b, 0x91, 0x09, 0x88, 0xb3, 0xd0, 0x10, 0xa3, 0x56, 0x3f, 0x35, 0xed,
+	0x06, 0xb6, 0xd8, 0x69, 0x14, 0xdf, 0xbd, 0x3f, 0x4d, 0x2d, 0xc5, 0xe4, 0x39, 0xc4, 0x37, 0xbf,
+	0xd2, 0x1e, 0xac, 0x41, 0xa7, 0xb0, 0xee, 0x62, 0xdb, 0xaa, 0x05, 0x67, 0x8e, 0xeb, 0x99, 0x0e,
+	0xed, 0x40, 0x84, 0xb1, 0xec, 0x8e, 0x67, 0xa9, 0x60, 0xdb, 0xf2, 0x5d, 0xb3, 0xc2, 0x41, 0x3e,
+	0xcf, 0xaa, 0x3b, 0x5c, 0x87, 0x6c, 0xb8, 0xd3, 0xcf, 0xe4, 0xdf, 0x76, 0x2d, 0x75, 0x91, 0x71,
+	0x3d, 0x9c, 0x8d, 0x2b, 0xef, 0xc3, 0x7c, 0xb6, 0x75, 0x77, 0x54, 0xed, 0x70, 0xcf, 0xcc, 0x63,
+	0xc2, 0x7a, 0xb6, 0x74, 0x95, 0x9e, 0x65, 0x39, 0x68, 0x64, 0xcf, 0x44, 0x1d, 0xfa, 0x1c, 0x56,
+	0x02, 0x12, 0x07, 0xd7, 0x71, 0xf3, 0x0c, 0x5b, 0xec, 0xd4, 0x8e, 0xef, 0xde, 0x9b, 0xc0, 0x12,
+	0x2c, 0x6b, 0x8e, 0xf0, 0x29, 0x14, 0x77, 0xa0, 0x82, 0xba, 0x41, 0xd8, 0x3e, 0x39, 0xc3, 0x0e,
+	0xb6, 0xd4, 0xd8, 0x34, 0x37, 0x08, 0x11, 0x70, 0x48, 0xe0, 0x06, 0xee, 0x60, 0x0d, 0xfa, 0x0c,
+	0x94, 0xde, 0xbc, 0x38, 0x98, 0xb9, 0x30, 0x30, 0x82, 0xcc, 0x74, 0x82, 0x3c, 0x07, 0xf8, 0xe6,
+	0x97, 0xdd, 0xfe, 0xf2, 0x3e, 0xfd, 0x74, 0xd2, 0xcd, 0x3a, 0x35, 0x1f, 0x9f, 0x55, 0x7f, 0xde,
+	0x87, 0x0c, 0xe9, 0x0f, 0x6a, 0x90, 0x01, 0xc9, 0x16, 0x69, 0x84, 0xac, 0x27, 0x98, 0xf5, 0xb7,
+	0x27, 0xac, 0x3f, 0xd2, 0x18, 0x32, 0x9c, 0x68, 0x85, 0x0a, 0xd1, 0x27, 0xb0, 0xdc, 0x22, 0x0d,
+	0xeb, 0x38, 0x64, 0x35, 0xc9, 0xac, 0xbe, 0x33, 0xd1, 0x6a, 0x21, 0x37, 0x64, 0x37, 0xc5, 0xec,
+	0xf4, 0x2c, 0xb7, 0x61, 0xa3, 0x4e, 0x6c, 0x1b, 0xd7, 0xbd, 0x26, 0xb1, 0x6b, 0x74, 0x57, 0x3b,
+	0x6e, 0x35, 0xdd, 0x53, 0x6c, 0xa9, 0xa9, 0x69, 0x2b, 0x21, 0x1f, 0xe0, 0xf4, 0x1e, 0x2c, 0x58,
+	0x09, 0xf5, 0x51, 0xb5, 0xd4, 0x3f, 0x43, 0x74, 0x27, 0x66, 0xb3, 0x85, 0x2d, 0x75, 0x79, 0x9a,
+	0x7f, 0xf6, 0x98, 0x1e, 0x31, 0x44, 0xe0, 0x9f, 0xf5, 0x81, 0x0a, 0xf4, 0x21, 0x2c, 0x3a, 0xd8,
+	0x3d, 0xb7, 0xeb, 0x2a, 0x9a, 0x76, 0x1d, 0x31, 0x58, 0x3b, 0xdf, 0x94, 0x80, 0xd1, 0x91, 0x16,
+	0xfb, 0x71, 0xd7, 0x6e, 0x11, 0xd3, 0xc2, 0x96, 0xba, 0x3a, 0x6d, 0xa4, 0xf9, 0x26, 0x77, 0x24,
+	0xda, 0x07, 0x23, 0xdd, 0xee, 0x2b, 0x46, 0x35, 0x40, 0xec, 0x38, 0x70, 0x4f, 0xbb, 0x9e, 0xd7,
+	0xb4, 0x1b, 0x35, 0x8b, 0x3c, 0xb3, 0xd5, 0xb5, 0x69, 0x7d, 0xa7, 0xe7, 0x45, 0x45, 0x40, 0x0a,
+	0xe4, 0x99, 0x1d, 0xf4, 0xfd, 0x74, 0xa0, 0x22, 0xb7, 0x04, 0x11, 0x76, 0x18, 0x6a, 0x8f, 0x20,
+	0xd5, 0x1b, 0x31, 0x16, 0xb3, 0xa9, 0xb0, 0x64, 0x5a, 0x96, 0x83, 0x5d, 0x57, 0xbc, 0x51, 0xfa,
+	0xbf, 0xec, 0x00, 0x13, 0x2e, 0xcc, 0xce, 0x9e, 0x68, 0xe8, 0x65, 0xf2, 0x19, 0xc4, 0x79, 0xd7,
+	0xf8, 0x79, 0x79, 0x13, 0x81, 0x9f, 0x3c, 0x53, 0xbc, 0xf1, 0x19, 0x28, 0x83, 0xc7, 0x1c, 0xda,
+	0x0b, 0x1e, 0x54, 0xa6, 0x5e, 0x34, 0x43, 0xa2, 0xf9, 0xa9, 0xfd, 0xdd, 0xc5, 0x96, 0xe4, 0xbf,
+	0xa9, 0x68, 0x9f, 0xc3, 0xea, 0x88, 0x09, 0xbb, 0x49, 0xfb, 0xeb, 0x23, 0xe7, 0x0c, 0xe9, 0xbd,
+	0xcb, 0xd1, 0xd4, 0xcb, 0x6b, 0x98, 0x62, 0x20, 0x07, 0x60, 0xc2, 0xc6, 0xe8, 0x53, 0xf5, 0xe6,
+	0xba, 0xf0, 0x1b, 0x09, 0xd0, 0x70, 0x50, 0x70, 0x63, 0xf6, 0xaf, 0xf4, 0x22, 0x2c, 0x8f, 0xbe,
+	0xcc, 0x6a, 0xbf, 0x95, 0x40, 0x1d, 0x77, 0xee, 0xdf, 0x9c, 0xd2, 0x20, 0xca, 0x9f, 0x1f, 0x13,
+	0xe5, 0xbf, 0x0a, 0xf3, 0x1e, 0x19, 0x29, 0x74, 0xde, 0x23, 0xda, 0xef, 0x25, 0x48, 0x8f, 0x0f,
+	0x18, 0x7e, 0x34, 0x32, 0x07, 0xc7, 0x32, 0x1c, 0x69, 0xfc, 0x68, 0x44, 0xfe, 0x4e, 0x82, 0xf5,
+	0x91, 0x81, 0xca, 0x2d, 0x2a, 0xdc, 0x06, 0x99, 0x5e, 0x26, 0x46, 0x6a, 0x64, 0x35, 0xda, 0xcf,
+	0x25, 0xd8, 0x18, 0x1d, 0xed, 0xdc, 0x9e, 0x4c, 0xf6, 0x9c, 0x3a, 0x2a, 0x22, 0xba, 0x45, 0x09,
+	0xe1, 0x71, 0xe8, 0x0f, 0x42, 0x6e, 0x51, 0x84, 0x07, 0xd1, 0x03, 0xd2, 0xb8, 0x6d, 0xd6, 0x9f,
+	0xc1, 0xca, 0x50, 0x44, 0x77, 0x8b, 0xf4, 0x5f, 0xc1, 0xea, 0x88, 0xd0, 0xef, 0x16, 0x05, 0x58,
+	0x90, 0x1e, 0x1f, 0x1a, 0xa2, 0x47, 0x20, 0x37, 0xed, 0x13, 0x22, 0x54, 0xec, 0xcc, 0x12, 0xf4,
+	0xb1, 0x37, 0xa9, 0x9e, 0x10, 0x86, 0xd7, 0x6a, 0xb0, 0x3e, 0x32, 0x2c, 0xbc, 0x31, 0x82, 0xbb,
+	0x10, 0x0f, 0x85, 0x88, 0xf4, 0xe9, 0xe6, 0xa4, 0xdb, 0x6a, 0xd1, 0x4b, 0xa9, 0xc7, 0xdf, 0xef,
+	0xa2, 0x46, 0x8c, 0x96, 0xd0, 0x47, 0x64, 0xfc, 0xd6, 0xff, 0x01, 0xf4, 0xde, 0x6c, 0x51, 0x1c,
+	0x96, 0x8e, 0x4a, 0x1f, 0x95, 0xca, 0x1f, 0x97, 0x94, 0x39, 0x04, 0xb0, 0xf8, 0x44, 0x7f, 0x92,
+	0xd3, 0x0d, 0x45, 0x42, 0x09, 0x88, 0x96, 0x73, 0x15, 0xdd, 0x78, 0xaa, 0x1b, 0xca, 0x3c, 0x6d,
+	0xf6, 0x71, 0xb1, 0x5a, 0xd2, 0x2b, 0x15, 0x65, 0xe1, 0xad, 0x5f, 0x2f, 0x40, 0x2c, 0x78, 0xd7,
+	0x40, 0x2b, 0x90, 0x14, 0x16, 0x6a, 0xfa, 0x53, 0xbd, 0x54, 0x55, 0xe6, 0x90, 0x02, 0x09, 0x6e,
+	0xa7, 0x66, 0xe8, 0xd9, 0xc2, 0xa7, 0x8a, 0x84, 0x10, 0xa4, 0x0e, 0xf4, 0x6c, 0x41, 0x37, 0x6a,
+	0x47, 0x87, 0x85, 0x6c, 0x55, 0x2f, 0x28, 0xf3, 0x68, 0x03, 0x10, 0x6f, 0x55, 0xd9, 0x2f, 0x1e,
+	0xd6, 0xf2, 0xfb, 0xd9, 0xd2, 0x9e, 0x5e, 0x50, 0x16, 0xd0, 0x2b, 0xb0, 0x5e, 0xd1, 0x4b, 0x85,
+	0x5a, 0xa5, 0x94, 0x3d, 0xac, 0xec, 0x97, 0xab, 0xb5, 0x4a, 0x35, 0x6b, 0x50, 0x88, 0x8c, 0xfe,
+	0x0b, 0xee, 0xf4, 0x57, 0xe5, 0xcb, 0x4f, 0x0e, 0x0f, 0x74, 0x5a, 0x19, 0x19, 0xc6, 0x65, 0x73,
+	0x65, 0x86, 0x5b, 0x44, 0xeb, 0xb0, 0x12, 0x94, 0x1a, 0x7a, 0x5e, 0x2f, 0x3e, 0xd5, 0x0b, 0xca,
+	0x12, 0x55, 0x10, 0x2e, 0x2e, 0x3f, 0xd5, 0x0d, 0xbd, 0xa0, 0x44, 0xd1, 0x1a, 0x28, 0x3d, 0x06,
+	0x43, 0x67, 0x7a, 0x63, 0x7d, 0xad, 0x29, 0x6f, 0x36, 0x4f, 0xcb, 0x81, 0x0e, 0xc0, 0x41, 0x79,
+	0x2f, 0x54, 0x14, 0x47, 0xab, 0xb0, 0x7c, 0x50, 0xde, 0x2b, 0xe4, 0x42, 0x85, 0x09, 0x94, 0x86,
+	0x8d, 0x7c, 0xb9, 0x54, 0xd2, 0xf3, 0xd5, 0x62, 0xb9, 0x54, 0xd3, 0x2b, 0xd5, 0x6c, 0xee, 0xa0,
+	0x58, 0xd9, 0xd7, 0x0b, 0x4a, 0x92, 0x0a, 0x0c, 0xd5, 0x3d, 0xca, 0x16, 0x0f, 0xf4, 0x82, 0x92,
+	0xa2, 0x13, 0x62, 0xe8, 0x95, 0x4f, 0x4b, 0x79, 0x65, 0x99, 0xda, 0x14, 0x83, 0x7a, 0x54, 0x3a,
+	0x28, 0x67, 0x0b, 0x7a, 0x41, 0x51, 0xa8, 0xa6, 0xfd, 0x72, 0xa5, 0x5a, 0xab, 0xec, 0x1f, 0x55,
+	0xab, 0xc5, 0xd2, 0x5e, 0xad, 0x40, 0x67, 0x72, 0x65, 0xf7, 0xef, 0x12, 0xc4, 0x44, 0xee, 0x17,
+	0x3b, 0xa8, 0x03, 0x4b, 0x3c, 0x03, 0x8c, 0xd1, 0x84, 0xab, 0xe3, 0xe8, 0x74, 0x74, 0xfa, 0xc1,
+	0x15, 0x10, 0xfc, 0xad, 0xed, 0xbe, 0x84, 0xce, 0x60, 0x79, 0x20, 0xf5, 0x3b, 0x89, 0x79, 0x74,
+	0xca, 0x39, 0xfd, 0xe0, 0x0a, 0x08, 0xce, 0xbc, 0xfb, 0x37, 0x00, 0x99, 0x3e, 0x3c, 0x23, 0x0b,
+	0x62, 0x41, 0xa2, 0x17, 0x4d, 0x78, 0xa4, 0x1a, 0x4c, 0x32, 0xa7, 0xdf, 0x9e, 0xa9, 0x2d, 0xa7,
+	0x43, 0x47, 0x20, 0xd3, 0x3c, 0x2f, 0x9a, 0xb0, 0x45, 0x85, 0x32, 0xcb, 0xe9, 0x37, 0xa6, 0x35,
+	0x13, 0x66, 0x3f, 0x81, 0x08, 0xcb, 0xf5, 0xa2, 0x37, 0x26, 0x3e, 0x85, 0x05, 0xf9, 0xe4, 0xf4,
+	0x9b, 0x53, 0xdb, 0x09, 0xcb, 0x16, 0xc4, 0x82, 0x64, 0xeb, 0xa4, 0x61, 0x19, 0x4c, 0x2c, 0xa7,
+	0xdf, 0x9e, 0xa9, 0xad, 0x60, 0x69, 0x43, 0x22, 0x9c, 0x3c, 0x45, 0xef, 0x4c, 0xba, 0xdb, 0x0e,
+	0x65, 0x79, 0xd3, 0x99, 0x59, 0x9b, 0x0b, 0xba, 0x0e, 0x24, 0xfb, 0x12, 0xa2, 0x68, 0x82, 0x81,
+	0x51, 0xb9, 0xdb, 0xf4, 0xbd, 0x99, 0xdb, 0x0b, 0xc6, 0xaf, 0x00, 0x0d, 0xa7, 0x33, 0xd1, 0xbb,
+	0xe3, 0xcd, 0x8c, 0x4d, 0xcb, 0xa6, 0x1f, 0x5e, 0x0d, 0x24, 0x04, 0xb8, 0x90, 0xea, 0xcf, 0x72,
+	0xa2, 0x7b, 0x13, 0x17, 0xcb, 0x70, 0x6e, 0x35, 0x7d, 0x7f, 0x76, 0x80, 0x20, 0x6d, 0x00, 0xf4,
+	0x12, 0x9d, 0x68, 0xd2, 0x33, 0xd1, 0x60, 0x8e, 0x34, 0x7d, 0x77, 0xb6, 0xc6, 0x82, 0xe8, 0x27,
+	0x10, 0x0f, 0xa5, 0xa4, 0xd0, 0xdd, 0x89, 0x4a, 0x07, 0xf2, 0x59, 0xe9, 0x77, 0x66, 0x6c, 0x2d,
+	0xb8, 0xba, 0x90, 0xea, 0xcf, 0x9b, 0x4c, 0x1a, 0xc9, 0x91, 0x39, 0x9e, 0xf4, 0xfd, 0xd9, 0x01,
+	0xc1, 0x06, 0xd9, 0x85, 0x54, 0xb1, 0x3d, 0x2b, 0x6d, 0xb1, 0x7d, 0x45, 0xda, 0xd1, 0x39, 0x90,
+	0x1d, 0x09, 0x19, 0x10, 0x61, 0x49, 0x90, 0x49, 0x3b, 0x4b, 0x38, 0x4b, 0x92, 0xde, 0x9a, 0x92,
+	0xda, 0xb8, 0x2f, 0xe5, 0xd4, 0x6f, 0x2f, 0x37, 0xa5, 0xef, 0x2e, 0x37, 0xa5, 0xef, 0x2f, 0x37,
+	0xa5, 0x6f, 0x7e, 0xd8, 0x9c, 0xfb, 0xee, 0x87, 0xcd, 0xb9, 0xbf, 0xfc, 0xb0, 0x39, 0x77, 0xbc,
+	0xc8, 0x92, 0x13, 0xef, 0xfe, 0x73, 0x00, 0xbe, 0x51, 0xfb, 0x87, 0x74, 0x29, 0x00, 0x00,
 }
 
-func (m *SnapshotCompactedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// ForwarderClient is the client API for Forwarder service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ForwarderClient interface {
+	Propose(ctx context.Context, in *ForwardProposalRequest, opts ...grpc.CallOption) (Forwarder_ProposeClient, error)
+	GetAppliedIndex(ctx context.Context, in *GetAppliedIndexRequest, opts ...grpc.CallOption) (*GetAppliedIndexResponse, error)
+}
+
+type forwarderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewForwarderClient(cc *grpc.ClientConn) ForwarderClient {
+	return &forwarderClient{cc}
+}
+
+func (c *forwarderClient) Propose(ctx context.Context, in *ForwardProposalRequest, opts ...grpc.CallOption) (Forwarder_ProposeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Forwarder_serviceDesc.Streams[0], "/atomix.consensus.node.v1.Forwarder/Propose", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &forwarderProposeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *SnapshotCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Forwarder_ProposeClient interface {
+	Recv() (*ForwardProposalResponse, error)
+	grpc.ClientStream
 }
 
-func (m *SnapshotCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+type forwarderProposeClient struct {
+	grpc.ClientStream
+}
+
+func (x *forwarderProposeClient) Recv() (*ForwardProposalResponse, error) {
+	m := new(ForwardProposalResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *LogEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *forwarderClient) GetAppliedIndex(ctx context.Context, in *GetAppliedIndexRequest, opts ...grpc.CallOption) (*GetAppliedIndexResponse, error) {
+	out := new(GetAppliedIndexResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Forwarder/GetAppliedIndex", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *LogEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// ForwarderServer is the server API for Forwarder service.
+type ForwarderServer interface {
+	Propose(*ForwardProposalRequest, Forwarder_ProposeServer) error
+	GetAppliedIndex(context.Context, *GetAppliedIndexRequest) (*GetAppliedIndexResponse, error)
 }
 
-func (m *LogEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
-	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+// UnimplementedForwarderServer can be embedded to have forward compatible implementations.
+type UnimplementedForwarderServer struct {
 }
 
-func (m *LogCompactedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (*UnimplementedForwarderServer) Propose(req *ForwardProposalRequest, srv Forwarder_ProposeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Propose not implemented")
+}
+func (*UnimplementedForwarderServer) GetAppliedIndex(ctx context.Context, req *GetAppliedIndexRequest) (*GetAppliedIndexResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAppliedIndex not implemented")
+}
+
+func RegisterForwarderServer(s *grpc.Server, srv ForwarderServer) {
+	s.RegisterService(&_Forwarder_serviceDesc, srv)
+}
+
+func _Forwarder_Propose_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ForwardProposalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return dAtA[:n], nil
+	return srv.(ForwarderServer).Propose(m, &forwarderProposeServer{stream})
 }
 
-func (m *LogCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Forwarder_ProposeServer interface {
+	Send(*ForwardProposalResponse) error
+	grpc.ServerStream
 }
 
-func (m *LogCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
+type forwarderProposeServer struct {
+	grpc.ServerStream
+}
+
+func (x *forwarderProposeServer) Send(m *ForwardProposalResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Forwarder_GetAppliedIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppliedIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	if interceptor == nil {
+		return srv.(ForwarderServer).GetAppliedIndex(ctx, in)
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Forwarder/GetAppliedIndex",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwarderServer).GetAppliedIndex(ctx, req.(*GetAppliedIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LogDBCompactedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+var _Forwarder_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "atomix.consensus.node.v1.Forwarder",
+	HandlerType: (*ForwarderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAppliedIndex",
+			Handler:    _Forwarder_GetAppliedIndex_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Propose",
+			Handler:       _Forwarder_Propose_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "consensus/protocol.proto",
+}
+
+// NodeClient is the client API for Node service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type NodeClient interface {
+	Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error)
+	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
+	Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error)
+	AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error)
+	RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error)
+	PromoteMember(ctx context.Context, in *PromoteMemberRequest, opts ...grpc.CallOption) (*PromoteMemberResponse, error)
+	TransferLeadership(ctx context.Context, in *TransferLeadershipRequest, opts ...grpc.CallOption) (*TransferLeadershipResponse, error)
+	GetGroupStatus(ctx context.Context, in *GetGroupStatusRequest, opts ...grpc.CallOption) (*GetGroupStatusResponse, error)
+	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoResponse, error)
+	ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (Node_ExportSnapshotClient, error)
+	ImportSnapshot(ctx context.Context, opts ...grpc.CallOption) (Node_ImportSnapshotClient, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Node_WatchClient, error)
+}
+
+type nodeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNodeClient(cc *grpc.ClientConn) NodeClient {
+	return &nodeClient{cc}
+}
+
+func (c *nodeClient) Bootstrap(ctx context.Context, in *BootstrapRequest, opts ...grpc.CallOption) (*BootstrapResponse, error) {
+	out := new(BootstrapResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/Bootstrap", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *LogDBCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *nodeClient) Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error) {
+	out := new(JoinResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/Join", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *LogDBCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Index != 0 {
-		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
-		i--
-		dAtA[i] = 0x10
-	}
-	{
-		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (c *nodeClient) Leave(ctx context.Context, in *LeaveRequest, opts ...grpc.CallOption) (*LeaveResponse, error) {
+	out := new(LeaveResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *ConnectionEstablishedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *nodeClient) AddMember(ctx context.Context, in *AddMemberRequest, opts ...grpc.CallOption) (*AddMemberResponse, error) {
+	out := new(AddMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/AddMember", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *ConnectionEstablishedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *nodeClient) RemoveMember(ctx context.Context, in *RemoveMemberRequest, opts ...grpc.CallOption) (*RemoveMemberResponse, error) {
+	out := new(RemoveMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/RemoveMember", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *ConnectionEstablishedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.ConnectionInfo.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (c *nodeClient) PromoteMember(ctx context.Context, in *PromoteMemberRequest, opts ...grpc.CallOption) (*PromoteMemberResponse, error) {
+	out := new(PromoteMemberResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/PromoteMember", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *ConnectionFailedEvent) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *nodeClient) TransferLeadership(ctx context.Context, in *TransferLeadershipRequest, opts ...grpc.CallOption) (*TransferLeadershipResponse, error) {
+	out := new(TransferLeadershipResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/TransferLeadership", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *ConnectionFailedEvent) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *nodeClient) GetGroupStatus(ctx context.Context, in *GetGroupStatusRequest, opts ...grpc.CallOption) (*GetGroupStatusResponse, error) {
+	out := new(GetGroupStatusResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/GetGroupStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *ConnectionFailedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	{
-		size, err := m.ConnectionInfo.MarshalToSizedBuffer(dAtA[:i])
-		if err != nil {
-			return 0, err
-		}
-		i -= size
-		i = encodeVarintProtocol(dAtA, i, uint64(size))
+func (c *nodeClient) ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/ListGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	i--
-	dAtA[i] = 0xa
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func encodeVarintProtocol(dAtA []byte, offset int, v uint64) int {
-	offset -= sovProtocol(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (c *nodeClient) GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoResponse, error) {
+	out := new(GetNodeInfoResponse)
+	err := c.cc.Invoke(ctx, "/atomix.consensus.node.v1.Node/GetNodeInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return out, nil
 }
-func (m *GroupConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.GroupID != 0 {
-		n += 1 + sovProtocol(uint64(m.GroupID))
-	}
-	if m.MemberID != 0 {
-		n += 1 + sovProtocol(uint64(m.MemberID))
+
+func (c *nodeClient) ExportSnapshot(ctx context.Context, in *ExportSnapshotRequest, opts ...grpc.CallOption) (Node_ExportSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[0], "/atomix.consensus.node.v1.Node/ExportSnapshot", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Role != 0 {
-		n += 1 + sovProtocol(uint64(m.Role))
+	x := &nodeExportSnapshotClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if len(m.Members) > 0 {
-		for _, e := range m.Members {
-			l = e.Size()
-			n += 1 + l + sovProtocol(uint64(l))
-		}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	return n
+	return x, nil
 }
 
-func (m *MemberConfig) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MemberID != 0 {
-		n += 1 + sovProtocol(uint64(m.MemberID))
-	}
-	l = len(m.Host)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	if m.Port != 0 {
-		n += 1 + sovProtocol(uint64(m.Port))
-	}
-	return n
+type Node_ExportSnapshotClient interface {
+	Recv() (*ExportSnapshotResponse, error)
+	grpc.ClientStream
 }
 
-func (m *RaftProposal) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	if m.SequenceNum != 0 {
-		n += 1 + sovProtocol(uint64(m.SequenceNum))
-	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
-	}
-	return n
+type nodeExportSnapshotClient struct {
+	grpc.ClientStream
 }
 
-func (m *BootstrapRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (x *nodeExportSnapshotClient) Recv() (*ExportSnapshotResponse, error) {
+	m := new(ExportSnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.Group.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return m, nil
 }
 
-func (m *BootstrapResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *nodeClient) ImportSnapshot(ctx context.Context, opts ...grpc.CallOption) (Node_ImportSnapshotClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[1], "/atomix.consensus.node.v1.Node/ImportSnapshot", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	x := &nodeImportSnapshotClient{stream}
+	return x, nil
 }
 
-func (m *JoinRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.Group.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+type Node_ImportSnapshotClient interface {
+	Send(*ImportSnapshotRequest) error
+	CloseAndRecv() (*ImportSnapshotResponse, error)
+	grpc.ClientStream
 }
 
-func (m *JoinResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
+type nodeImportSnapshotClient struct {
+	grpc.ClientStream
 }
 
-func (m *LeaveRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (x *nodeImportSnapshotClient) Send(m *ImportSnapshotRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *nodeImportSnapshotClient) CloseAndRecv() (*ImportSnapshotResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.GroupID != 0 {
-		n += 1 + sovProtocol(uint64(m.GroupID))
+	m := new(ImportSnapshotResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return n
+	return m, nil
 }
 
-func (m *LeaveResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (c *nodeClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Node_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Node_serviceDesc.Streams[2], "/atomix.consensus.node.v1.Node/Watch", opts...)
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	x := &nodeWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *WatchRequest) Size() (n int) {
-	if m == nil {
-		return 0
+type Node_WatchClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type nodeWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *nodeWatchClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	return n
+	return m, nil
 }
 
-func (m *Event) Size() (n int) {
-	if m == nil {
-		return 0
+// NodeServer is the server API for Node service.
+type NodeServer interface {
+	Bootstrap(context.Context, *BootstrapRequest) (*BootstrapResponse, error)
+	Join(context.Context, *JoinRequest) (*JoinResponse, error)
+	Leave(context.Context, *LeaveRequest) (*LeaveResponse, error)
+	AddMember(context.Context, *AddMemberRequest) (*AddMemberResponse, error)
+	RemoveMember(context.Context, *RemoveMemberRequest) (*RemoveMemberResponse, error)
+	PromoteMember(context.Context, *PromoteMemberRequest) (*PromoteMemberResponse, error)
+	TransferLeadership(context.Context, *TransferLeadershipRequest) (*TransferLeadershipResponse, error)
+	GetGroupStatus(context.Context, *GetGroupStatusRequest) (*GetGroupStatusResponse, error)
+	ListGroups(context.Context, *ListGroupsRequest) (*ListGroupsResponse, error)
+	GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoResponse, error)
+	ExportSnapshot(*ExportSnapshotRequest, Node_ExportSnapshotServer) error
+	ImportSnapshot(Node_ImportSnapshotServer) error
+	Watch(*WatchRequest, Node_WatchServer) error
+}
+
+// UnimplementedNodeServer can be embedded to have forward compatible implementations.
+type UnimplementedNodeServer struct {
+}
+
+func (*UnimplementedNodeServer) Bootstrap(ctx context.Context, req *BootstrapRequest) (*BootstrapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bootstrap not implemented")
+}
+func (*UnimplementedNodeServer) Join(ctx context.Context, req *JoinRequest) (*JoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (*UnimplementedNodeServer) Leave(ctx context.Context, req *LeaveRequest) (*LeaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (*UnimplementedNodeServer) AddMember(ctx context.Context, req *AddMemberRequest) (*AddMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddMember not implemented")
+}
+func (*UnimplementedNodeServer) RemoveMember(ctx context.Context, req *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveMember not implemented")
+}
+func (*UnimplementedNodeServer) PromoteMember(ctx context.Context, req *PromoteMemberRequest) (*PromoteMemberResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteMember not implemented")
+}
+func (*UnimplementedNodeServer) TransferLeadership(ctx context.Context, req *TransferLeadershipRequest) (*TransferLeadershipResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferLeadership not implemented")
+}
+func (*UnimplementedNodeServer) GetGroupStatus(ctx context.Context, req *GetGroupStatusRequest) (*GetGroupStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroupStatus not implemented")
+}
+func (*UnimplementedNodeServer) ListGroups(ctx context.Context, req *ListGroupsRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (*UnimplementedNodeServer) GetNodeInfo(ctx context.Context, req *GetNodeInfoRequest) (*GetNodeInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
+}
+func (*UnimplementedNodeServer) ExportSnapshot(req *ExportSnapshotRequest, srv Node_ExportSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportSnapshot not implemented")
+}
+func (*UnimplementedNodeServer) ImportSnapshot(srv Node_ImportSnapshotServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportSnapshot not implemented")
+}
+func (*UnimplementedNodeServer) Watch(req *WatchRequest, srv Node_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+
+func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
+	s.RegisterService(&_Node_serviceDesc, srv)
+}
+
+func _Node_Bootstrap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Event != nil {
-		n += m.Event.Size()
+	if interceptor == nil {
+		return srv.(NodeServer).Bootstrap(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/Bootstrap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Bootstrap(ctx, req.(*BootstrapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *Event_MemberReady) Size() (n int) {
-	if m == nil {
-		return 0
+func _Node_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.MemberReady != nil {
-		l = m.MemberReady.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).Join(ctx, in)
 	}
-	return n
-}
-func (m *Event_LeaderUpdated) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/Join",
 	}
-	var l int
-	_ = l
-	if m.LeaderUpdated != nil {
-		l = m.LeaderUpdated.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Join(ctx, req.(*JoinRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Event_MembershipChanged) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.MembershipChanged != nil {
-		l = m.MembershipChanged.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).Leave(ctx, in)
 	}
-	return n
-}
-func (m *Event_SendSnapshotStarted) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/Leave",
 	}
-	var l int
-	_ = l
-	if m.SendSnapshotStarted != nil {
-		l = m.SendSnapshotStarted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).Leave(ctx, req.(*LeaveRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Event_SendSnapshotCompleted) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_AddMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.SendSnapshotCompleted != nil {
-		l = m.SendSnapshotCompleted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).AddMember(ctx, in)
 	}
-	return n
-}
-func (m *Event_SendSnapshotAborted) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/AddMember",
 	}
-	var l int
-	_ = l
-	if m.SendSnapshotAborted != nil {
-		l = m.SendSnapshotAborted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).AddMember(ctx, req.(*AddMemberRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Event_SnapshotReceived) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_RemoveMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.SnapshotReceived != nil {
-		l = m.SnapshotReceived.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).RemoveMember(ctx, in)
 	}
-	return n
-}
-func (m *Event_SnapshotRecovered) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/RemoveMember",
 	}
-	var l int
-	_ = l
-	if m.SnapshotRecovered != nil {
-		l = m.SnapshotRecovered.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).RemoveMember(ctx, req.(*RemoveMemberRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Event_SnapshotCreated) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_PromoteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.SnapshotCreated != nil {
-		l = m.SnapshotCreated.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).PromoteMember(ctx, in)
 	}
-	return n
-}
-func (m *Event_SnapshotCompacted) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/PromoteMember",
 	}
-	var l int
-	_ = l
-	if m.SnapshotCompacted != nil {
-		l = m.SnapshotCompacted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).PromoteMember(ctx, req.(*PromoteMemberRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Event_LogCompacted) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_TransferLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.LogCompacted != nil {
-		l = m.LogCompacted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).TransferLeadership(ctx, in)
 	}
-	return n
-}
-func (m *Event_LogdbCompacted) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/TransferLeadership",
 	}
-	var l int
-	_ = l
-	if m.LogdbCompacted != nil {
-		l = m.LogdbCompacted.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).TransferLeadership(ctx, req.(*TransferLeadershipRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *Event_ConnectionEstablished) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_GetGroupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGroupStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.ConnectionEstablished != nil {
-		l = m.ConnectionEstablished.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).GetGroupStatus(ctx, in)
 	}
-	return n
-}
-func (m *Event_ConnectionFailed) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/GetGroupStatus",
 	}
-	var l int
-	_ = l
-	if m.ConnectionFailed != nil {
-		l = m.ConnectionFailed.Size()
-		n += 1 + l + sovProtocol(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetGroupStatus(ctx, req.(*GetGroupStatusRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
-func (m *ConnectionInfo) Size() (n int) {
-	if m == nil {
-		return 0
+
+func _Node_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.Address)
-	if l > 0 {
-		n += 1 + l + sovProtocol(uint64(l))
+	if interceptor == nil {
+		return srv.(NodeServer).ListGroups(ctx, in)
 	}
-	if m.Snapshot {
-		n += 2
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/ListGroups",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).ListGroups(ctx, req.(*ListGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *MemberEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func _Node_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if m.GroupID != 0 {
-		n += 1 + sovProtocol(uint64(m.GroupID))
+	if interceptor == nil {
+		return srv.(NodeServer).GetNodeInfo(ctx, in)
 	}
-	if m.MemberID != 0 {
-		n += 1 + sovProtocol(uint64(m.MemberID))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/atomix.consensus.node.v1.Node/GetNodeInfo",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).GetNodeInfo(ctx, req.(*GetNodeInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *MemberReadyEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func _Node_ExportSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportSnapshotRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return srv.(NodeServer).ExportSnapshot(m, &nodeExportSnapshotServer{stream})
 }
 
-func (m *MembershipChangedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+type Node_ExportSnapshotServer interface {
+	Send(*ExportSnapshotResponse) error
+	grpc.ServerStream
 }
 
-func (m *LeaderUpdatedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Term != 0 {
-		n += 1 + sovProtocol(uint64(m.Term))
-	}
-	if m.Leader != 0 {
-		n += 1 + sovProtocol(uint64(m.Leader))
-	}
-	return n
+type nodeExportSnapshotServer struct {
+	grpc.ServerStream
 }
 
-func (m *SendSnapshotStartedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	if m.To != 0 {
-		n += 1 + sovProtocol(uint64(m.To))
-	}
-	return n
+func (x *nodeExportSnapshotServer) Send(m *ExportSnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *SendSnapshotCompletedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	if m.To != 0 {
-		n += 1 + sovProtocol(uint64(m.To))
-	}
-	return n
+func _Node_ImportSnapshot_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NodeServer).ImportSnapshot(&nodeImportSnapshotServer{stream})
 }
 
-func (m *SendSnapshotAbortedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	if m.To != 0 {
-		n += 1 + sovProtocol(uint64(m.To))
-	}
-	return n
+type Node_ImportSnapshotServer interface {
+	SendAndClose(*ImportSnapshotResponse) error
+	Recv() (*ImportSnapshotRequest, error)
+	grpc.ServerStream
 }
 
-func (m *SnapshotReceivedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	if m.From != 0 {
-		n += 1 + sovProtocol(uint64(m.From))
-	}
-	return n
+type nodeImportSnapshotServer struct {
+	grpc.ServerStream
 }
 
-func (m *SnapshotRecoveredEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (x *nodeImportSnapshotServer) SendAndClose(m *ImportSnapshotResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *nodeImportSnapshotServer) Recv() (*ImportSnapshotRequest, error) {
+	m := new(ImportSnapshotRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	return n
+	return m, nil
 }
 
-func (m *SnapshotCreatedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+func _Node_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return n
+	return srv.(NodeServer).Watch(m, &nodeWatchServer{stream})
 }
 
-func (m *SnapshotCompactedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	return n
+type Node_WatchServer interface {
+	Send(*Event) error
+	grpc.ServerStream
 }
 
-func (m *LogEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	return n
+type nodeWatchServer struct {
+	grpc.ServerStream
 }
 
-func (m *LogCompactedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
-	}
-	return n
+func (x *nodeWatchServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *LogDBCompactedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+var _Node_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "atomix.consensus.node.v1.Node",
+	HandlerType: (*NodeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Bootstrap",
+			Handler:    _Node_Bootstrap_Handler,
+		},
+		{
+			MethodName: "Join",
+			Handler:    _Node_Join_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _Node_Leave_Handler,
+		},
+		{
+			MethodName: "AddMember",
+			Handler:    _Node_AddMember_Handler,
+		},
+		{
+			MethodName: "RemoveMember",
+			Handler:    _Node_RemoveMember_Handler,
+		},
+		{
+			MethodName: "PromoteMember",
+			Handler:    _Node_PromoteMember_Handler,
+		},
+		{
+			MethodName: "TransferLeadership",
+			Handler:    _Node_TransferLeadership_Handler,
+		},
+		{
+			MethodName: "GetGroupStatus",
+			Handler:    _Node_GetGroupStatus_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _Node_ListGroups_Handler,
+		},
+		{
+			MethodName: "GetNodeInfo",
+			Handler:    _Node_GetNodeInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportSnapshot",
+			Handler:       _Node_ExportSnapshot_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportSnapshot",
+			Handler:       _Node_ImportSnapshot_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Node_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "consensus/protocol.proto",
+}
+
+func (m *GroupConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *GroupConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GroupConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.MemberEvent.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	if m.Index != 0 {
-		n += 1 + sovProtocol(uint64(m.Index))
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
 	}
-	return n
+	if m.Role != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ConnectionEstablishedEvent) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *MemberConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = m.ConnectionInfo.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ConnectionFailedEvent) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *MemberConfig) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = m.ConnectionInfo.Size()
-	n += 1 + l + sovProtocol(uint64(l))
-	return n
+	if m.Port != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Port))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Host) > 0 {
+		i -= len(m.Host)
+		copy(dAtA[i:], m.Host)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Host)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func sovProtocol(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *RaftProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozProtocol(x uint64) (n int) {
-	return sovProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *RaftProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (m *GroupConfig) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
+
+func (m *RaftProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Batch) > 0 {
+		for iNdEx := len(m.Batch) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Batch[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.TraceContext) > 0 {
+		for k := range m.TraceContext {
+			v := m.TraceContext[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintProtocol(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintProtocol(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintProtocol(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.SequenceNum != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SequenceNum))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RaftProposalResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RaftProposalResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RaftProposalResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Complete {
+		i--
+		if m.Complete {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Outputs) > 0 {
+		for iNdEx := len(m.Outputs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Outputs[iNdEx])
+			copy(dAtA[i:], m.Outputs[iNdEx])
+			i = encodeVarintProtocol(dAtA, i, uint64(len(m.Outputs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.SequenceNum != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SequenceNum))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaderHint) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaderHint) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaderHint) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Port != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Port))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Host) > 0 {
+		i -= len(m.Host)
+		copy(dAtA[i:], m.Host)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Host)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Leader != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Leader))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForwardProposalRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForwardProposalRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForwardProposalRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Input) > 0 {
+		i -= len(m.Input)
+		copy(dAtA[i:], m.Input)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Input)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ForwardProposalResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ForwardProposalResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ForwardProposalResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Output) > 0 {
+		i -= len(m.Output)
+		copy(dAtA[i:], m.Output)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Output)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAppliedIndexRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAppliedIndexRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAppliedIndexRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetAppliedIndexResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetAppliedIndexResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetAppliedIndexResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BootstrapRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *BootstrapResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *JoinRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JoinRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *JoinResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *JoinResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *JoinResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaveResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaveResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *AddMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Role != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x18
+	}
+	{
+		size, err := m.Member.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AddMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AddMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AddMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RemoveMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RemoveMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RemoveMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *PromoteMemberRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PromoteMemberRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PromoteMemberRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PromoteMemberResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PromoteMemberResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PromoteMemberResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferLeadershipRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeadershipRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferLeadershipRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TargetMemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.TargetMemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferLeadershipResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferLeadershipResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferLeadershipResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetGroupStatusRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetGroupStatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetGroupStatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetGroupStatusResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetGroupStatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetGroupStatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Group.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ListGroupsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListGroupsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListGroupsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ListGroupsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListGroupsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListGroupsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Groups) > 0 {
+		for iNdEx := len(m.Groups) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Groups[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GroupStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GroupStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GroupStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Witnesses) > 0 {
+		for iNdEx := len(m.Witnesses) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Witnesses[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.Observers) > 0 {
+		for iNdEx := len(m.Observers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Observers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if m.SnapshotIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.SnapshotIndex))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.AppliedIndex != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.AppliedIndex))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Leader != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Leader))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Role != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Role))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GetNodeInfoRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNodeInfoRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetNodeInfoRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *GetNodeInfoResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetNodeInfoResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GetNodeInfoResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Node.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *NodeInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for iNdEx := len(m.Logs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Logs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.HostID) > 0 {
+		i -= len(m.HostID)
+		copy(dAtA[i:], m.HostID)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.HostID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberLogInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberLogInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberLogInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportSnapshotRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Time != nil {
+		n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.Time):])
+		if err6 != nil {
+			return 0, err6
+		}
+		i -= n6
+		i = encodeVarintProtocol(dAtA, i, uint64(n6))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExportSnapshotResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportSnapshotResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportSnapshotResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TotalSize != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.TotalSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportSnapshotRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportSnapshotRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportSnapshotRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportSnapshotResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportSnapshotResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportSnapshotResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FromSequence != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.FromSequence))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.EventTypes) > 0 {
+		dAtA8 := make([]byte, len(m.EventTypes)*10)
+		var j7 int
+		for _, num := range m.EventTypes {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintProtocol(dAtA, i, uint64(j7))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.GroupIDs) > 0 {
+		dAtA10 := make([]byte, len(m.GroupIDs)*10)
+		var j9 int
+		for _, num := range m.GroupIDs {
+			for num >= 1<<7 {
+				dAtA10[j9] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j9++
+			}
+			dAtA10[j9] = uint8(num)
+			j9++
+		}
+		i -= j9
+		copy(dAtA[i:], dAtA10[:j9])
+		i = encodeVarintProtocol(dAtA, i, uint64(j9))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Event != nil {
+		{
+			size := m.Event.Size()
+			i -= size
+			if _, err := m.Event.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if len(m.HostID) > 0 {
+		i -= len(m.HostID)
+		copy(dAtA[i:], m.HostID)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.HostID)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
+	}
+	n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err11 != nil {
+		return 0, err11
+	}
+	i -= n11
+	i = encodeVarintProtocol(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *Event_MemberReady) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_MemberReady) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MemberReady != nil {
+		{
+			size, err := m.MemberReady.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_LeaderUpdated) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_LeaderUpdated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LeaderUpdated != nil {
+		{
+			size, err := m.LeaderUpdated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_MembershipChanged) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_MembershipChanged) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MembershipChanged != nil {
+		{
+			size, err := m.MembershipChanged.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SendSnapshotStarted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SendSnapshotStarted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SendSnapshotStarted != nil {
+		{
+			size, err := m.SendSnapshotStarted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SendSnapshotCompleted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SendSnapshotCompleted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SendSnapshotCompleted != nil {
+		{
+			size, err := m.SendSnapshotCompleted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SendSnapshotAborted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SendSnapshotAborted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SendSnapshotAborted != nil {
+		{
+			size, err := m.SendSnapshotAborted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SnapshotReceived) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SnapshotReceived) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotReceived != nil {
+		{
+			size, err := m.SnapshotReceived.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SnapshotRecovered) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SnapshotRecovered) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotRecovered != nil {
+		{
+			size, err := m.SnapshotRecovered.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SnapshotCreated) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SnapshotCreated) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotCreated != nil {
+		{
+			size, err := m.SnapshotCreated.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_SnapshotCompacted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_SnapshotCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SnapshotCompacted != nil {
+		{
+			size, err := m.SnapshotCompacted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_LogCompacted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_LogCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LogCompacted != nil {
+		{
+			size, err := m.LogCompacted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_LogdbCompacted) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_LogdbCompacted) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.LogdbCompacted != nil {
+		{
+			size, err := m.LogdbCompacted.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_ConnectionEstablished) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_ConnectionEstablished) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ConnectionEstablished != nil {
+		{
+			size, err := m.ConnectionEstablished.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_ConnectionFailed) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_ConnectionFailed) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ConnectionFailed != nil {
+		{
+			size, err := m.ConnectionFailed.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_Resync) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_Resync) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Resync != nil {
+		{
+			size, err := m.Resync.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_MemberUnloaded) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_MemberUnloaded) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.MemberUnloaded != nil {
+		{
+			size, err := m.MemberUnloaded.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Event_HostShuttingDown) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_HostShuttingDown) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HostShuttingDown != nil {
+		{
+			size, err := m.HostShuttingDown.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProtocol(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ConnectionInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Snapshot {
+		i--
+		if m.Snapshot {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Address) > 0 {
+		i -= len(m.Address)
+		copy(dAtA[i:], m.Address)
+		i = encodeVarintProtocol(dAtA, i, uint64(len(m.Address)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.MemberID))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberReadyEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberReadyEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberReadyEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *MemberUnloadedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MemberUnloadedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MemberUnloadedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *HostShuttingDownEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HostShuttingDownEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HostShuttingDownEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for iNdEx := len(m.Members) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Members[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProtocol(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MembershipChangedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MembershipChangedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MembershipChangedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaderUpdatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaderUpdatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaderUpdatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Leader != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Leader))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Term != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Term))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SendSnapshotStartedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SendSnapshotStartedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SendSnapshotStartedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.To != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.To))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SendSnapshotCompletedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SendSnapshotCompletedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SendSnapshotCompletedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.To != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.To))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SendSnapshotAbortedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SendSnapshotAbortedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SendSnapshotAbortedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.To != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.To))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotReceivedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotReceivedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotReceivedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.From != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.From))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotRecoveredEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotRecoveredEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotRecoveredEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotCreatedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotCreatedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotCreatedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *SnapshotCompactedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SnapshotCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SnapshotCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LogEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LogCompactedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *LogDBCompactedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogDBCompactedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LogDBCompactedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintProtocol(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	{
+		size, err := m.MemberEvent.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectionEstablishedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionEstablishedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionEstablishedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.ConnectionInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ConnectionFailedEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConnectionFailedEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConnectionFailedEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.ConnectionInfo.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintProtocol(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ResyncEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResyncEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResyncEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FullState {
+		i--
+		if m.FullState {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintProtocol(dAtA []byte, offset int, v uint64) int {
+	offset -= sovProtocol(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *GroupConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	if m.Role != 0 {
+		n += 1 + sovProtocol(uint64(m.Role))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MemberConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovProtocol(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *RaftProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.SequenceNum != 0 {
+		n += 1 + sovProtocol(uint64(m.SequenceNum))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if len(m.TraceContext) > 0 {
+		for k, v := range m.TraceContext {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovProtocol(uint64(len(k))) + 1 + len(v) + sovProtocol(uint64(len(v)))
+			n += mapEntrySize + 1 + sovProtocol(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Batch) > 0 {
+		for _, e := range m.Batch {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *RaftProposalResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.SequenceNum != 0 {
+		n += 1 + sovProtocol(uint64(m.SequenceNum))
+	}
+	if len(m.Outputs) > 0 {
+		for _, b := range m.Outputs {
+			l = len(b)
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if m.Complete {
+		n += 2
+	}
+	return n
+}
+
+func (m *LeaderHint) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Leader != 0 {
+		n += 1 + sovProtocol(uint64(m.Leader))
+	}
+	l = len(m.Host)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Port != 0 {
+		n += 1 + sovProtocol(uint64(m.Port))
+	}
+	return n
+}
+
+func (m *ForwardProposalRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	l = len(m.Input)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *ForwardProposalResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Output)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *GetAppliedIndexRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	return n
+}
+
+func (m *GetAppliedIndexResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *BootstrapRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Group.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *BootstrapResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *JoinRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Group.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *JoinResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *LeaveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	return n
+}
+
+func (m *LeaveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *AddMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	l = m.Member.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Role != 0 {
+		n += 1 + sovProtocol(uint64(m.Role))
+	}
+	return n
+}
+
+func (m *AddMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RemoveMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	return n
+}
+
+func (m *RemoveMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *PromoteMemberRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	return n
+}
+
+func (m *PromoteMemberResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TransferLeadershipRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.TargetMemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.TargetMemberID))
+	}
+	return n
+}
+
+func (m *TransferLeadershipResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetGroupStatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	return n
+}
+
+func (m *GetGroupStatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Group.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *ListGroupsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListGroupsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Groups) > 0 {
+		for _, e := range m.Groups {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GroupStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	if m.Role != 0 {
+		n += 1 + sovProtocol(uint64(m.Role))
+	}
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Leader != 0 {
+		n += 1 + sovProtocol(uint64(m.Leader))
+	}
+	if m.AppliedIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.AppliedIndex))
+	}
+	if m.SnapshotIndex != 0 {
+		n += 1 + sovProtocol(uint64(m.SnapshotIndex))
+	}
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if len(m.Observers) > 0 {
+		for _, e := range m.Observers {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	if len(m.Witnesses) > 0 {
+		for _, e := range m.Witnesses {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *GetNodeInfoRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetNodeInfoResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Node.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *NodeInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.HostID)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if len(m.Logs) > 0 {
+		for _, e := range m.Logs {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MemberLogInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	return n
+}
+
+func (m *ExportSnapshotRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.Time != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.Time)
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportSnapshotResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.TotalSize != 0 {
+		n += 1 + sovProtocol(uint64(m.TotalSize))
+	}
+	return n
+}
+
+func (m *ImportSnapshotRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	return n
+}
+
+func (m *ImportSnapshotResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *WatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.GroupIDs) > 0 {
+		l = 0
+		for _, e := range m.GroupIDs {
+			l += sovProtocol(uint64(e))
+		}
+		n += 1 + sovProtocol(uint64(l)) + l
+	}
+	if len(m.EventTypes) > 0 {
+		l = 0
+		for _, e := range m.EventTypes {
+			l += sovProtocol(uint64(e))
+		}
+		n += 1 + sovProtocol(uint64(l)) + l
+	}
+	if m.FromSequence != 0 {
+		n += 1 + sovProtocol(uint64(m.FromSequence))
+	}
+	return n
+}
+
+func (m *Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Event != nil {
+		n += m.Event.Size()
+	}
+	if m.Sequence != 0 {
+		n += 2 + sovProtocol(uint64(m.Sequence))
+	}
+	l = len(m.HostID)
+	if l > 0 {
+		n += 2 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+
+func (m *Event_MemberReady) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberReady != nil {
+		l = m.MemberReady.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_LeaderUpdated) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LeaderUpdated != nil {
+		l = m.LeaderUpdated.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_MembershipChanged) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MembershipChanged != nil {
+		l = m.MembershipChanged.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SendSnapshotStarted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SendSnapshotStarted != nil {
+		l = m.SendSnapshotStarted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SendSnapshotCompleted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SendSnapshotCompleted != nil {
+		l = m.SendSnapshotCompleted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SendSnapshotAborted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SendSnapshotAborted != nil {
+		l = m.SendSnapshotAborted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SnapshotReceived) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotReceived != nil {
+		l = m.SnapshotReceived.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SnapshotRecovered) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotRecovered != nil {
+		l = m.SnapshotRecovered.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SnapshotCreated) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotCreated != nil {
+		l = m.SnapshotCreated.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_SnapshotCompacted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SnapshotCompacted != nil {
+		l = m.SnapshotCompacted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_LogCompacted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogCompacted != nil {
+		l = m.LogCompacted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_LogdbCompacted) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LogdbCompacted != nil {
+		l = m.LogdbCompacted.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_ConnectionEstablished) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConnectionEstablished != nil {
+		l = m.ConnectionEstablished.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_ConnectionFailed) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ConnectionFailed != nil {
+		l = m.ConnectionFailed.Size()
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_Resync) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Resync != nil {
+		l = m.Resync.Size()
+		n += 2 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_MemberUnloaded) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MemberUnloaded != nil {
+		l = m.MemberUnloaded.Size()
+		n += 2 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *Event_HostShuttingDown) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HostShuttingDown != nil {
+		l = m.HostShuttingDown.Size()
+		n += 2 + l + sovProtocol(uint64(l))
+	}
+	return n
+}
+func (m *ConnectionInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Address)
+	if l > 0 {
+		n += 1 + l + sovProtocol(uint64(l))
+	}
+	if m.Snapshot {
+		n += 2
+	}
+	return n
+}
+
+func (m *MemberEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GroupID != 0 {
+		n += 1 + sovProtocol(uint64(m.GroupID))
+	}
+	if m.MemberID != 0 {
+		n += 1 + sovProtocol(uint64(m.MemberID))
+	}
+	return n
+}
+
+func (m *MemberReadyEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *MemberUnloadedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *HostShuttingDownEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Members) > 0 {
+		for _, e := range m.Members {
+			l = e.Size()
+			n += 1 + l + sovProtocol(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MembershipChangedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *LeaderUpdatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Term != 0 {
+		n += 1 + sovProtocol(uint64(m.Term))
+	}
+	if m.Leader != 0 {
+		n += 1 + sovProtocol(uint64(m.Leader))
+	}
+	return n
+}
+
+func (m *SendSnapshotStartedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.To != 0 {
+		n += 1 + sovProtocol(uint64(m.To))
+	}
+	return n
+}
+
+func (m *SendSnapshotCompletedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.To != 0 {
+		n += 1 + sovProtocol(uint64(m.To))
+	}
+	return n
+}
+
+func (m *SendSnapshotAbortedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.To != 0 {
+		n += 1 + sovProtocol(uint64(m.To))
+	}
+	return n
+}
+
+func (m *SnapshotReceivedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	if m.From != 0 {
+		n += 1 + sovProtocol(uint64(m.From))
+	}
+	return n
+}
+
+func (m *SnapshotRecoveredEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *SnapshotCreatedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *SnapshotCompactedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *LogEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *LogCompactedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *LogDBCompactedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.MemberEvent.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	if m.Index != 0 {
+		n += 1 + sovProtocol(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *ConnectionEstablishedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ConnectionInfo.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *ConnectionFailedEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.ConnectionInfo.Size()
+	n += 1 + l + sovProtocol(uint64(l))
+	return n
+}
+
+func (m *ResyncEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FullState {
+		n += 2
+	}
+	return n
+}
+
+func sovProtocol(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProtocol(x uint64) (n int) {
+	return sovProtocol(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *GroupConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= MemberRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, MemberConfig{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MemberConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNum", wireType)
+			}
+			m.SequenceNum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNum |= SequenceNum(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceContext", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TraceContext == nil {
+				m.TraceContext = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipProtocol(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthProtocol
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.TraceContext[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Batch = append(m.Batch, &RaftProposal{})
+			if err := m.Batch[len(m.Batch)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RaftProposalResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RaftProposalResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RaftProposalResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNum", wireType)
+			}
+			m.SequenceNum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNum |= SequenceNum(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Outputs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Outputs = append(m.Outputs, make([]byte, postIndex-iNdEx))
+			copy(m.Outputs[len(m.Outputs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Complete", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Complete = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaderHint) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaderHint: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaderHint: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			m.Leader = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Leader |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Host = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			m.Port = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Port |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForwardProposalRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForwardProposalRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForwardProposalRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Input = append(m.Input[:0], dAtA[iNdEx:postIndex]...)
+			if m.Input == nil {
+				m.Input = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ForwardProposalResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ForwardProposalResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ForwardProposalResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Output", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Output = append(m.Output[:0], dAtA[iNdEx:postIndex]...)
+			if m.Output == nil {
+				m.Output = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAppliedIndexRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAppliedIndexRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAppliedIndexRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetAppliedIndexResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetAppliedIndexResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetAppliedIndexResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BootstrapRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BootstrapResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *JoinResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: JoinResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: JoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Member", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Member.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+			}
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= MemberRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PromoteMemberRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PromoteMemberRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PromoteMemberRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PromoteMemberResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PromoteMemberResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PromoteMemberResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeadershipRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeadershipRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeadershipRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetMemberID", wireType)
+			}
+			m.TargetMemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TargetMemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferLeadershipResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferLeadershipResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferLeadershipResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetGroupStatusRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetGroupStatusRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetGroupStatusRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetGroupStatusResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetGroupStatusResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetGroupStatusResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListGroupsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListGroupsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListGroupsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListGroupsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListGroupsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListGroupsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Groups", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Groups = append(m.Groups, GroupStatus{})
+			if err := m.Groups[len(m.Groups)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GroupStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GroupStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GroupStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			m.Role = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Role |= MemberRole(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: GroupConfig: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GroupConfig: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Term = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Term |= Term(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
 			}
-			m.GroupID = 0
+			m.Leader = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -3816,16 +10395,16 @@ func (m *GroupConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.GroupID |= GroupID(b&0x7F) << shift
+				m.Leader |= MemberID(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AppliedIndex", wireType)
 			}
-			m.MemberID = 0
+			m.AppliedIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -3835,16 +10414,16 @@ func (m *GroupConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.MemberID |= MemberID(b&0x7F) << shift
+				m.AppliedIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Role", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotIndex", wireType)
 			}
-			m.Role = 0
+			m.SnapshotIndex = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -3854,14 +10433,216 @@ func (m *GroupConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Role |= MemberRole(b&0x7F) << shift
+				m.SnapshotIndex |= Index(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, MemberConfig{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Observers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Observers = append(m.Observers, MemberConfig{})
+			if err := m.Observers[len(m.Observers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Witnesses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Witnesses = append(m.Witnesses, MemberConfig{})
+			if err := m.Witnesses[len(m.Witnesses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetNodeInfoRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetNodeInfoRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetNodeInfoRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetNodeInfoResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetNodeInfoResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetNodeInfoResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Node", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3888,8 +10669,7 @@ func (m *GroupConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Members = append(m.Members, MemberConfig{})
-			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Node.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3914,7 +10694,7 @@ func (m *GroupConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MemberConfig) Unmarshal(dAtA []byte) error {
+func (m *NodeInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3937,34 +10717,15 @@ func (m *MemberConfig) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MemberConfig: wiretype end group for non-group")
+			return fmt.Errorf("proto: NodeInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MemberConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NodeInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
-			}
-			m.MemberID = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.MemberID |= MemberID(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Host", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HostID", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3992,13 +10753,13 @@ func (m *MemberConfig) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Host = string(dAtA[iNdEx:postIndex])
+			m.HostID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
 			}
-			m.Port = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4008,11 +10769,26 @@ func (m *MemberConfig) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Port |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, MemberLogInfo{})
+			if err := m.Logs[len(m.Logs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4034,7 +10810,7 @@ func (m *MemberConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RaftProposal) Unmarshal(dAtA []byte) error {
+func (m *MemberLogInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4057,17 +10833,17 @@ func (m *RaftProposal) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RaftProposal: wiretype end group for non-group")
+			return fmt.Errorf("proto: MemberLogInfo: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RaftProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MemberLogInfo: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
 			}
-			m.Term = 0
+			m.GroupID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4077,35 +10853,16 @@ func (m *RaftProposal) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Term |= Term(b&0x7F) << shift
+				m.GroupID |= GroupID(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNum", wireType)
-			}
-			m.SequenceNum = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowProtocol
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.SequenceNum |= SequenceNum(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
 			}
-			var byteLen int
+			m.MemberID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4115,26 +10872,11 @@ func (m *RaftProposal) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				m.MemberID |= MemberID(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
-			if m.Data == nil {
-				m.Data = []byte{}
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4156,7 +10898,7 @@ func (m *RaftProposal) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
+func (m *ExportSnapshotRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4179,15 +10921,34 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportSnapshotRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= GroupID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4214,7 +10975,10 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Time == nil {
+				m.Time = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.Time, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4239,7 +11003,7 @@ func (m *BootstrapRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
+func (m *ExportSnapshotResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4262,67 +11026,36 @@ func (m *BootstrapResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportSnapshotResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportSnapshotResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JoinRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= Index(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JoinRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JoinRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Group", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -4332,75 +11065,45 @@ func (m *JoinRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Group.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipProtocol(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthProtocol
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *JoinResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowProtocol
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalSize", wireType)
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.TotalSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalSize |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: JoinResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: JoinResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4422,7 +11125,7 @@ func (m *JoinResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
+func (m *ImportSnapshotRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4445,10 +11148,10 @@ func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LeaveRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportSnapshotRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportSnapshotRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -4470,6 +11173,59 @@ func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberID", wireType)
+			}
+			m.MemberID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MemberID |= MemberID(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -4491,7 +11247,7 @@ func (m *LeaveRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
+func (m *ImportSnapshotResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4514,10 +11270,10 @@ func (m *LeaveResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LeaveResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ImportSnapshotResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LeaveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ImportSnapshotResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		default:
@@ -4570,6 +11326,170 @@ func (m *WatchRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: WatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v GroupID
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= GroupID(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.GroupIDs = append(m.GroupIDs, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthProtocol
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthProtocol
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.GroupIDs) == 0 {
+					m.GroupIDs = make([]GroupID, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v GroupID
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= GroupID(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.GroupIDs = append(m.GroupIDs, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupIDs", wireType)
+			}
+		case 2:
+			if wireType == 0 {
+				var v EventType
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= EventType(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.EventTypes = append(m.EventTypes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowProtocol
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthProtocol
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthProtocol
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.EventTypes) == 0 {
+					m.EventTypes = make([]EventType, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v EventType
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowProtocol
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= EventType(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.EventTypes = append(m.EventTypes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventTypes", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromSequence", wireType)
+			}
+			m.FromSequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromSequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipProtocol(dAtA[iNdEx:])
@@ -5001,13 +11921,172 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &Event_SnapshotCompacted{v}
-			iNdEx = postIndex
-		case 12:
+			m.Event = &Event_SnapshotCompacted{v}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogCompacted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LogCompactedEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &Event_LogCompacted{v}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LogdbCompacted", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &LogDBCompactedEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &Event_LogdbCompacted{v}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionEstablished", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ConnectionEstablishedEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &Event_ConnectionEstablished{v}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionFailed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ConnectionFailedEvent{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Event = &Event_ConnectionFailed{v}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Sequence |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogCompacted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HostID", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowProtocol
@@ -5017,30 +12096,27 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthProtocol
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthProtocol
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &LogCompactedEvent{}
-			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			m.Event = &Event_LogCompacted{v}
+			m.HostID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 13:
+		case 18:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LogdbCompacted", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Resync", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5067,15 +12143,15 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &LogDBCompactedEvent{}
+			v := &ResyncEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &Event_LogdbCompacted{v}
+			m.Event = &Event_Resync{v}
 			iNdEx = postIndex
-		case 14:
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionEstablished", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberUnloaded", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5102,15 +12178,15 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ConnectionEstablishedEvent{}
+			v := &MemberUnloadedEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &Event_ConnectionEstablished{v}
+			m.Event = &Event_MemberUnloaded{v}
 			iNdEx = postIndex
-		case 15:
+		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionFailed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HostShuttingDown", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5137,11 +12213,11 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &ConnectionFailedEvent{}
+			v := &HostShuttingDownEvent{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Event = &Event_ConnectionFailed{v}
+			m.Event = &Event_HostShuttingDown{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -5437,6 +12513,173 @@ func (m *MemberReadyEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *MemberUnloadedEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MemberUnloadedEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MemberUnloadedEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MemberEvent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MemberEvent.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HostShuttingDownEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HostShuttingDownEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HostShuttingDownEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Members", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Members = append(m.Members, MemberEvent{})
+			if err := m.Members[len(m.Members)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *MembershipChangedEvent) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -6903,6 +14146,76 @@ func (m *ConnectionFailedEvent) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResyncEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProtocol
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResyncEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResyncEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FullState", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProtocol
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FullState = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProtocol(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProtocol
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipProtocol(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
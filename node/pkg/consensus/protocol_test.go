@@ -0,0 +1,445 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"fmt"
+	counterv1 "github.com/atomix/runtime/primitives/pkg/counter/v1"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"github.com/atomix/runtime/sdk/pkg/protocol/node"
+	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"net"
+	"testing"
+	"time"
+)
+
+// testCluster is a cluster of nodes hosted on distinct loopback addresses. As with the pods of a cluster, the
+// nodes share their Raft and API ports, so each node can reach the API server of the leader through its hint.
+type testCluster struct {
+	nodes   []*Protocol
+	hosts   []string
+	port    int
+	apiPort int
+}
+
+// newTestCluster starts a cluster of the given size. Each node serves the Node and Forwarder services.
+func newTestCluster(t *testing.T, size int, opts ...Option) *testCluster {
+	hosts := make([]string, size)
+	for i := range hosts {
+		hosts[i] = fmt.Sprintf("127.0.0.%d", i+1)
+	}
+	raftListeners := listenTestHosts(t, hosts)
+	port := raftListeners[0].Addr().(*net.TCPAddr).Port
+	for _, listener := range raftListeners {
+		listener.Close()
+	}
+	apiListeners := listenTestHosts(t, hosts)
+	apiPort := apiListeners[0].Addr().(*net.TCPAddr).Port
+
+	cluster := &testCluster{
+		hosts:   hosts,
+		port:    port,
+		apiPort: apiPort,
+	}
+	for i, host := range hosts {
+		dataDir := t.TempDir()
+		registry := statemachine.NewPrimitiveTypeRegistry()
+		counterv1.RegisterStateMachine(registry)
+		p := NewProtocol(RaftConfig{DataDir: &dataDir}, registry,
+			append([]Option{WithHost(host), WithPort(port), WithAPIPort(apiPort)}, opts...)...)
+		server := grpc.NewServer()
+		RegisterNodeServer(server, NewNodeServer(p))
+		RegisterForwarderServer(server, NewForwarderServer(p))
+		go server.Serve(apiListeners[i])
+		t.Cleanup(func() {
+			server.Stop()
+			p.Shutdown()
+		})
+		cluster.nodes = append(cluster.nodes, p)
+	}
+	return cluster
+}
+
+// listenTestHosts listens on the same free port on each of the given hosts
+func listenTestHosts(t *testing.T, hosts []string) []net.Listener {
+	for attempt := 0; attempt < 10; attempt++ {
+		listener, err := net.Listen("tcp", net.JoinHostPort(hosts[0], "0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		port := fmt.Sprint(listener.Addr().(*net.TCPAddr).Port)
+		listeners := []net.Listener{listener}
+		for _, host := range hosts[1:] {
+			listener, err := net.Listen("tcp", net.JoinHostPort(host, port))
+			if err != nil {
+				break
+			}
+			listeners = append(listeners, listener)
+		}
+		if len(listeners) == len(hosts) {
+			return listeners
+		}
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}
+	t.Fatal("no free port found")
+	return nil
+}
+
+// member returns the configuration of the member hosted by the given node
+func (c *testCluster) member(i int) MemberConfig {
+	return MemberConfig{
+		MemberID: MemberID(i + 1),
+		Host:     c.hosts[i],
+		Port:     int32(c.port),
+	}
+}
+
+// bootstrap bootstraps a group with a member on each of the given nodes, or on every node if none are given,
+// returning the node hosting the leader once it's elected
+func (c *testCluster) bootstrap(t *testing.T, groupID GroupID, nodes ...int) int {
+	if len(nodes) == 0 {
+		for i := range c.nodes {
+			nodes = append(nodes, i)
+		}
+	}
+	var members []MemberConfig
+	for _, i := range nodes {
+		members = append(members, c.member(i))
+	}
+	for _, i := range nodes {
+		err := c.nodes[i].Bootstrap(GroupConfig{
+			GroupID:  groupID,
+			MemberID: MemberID(i + 1),
+			Members:  members,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c.awaitLeader(t, groupID, nodes...)
+}
+
+// awaitLeader waits until the members of a group hosted by the given nodes agree on a leader that knows it
+// has been elected, returning the node hosting the leader
+func (c *testCluster) awaitLeader(t *testing.T, groupID GroupID, nodes ...int) int {
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		var leader MemberID
+		for _, i := range nodes {
+			_, memberLeader := c.partition(t, i, groupID).getLeader()
+			if memberLeader == 0 || (leader != 0 && memberLeader != leader) {
+				leader = 0
+				break
+			}
+			leader = memberLeader
+		}
+		if leader != 0 {
+			if partition, ok := c.getPartition(int(leader-1), groupID); ok {
+				if _, l := partition.getLeader(); l == leader {
+					return int(leader - 1)
+				}
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("leader of group %d not elected", groupID)
+	return 0
+}
+
+// partition returns the partition of the member of a group hosted by the given node once the member is started
+func (c *testCluster) partition(t *testing.T, i int, groupID GroupID) *Partition {
+	deadline := time.Now().Add(time.Minute)
+	for time.Now().Before(deadline) {
+		if partition, ok := c.getPartition(i, groupID); ok {
+			return partition
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("member of group %d not started on node %d", groupID, i)
+	return nil
+}
+
+func (c *testCluster) getPartition(i int, groupID GroupID) (*Partition, bool) {
+	c.nodes[i].mu.RLock()
+	defer c.nodes[i].mu.RUnlock()
+	partition, ok := c.nodes[i].partitions[protocol.PartitionID(groupID)]
+	return partition, ok
+}
+
+// newTestMemberPartition returns the partition of a local member that is not hosted by a node host
+func newTestMemberPartition(metrics *Metrics, groupID GroupID, memberID MemberID) *Partition {
+	return &Partition{
+		Partition: node.NewPartition(protocol.PartitionID(groupID), nil),
+		memberID:  memberID,
+		metrics:   metrics,
+	}
+}
+
+// openTestSession opens a session through the given partition, returning the session ID
+func openTestSession(t *testing.T, partition node.Partition) protocol.SessionID {
+	output, err := partition.Propose(context.Background(), newOpenSessionInput())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return output.Output.(*protocol.ProposalOutput_OpenSession).OpenSession.SessionID
+}
+
+// createTestCounter creates a counter through the given partition, returning the counter's primitive ID
+func createTestCounter(t *testing.T, partition node.Partition, sessionID protocol.SessionID, sequenceNum protocol.SequenceNum) protocol.PrimitiveID {
+	input := &protocol.ProposalInput{
+		Timestamp: time.Now(),
+		Input: &protocol.ProposalInput_Proposal{
+			Proposal: &protocol.SessionProposalInput{
+				SessionID:   sessionID,
+				SequenceNum: sequenceNum,
+				Input: &protocol.SessionProposalInput_CreatePrimitive{
+					CreatePrimitive: &protocol.CreatePrimitiveInput{
+						PrimitiveSpec: protocol.PrimitiveSpec{
+							Service:   counterv1.Service,
+							Namespace: "test",
+							Name:      "counter",
+						},
+					},
+				},
+			},
+		},
+	}
+	output, err := partition.Propose(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionOutput := output.Output.(*protocol.ProposalOutput_Proposal).Proposal
+	if sessionOutput.Failure != nil {
+		t.Fatalf("failed to create counter: %s", sessionOutput.Failure.Message)
+	}
+	return sessionOutput.Output.(*protocol.SessionProposalOutput_CreatePrimitive).CreatePrimitive.PrimitiveID
+}
+
+// newTestIncrementInput returns the input of a proposal incrementing a counter
+func newTestIncrementInput(t *testing.T, sessionID protocol.SessionID, sequenceNum protocol.SequenceNum, primitiveID protocol.PrimitiveID) *protocol.ProposalInput {
+	payload, err := proto.Marshal(&counterv1.CounterInput{
+		Input: &counterv1.CounterInput_Increment{
+			Increment: &counterv1.IncrementInput{
+				Delta: 1,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &protocol.ProposalInput{
+		Timestamp: time.Now(),
+		Input: &protocol.ProposalInput_Proposal{
+			Proposal: &protocol.SessionProposalInput{
+				SessionID:   sessionID,
+				SequenceNum: sequenceNum,
+				Input: &protocol.SessionProposalInput_Proposal{
+					Proposal: &protocol.PrimitiveProposalInput{
+						PrimitiveID: primitiveID,
+						Payload:     payload,
+					},
+				},
+			},
+		},
+	}
+}
+
+// incrementTestCounter proposes the given increment through the partition, returning the counter's new value
+func incrementTestCounter(t *testing.T, ctx context.Context, partition node.Partition, input *protocol.ProposalInput) int64 {
+	output, err := partition.Propose(ctx, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionOutput := output.Output.(*protocol.ProposalOutput_Proposal).Proposal
+	if sessionOutput.Failure != nil {
+		t.Fatalf("failed to increment counter: %s", sessionOutput.Failure.Message)
+	}
+	counterOutput := &counterv1.CounterOutput{}
+	if err := proto.Unmarshal(sessionOutput.Output.(*protocol.SessionProposalOutput_Proposal).Proposal.Payload, counterOutput); err != nil {
+		t.Fatal(err)
+	}
+	return counterOutput.GetIncrement().Value
+}
+
+// getTestCounter queries the value of a counter through the given partition
+func getTestCounter(ctx context.Context, partition node.Partition, sessionID protocol.SessionID, primitiveID protocol.PrimitiveID) (int64, error) {
+	payload, err := proto.Marshal(&counterv1.CounterInput{
+		Input: &counterv1.CounterInput_Get{
+			Get: &counterv1.GetInput{},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	input := &protocol.QueryInput{
+		Input: &protocol.QueryInput_Query{
+			Query: &protocol.SessionQueryInput{
+				SessionID: sessionID,
+				Input: &protocol.SessionQueryInput_Query{
+					Query: &protocol.PrimitiveQueryInput{
+						PrimitiveID: primitiveID,
+						Payload:     payload,
+					},
+				},
+			},
+		},
+	}
+	output, err := partition.Query(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	sessionOutput := output.Output.(*protocol.QueryOutput_Query).Query
+	if sessionOutput.Failure != nil {
+		return 0, fmt.Errorf("failed to get counter: %s", sessionOutput.Failure.Message)
+	}
+	counterOutput := &counterv1.CounterOutput{}
+	if err := proto.Unmarshal(sessionOutput.Output.(*protocol.SessionQueryOutput_Query).Query.Payload, counterOutput); err != nil {
+		return 0, err
+	}
+	return counterOutput.GetGet().Value, nil
+}
+
+// awaitTestGroupStatus waits until the status of a group on the given node satisfies the condition
+func awaitTestGroupStatus(t *testing.T, p *Protocol, groupID GroupID, condition func(status *GroupStatus) bool) *GroupStatus {
+	deadline := time.Now().Add(time.Minute)
+	var status *GroupStatus
+	for time.Now().Before(deadline) {
+		var err error
+		status, err = p.GetGroupStatus(context.Background(), groupID)
+		if err == nil && condition(status) {
+			return status
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("group %d did not reach the expected status: %v", groupID, status)
+	return nil
+}
+
+func hasTestMember(members []MemberConfig, memberID MemberID) bool {
+	for _, member := range members {
+		if member.MemberID == memberID {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMembershipChanges(t *testing.T) {
+	cluster := newTestCluster(t, 2)
+	cluster.bootstrap(t, 1, 0)
+	ctx := context.Background()
+	leader := cluster.nodes[0]
+
+	// Members join a group as observers to catch up before they're promoted to voting members
+	if err := leader.AddMember(ctx, 1, cluster.member(1), MemberRole_OBSERVER); err != nil {
+		t.Fatal(err)
+	}
+	if err := cluster.nodes[1].Join(GroupConfig{GroupID: 1, MemberID: 2, Role: MemberRole_OBSERVER}); err != nil {
+		t.Fatal(err)
+	}
+	if err := leader.AddMember(ctx, 1, cluster.member(1), MemberRole_OBSERVER); err != nil {
+		t.Fatalf("expected adding an existing observer to be a no-op, got %v", err)
+	}
+	awaitTestGroupStatus(t, cluster.nodes[1], 1, func(status *GroupStatus) bool {
+		return status.Role == MemberRole_OBSERVER && status.Leader == 1
+	})
+	status, err := leader.GetGroupStatus(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasTestMember(status.Observers, 2) || hasTestMember(status.Members, 2) {
+		t.Fatalf("expected member 2 to be an observer, got %v", status)
+	}
+
+	if err := leader.PromoteMember(ctx, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	status = awaitTestGroupStatus(t, leader, 1, func(status *GroupStatus) bool {
+		return hasTestMember(status.Members, 2)
+	})
+	if hasTestMember(status.Observers, 2) {
+		t.Errorf("expected promoted member to no longer be an observer, got %v", status)
+	}
+	if err := leader.PromoteMember(ctx, 1, 3); !errors.IsNotFound(err) {
+		t.Errorf("expected NotFound promoting an unknown observer, got %v", err)
+	}
+
+	if err := leader.RemoveMember(ctx, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	awaitTestGroupStatus(t, leader, 1, func(status *GroupStatus) bool {
+		return !hasTestMember(status.Members, 2)
+	})
+	if err := leader.RemoveMember(ctx, 1, 2); err != nil {
+		t.Errorf("expected removing a removed member to be a no-op, got %v", err)
+	}
+	// Removed member IDs cannot be reused, so the controller must add a replacement under a new ID
+	if err := leader.AddMember(ctx, 1, cluster.member(1), MemberRole_MEMBER); !errors.IsConflict(err) {
+		t.Errorf("expected Conflict adding a removed member, got %v", err)
+	}
+}
+
+func TestTransferLeadership(t *testing.T) {
+	cluster := newTestCluster(t, 3)
+	leader := cluster.bootstrap(t, 1)
+	target := (leader + 1) % len(cluster.nodes)
+
+	if err := cluster.nodes[leader].TransferLeadership(context.Background(), 1, MemberID(target+1)); err != nil {
+		t.Fatal(err)
+	}
+	if newLeader := cluster.awaitLeader(t, 1, 0, 1, 2); newLeader != target {
+		t.Fatalf("expected member %d to be elected, got member %d", target+1, newLeader+1)
+	}
+
+	// Resigning transfers leadership of every group led by the node to another member
+	if err := cluster.nodes[target].Resign(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if newLeader := cluster.awaitLeader(t, 1, 0, 1, 2); newLeader == target {
+		t.Fatalf("expected member %d to resign leadership", target+1)
+	}
+}
+
+func TestGroupStatus(t *testing.T) {
+	cluster := newTestCluster(t, 3)
+	leader := cluster.bootstrap(t, 1)
+	openTestSession(t, cluster.partition(t, leader, 1))
+	ctx := context.Background()
+
+	for i, p := range cluster.nodes {
+		status := awaitTestGroupStatus(t, p, 1, func(status *GroupStatus) bool {
+			return status.Leader == MemberID(leader+1) && status.AppliedIndex > 0
+		})
+		if status.GroupID != 1 || status.MemberID != MemberID(i+1) || status.Role != MemberRole_MEMBER || status.Term == 0 {
+			t.Errorf("unexpected status of member %d: %v", i+1, status)
+		}
+		if len(status.Members) != len(cluster.nodes) {
+			t.Errorf("expected %d members, got %v", len(cluster.nodes), status.Members)
+		}
+		for j, member := range status.Members {
+			if member != cluster.member(j) {
+				t.Errorf("expected member %v, got %v", cluster.member(j), member)
+			}
+		}
+
+		groups, err := p.ListGroups(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(groups) != 1 || groups[0].GroupID != 1 || groups[0].MemberID != MemberID(i+1) {
+			t.Errorf("expected node to list group 1, got %v", groups)
+		}
+	}
+
+	if _, err := cluster.nodes[0].GetGroupStatus(ctx, 2); !errors.IsNotFound(err) {
+		t.Errorf("expected NotFound for an unknown group, got %v", err)
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"context"
 	"github.com/atomix/runtime/sdk/pkg/errors"
 	"github.com/atomix/runtime/sdk/pkg/logging"
+	"io"
 )
 
+const snapshotChunkSize = 1024 * 1024
+
 func NewNodeServer(protocol *Protocol) NodeServer {
 	return &nodeServer{
 		protocol: protocol,
@@ -68,12 +71,243 @@ func (s *nodeServer) Leave(ctx context.Context, request *LeaveRequest) (*LeaveRe
 	return response, nil
 }
 
+func (s *nodeServer) AddMember(ctx context.Context, request *AddMemberRequest) (*AddMemberResponse, error) {
+	log.Debugw("AddMember",
+		logging.Stringer("AddMemberRequest", request))
+	if err := s.protocol.AddMember(ctx, request.GroupID, request.Member, request.Role); err != nil {
+		log.Warnw("AddMember",
+			logging.Stringer("AddMemberRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &AddMemberResponse{}
+	log.Debugw("AddMember",
+		logging.Stringer("AddMemberRequest", request),
+		logging.Stringer("AddMemberResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) RemoveMember(ctx context.Context, request *RemoveMemberRequest) (*RemoveMemberResponse, error) {
+	log.Debugw("RemoveMember",
+		logging.Stringer("RemoveMemberRequest", request))
+	if err := s.protocol.RemoveMember(ctx, request.GroupID, request.MemberID); err != nil {
+		log.Warnw("RemoveMember",
+			logging.Stringer("RemoveMemberRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &RemoveMemberResponse{}
+	log.Debugw("RemoveMember",
+		logging.Stringer("RemoveMemberRequest", request),
+		logging.Stringer("RemoveMemberResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) PromoteMember(ctx context.Context, request *PromoteMemberRequest) (*PromoteMemberResponse, error) {
+	log.Debugw("PromoteMember",
+		logging.Stringer("PromoteMemberRequest", request))
+	if err := s.protocol.PromoteMember(ctx, request.GroupID, request.MemberID); err != nil {
+		log.Warnw("PromoteMember",
+			logging.Stringer("PromoteMemberRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &PromoteMemberResponse{}
+	log.Debugw("PromoteMember",
+		logging.Stringer("PromoteMemberRequest", request),
+		logging.Stringer("PromoteMemberResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) TransferLeadership(ctx context.Context, request *TransferLeadershipRequest) (*TransferLeadershipResponse, error) {
+	log.Debugw("TransferLeadership",
+		logging.Stringer("TransferLeadershipRequest", request))
+	if err := s.protocol.TransferLeadership(ctx, request.GroupID, request.TargetMemberID); err != nil {
+		log.Warnw("TransferLeadership",
+			logging.Stringer("TransferLeadershipRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &TransferLeadershipResponse{}
+	log.Debugw("TransferLeadership",
+		logging.Stringer("TransferLeadershipRequest", request),
+		logging.Stringer("TransferLeadershipResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) GetGroupStatus(ctx context.Context, request *GetGroupStatusRequest) (*GetGroupStatusResponse, error) {
+	log.Debugw("GetGroupStatus",
+		logging.Stringer("GetGroupStatusRequest", request))
+	group, err := s.protocol.GetGroupStatus(ctx, request.GroupID)
+	if err != nil {
+		log.Warnw("GetGroupStatus",
+			logging.Stringer("GetGroupStatusRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &GetGroupStatusResponse{
+		Group: *group,
+	}
+	log.Debugw("GetGroupStatus",
+		logging.Stringer("GetGroupStatusRequest", request),
+		logging.Stringer("GetGroupStatusResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) ListGroups(ctx context.Context, request *ListGroupsRequest) (*ListGroupsResponse, error) {
+	log.Debugw("ListGroups",
+		logging.Stringer("ListGroupsRequest", request))
+	groups, err := s.protocol.ListGroups(ctx)
+	if err != nil {
+		log.Warnw("ListGroups",
+			logging.Stringer("ListGroupsRequest", request),
+			logging.Error("Error", err))
+		return nil, errors.ToProto(err)
+	}
+	response := &ListGroupsResponse{
+		Groups: groups,
+	}
+	log.Debugw("ListGroups",
+		logging.Stringer("ListGroupsRequest", request),
+		logging.Stringer("ListGroupsResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) GetNodeInfo(ctx context.Context, request *GetNodeInfoRequest) (*GetNodeInfoResponse, error) {
+	log.Debugw("GetNodeInfo",
+		logging.Stringer("GetNodeInfoRequest", request))
+	response := &GetNodeInfoResponse{
+		Node: *s.protocol.GetNodeInfo(),
+	}
+	log.Debugw("GetNodeInfo",
+		logging.Stringer("GetNodeInfoRequest", request),
+		logging.Stringer("GetNodeInfoResponse", response))
+	return response, nil
+}
+
+func (s *nodeServer) ExportSnapshot(request *ExportSnapshotRequest, server Node_ExportSnapshotServer) error {
+	log.Debugw("ExportSnapshot",
+		logging.Stringer("ExportSnapshotRequest", request))
+	snapshot, err := s.protocol.ExportSnapshot(server.Context(), request.GroupID, request.Time)
+	if err != nil {
+		log.Warnw("ExportSnapshot",
+			logging.Stringer("ExportSnapshotRequest", request),
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
+	defer snapshot.Close()
+
+	buf := make([]byte, snapshotChunkSize)
+	for {
+		n, err := io.ReadFull(snapshot, buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			log.Warnw("ExportSnapshot",
+				logging.Stringer("ExportSnapshotRequest", request),
+				logging.Error("Error", err))
+			return errors.ToProto(errors.NewInternal(err.Error()))
+		}
+		response := &ExportSnapshotResponse{
+			Index:     snapshot.Index,
+			Data:      buf[:n],
+			TotalSize: uint64(snapshot.Size),
+		}
+		if err := server.Send(response); err != nil {
+			log.Warnw("ExportSnapshot",
+				logging.Stringer("ExportSnapshotRequest", request),
+				logging.Error("Error", err))
+			return errors.ToProto(err)
+		}
+	}
+	log.Debugw("ExportSnapshot",
+		logging.Stringer("ExportSnapshotRequest", request),
+		logging.Uint64("Index", uint64(snapshot.Index)),
+		logging.Int64("Size", snapshot.Size))
+	return nil
+}
+
+func (s *nodeServer) ImportSnapshot(server Node_ImportSnapshotServer) error {
+	request, err := server.Recv()
+	if err == io.EOF {
+		err = errors.NewInvalid("no snapshot received")
+	}
+	if err != nil {
+		log.Warnw("ImportSnapshot",
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
+
+	groupID, memberID := request.GroupID, request.MemberID
+	log.Debugw("ImportSnapshot",
+		logging.Uint32("GroupID", uint32(groupID)),
+		logging.Uint32("MemberID", uint32(memberID)))
+	reader := &importSnapshotReader{
+		server:   server,
+		groupID:  groupID,
+		memberID: memberID,
+		request:  request,
+	}
+	if err := s.protocol.ImportSnapshot(server.Context(), groupID, memberID, reader); err != nil {
+		if reader.err != nil {
+			err = reader.err
+		}
+		log.Warnw("ImportSnapshot",
+			logging.Uint32("GroupID", uint32(groupID)),
+			logging.Uint32("MemberID", uint32(memberID)),
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
+	response := &ImportSnapshotResponse{}
+	log.Debugw("ImportSnapshot",
+		logging.Uint32("GroupID", uint32(groupID)),
+		logging.Uint32("MemberID", uint32(memberID)),
+		logging.Stringer("ImportSnapshotResponse", response))
+	return server.SendAndClose(response)
+}
+
+// importSnapshotReader reads the chunks of a snapshot from an ImportSnapshot stream. Errors receiving from
+// the stream are recorded to be returned to the client in place of the error staging the snapshot.
+type importSnapshotReader struct {
+	server   Node_ImportSnapshotServer
+	groupID  GroupID
+	memberID MemberID
+	request  *ImportSnapshotRequest
+	data     []byte
+	err      error
+}
+
+func (r *importSnapshotReader) Read(p []byte) (int, error) {
+	for len(r.data) == 0 {
+		if r.request == nil {
+			request, err := r.server.Recv()
+			if err == io.EOF {
+				return 0, io.EOF
+			}
+			if err != nil {
+				r.err = err
+				return 0, err
+			}
+			r.request = request
+		}
+		if r.request.GroupID != r.groupID || r.request.MemberID != r.memberID {
+			r.err = errors.NewInvalid("snapshot chunks must target a single member")
+			return 0, r.err
+		}
+		r.data = r.request.Data
+		r.request = nil
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
 func (s *nodeServer) Watch(request *WatchRequest, server Node_WatchServer) error {
 	log.Debugw("Watch",
 		logging.Stringer("WatchRequest", request))
-	ch := make(chan Event, 100)
-	go s.protocol.Watch(server.Context(), ch)
-	for event := range ch {
+	watcher := s.protocol.Watch(server.Context(), request)
+	for event := range watcher.Events() {
 		log.Debugw("Watch",
 			logging.Stringer("WatchRequest", request),
 			logging.Stringer("NodeEvent", &event))
@@ -86,5 +320,11 @@ func (s *nodeServer) Watch(request *WatchRequest, server Node_WatchServer) error
 			return errors.ToProto(err)
 		}
 	}
+	if err := watcher.Err(); err != nil {
+		log.Warnw("Watch",
+			logging.Stringer("WatchRequest", request),
+			logging.Error("Error", err))
+		return errors.ToProto(err)
+	}
 	return nil
 }
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/logging"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"github.com/bits-and-blooms/bloom/v3"
+	"github.com/lni/dragonboat/v3"
+	"github.com/lni/dragonboat/v3/client"
+	"sync"
+	"time"
+)
+
+const (
+	// sessionIdleTimeout is the time after which a session that has not been used on this node is released.
+	// It must be longer than the timeout of any Atomix session to avoid releasing sessions that are still open.
+	sessionIdleTimeout = 5 * time.Minute
+	// sessionSweepInterval is the minimum interval at which idle sessions are released
+	sessionSweepInterval = time.Minute
+)
+
+// newSessionManager returns a new manager for the Raft client sessions of the given partition
+func newSessionManager(partition *Partition, host *dragonboat.NodeHost) *sessionManager {
+	return &sessionManager{
+		partition: partition,
+		host:      host,
+		sessions:  make(map[protocol.SessionID]*raftSession),
+		lastSweep: time.Now(),
+	}
+}
+
+// sessionManager maps Atomix client sessions to dragonboat client sessions. Each Atomix session is registered
+// as a dragonboat session whose client ID is the Atomix session ID, and each session proposal is submitted with
+// its sequence number as the series ID. Because the client session is derived from the Atomix session, a
+// proposal retried through any node after a leader change is deduplicated by the Raft layer.
+//
+// Proposals are only made through the leader, so the leader tracks the sessions in use. Sessions are unregistered
+// when closed, and sessions that are not used for sessionIdleTimeout are assumed to have expired and are
+// released. Dragonboat may also evict sessions from its session table, so a session whose proposal is rejected
+// is registered again.
+type sessionManager struct {
+	partition *Partition
+	host      *dragonboat.NodeHost
+	sessions  map[protocol.SessionID]*raftSession
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// raftSession tracks the state of a dragonboat client session on this node
+type raftSession struct {
+	id          protocol.SessionID
+	registered  bool
+	respondedTo protocol.SequenceNum
+	// lastUsed and term are the time and term at which the session was last used
+	lastUsed time.Time
+	term     Term
+	mu       sync.Mutex
+}
+
+// getSession returns the dragonboat client session with which to propose the given input
+func (m *sessionManager) getSession(ctx context.Context, input *protocol.ProposalInput) (*client.Session, error) {
+	proposal, ok := input.Input.(*protocol.ProposalInput_Proposal)
+	if !ok || proposal.Proposal.SessionID == 0 || proposal.Proposal.SequenceNum == 0 {
+		return m.host.GetNoOPSession(uint64(m.partition.ID())), nil
+	}
+
+	session := m.get(proposal.Proposal.SessionID)
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if !session.registered {
+		if err := m.register(ctx, session.id); err != nil {
+			return nil, err
+		}
+		session.registered = true
+	}
+	return &client.Session{
+		ClusterID:   uint64(m.partition.ID()),
+		ClientID:    uint64(session.id),
+		SeriesID:    uint64(proposal.Proposal.SequenceNum),
+		RespondedTo: uint64(session.respondedTo),
+	}, nil
+}
+
+// reject marks the given session unregistered after a proposal was rejected because the session was not found
+// in dragonboat's session table, e.g. because it was evicted, forcing the session to be registered again
+func (m *sessionManager) reject(sessionID protocol.SessionID) {
+	session := m.get(sessionID)
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	if session.registered {
+		log.Debugw("Raft client session not found",
+			logging.Uint64("SessionID", uint64(sessionID)))
+		session.registered = false
+	}
+}
+
+// keepAlive advances the sequence number up to which the client has received responses for the session,
+// allowing the Raft layer to discard cached results for completed proposals
+func (m *sessionManager) keepAlive(input *protocol.KeepAliveInput) {
+	openInputs := &bloom.BloomFilter{}
+	if err := json.Unmarshal(input.InputFilter, openInputs); err != nil {
+		return
+	}
+
+	session := m.get(input.SessionID)
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	for sequenceNum := session.respondedTo + 1; sequenceNum <= input.LastInputSequenceNum; sequenceNum++ {
+		sequenceNumBytes := make([]byte, 8)
+		binary.BigEndian.PutUint64(sequenceNumBytes, uint64(sequenceNum))
+		if openInputs.Test(sequenceNumBytes) {
+			break
+		}
+		session.respondedTo = sequenceNum
+	}
+}
+
+// close unregisters the dragonboat client session for the given Atomix session
+func (m *sessionManager) close(ctx context.Context, sessionID protocol.SessionID) error {
+	m.mu.Lock()
+	session, ok := m.sessions[sessionID]
+	delete(m.sessions, sessionID)
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	session.mu.Lock()
+	registered := session.registered
+	session.mu.Unlock()
+	if !registered {
+		return nil
+	}
+	return m.unregister(ctx, sessionID)
+}
+
+// get returns the state of the given session, recording its use
+func (m *sessionManager) get(sessionID protocol.SessionID) *raftSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if now.Sub(m.lastSweep) >= sessionSweepInterval {
+		m.sweep(now)
+	}
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		session = &raftSession{
+			id: sessionID,
+		}
+		m.sessions[sessionID] = session
+	}
+	session.lastUsed = now
+	session.term, _ = m.partition.getLeader()
+	return session
+}
+
+// sweep releases the sessions that have not been used for sessionIdleTimeout. A session that has been idle
+// through the current term is assumed to have expired and is unregistered. Sessions last used in an earlier
+// term may be in use through another node, so they're only removed from this node; if they have expired,
+// dragonboat eventually evicts them from its session table. Must be called with the manager locked.
+func (m *sessionManager) sweep(now time.Time) {
+	m.lastSweep = now
+	term, leader := m.partition.getLeader()
+	var expired []protocol.SessionID
+	for sessionID, session := range m.sessions {
+		if now.Sub(session.lastUsed) < sessionIdleTimeout {
+			continue
+		}
+		// A locked session is being registered and is not idle
+		if !session.mu.TryLock() {
+			continue
+		}
+		delete(m.sessions, sessionID)
+		if session.registered && session.term == term && leader == m.partition.memberID {
+			expired = append(expired, sessionID)
+		}
+		session.mu.Unlock()
+	}
+	if len(expired) == 0 {
+		return
+	}
+	go func() {
+		for _, sessionID := range expired {
+			log.Debugw("Releasing expired Raft client session",
+				logging.Uint64("SessionID", uint64(sessionID)))
+			if err := m.unregister(context.Background(), sessionID); err != nil {
+				log.Warnw("Failed to release expired Raft client session",
+					logging.Uint64("SessionID", uint64(sessionID)),
+					logging.Error("Error", err))
+			}
+		}
+	}()
+}
+
+// register registers the given session. Dragonboat only rejects registration of a session that is already
+// registered, e.g. through another node before a leader change, so a rejected registration is treated as
+// registered. If the session has since been evicted, its proposals are rejected and the session is
+// registered again.
+func (m *sessionManager) register(ctx context.Context, sessionID protocol.SessionID) error {
+	rejected, err := m.propose(ctx, sessionID, client.SeriesIDForRegister)
+	if err != nil {
+		return err
+	}
+	if rejected {
+		log.Debugw("Raft client session already registered",
+			logging.Uint64("SessionID", uint64(sessionID)))
+	}
+	return nil
+}
+
+// unregister unregisters the given session. Dragonboat only rejects unregistration of a session that is not
+// registered, so a rejected request means the session has already been released.
+func (m *sessionManager) unregister(ctx context.Context, sessionID protocol.SessionID) error {
+	rejected, err := m.propose(ctx, sessionID, client.SeriesIDForUnregister)
+	if err != nil {
+		return err
+	}
+	if rejected {
+		log.Debugw("Raft client session already unregistered",
+			logging.Uint64("SessionID", uint64(sessionID)))
+	}
+	return nil
+}
+
+// propose proposes a registration or unregistration of the given session, returning whether it was rejected
+func (m *sessionManager) propose(ctx context.Context, sessionID protocol.SessionID, seriesID uint64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, defaultClientTimeout)
+	defer cancel()
+	session := &client.Session{
+		ClusterID: uint64(m.partition.ID()),
+		ClientID:  uint64(sessionID),
+		SeriesID:  seriesID,
+	}
+	requestState, err := m.host.ProposeSession(session, defaultClientTimeout)
+	if err != nil {
+		return false, wrapError(err)
+	}
+	defer requestState.Release()
+
+	select {
+	case result := <-requestState.ResultC():
+		if result.Completed() {
+			return false, nil
+		}
+		if result.Rejected() {
+			return true, nil
+		}
+		log.Warnw("Failed to update Raft client session",
+			logging.Uint64("SessionID", uint64(sessionID)))
+		if result.Timeout() {
+			return false, errors.NewTimeout("session request timed out")
+		}
+		return false, errors.NewUnavailable("session request failed")
+	case <-ctx.Done():
+		return false, errors.NewTimeout(ctx.Err().Error())
+	}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"github.com/bits-and-blooms/bloom/v3"
+	"testing"
+	"time"
+)
+
+func TestRetriedIncrement(t *testing.T) {
+	cluster := newTestCluster(t, 3)
+	leader := cluster.bootstrap(t, 1)
+	partition := cluster.partition(t, leader, 1)
+	ctx := context.Background()
+	sessionID := openTestSession(t, partition)
+	counterID := createTestCounter(t, partition, sessionID, 1)
+
+	increment := newTestIncrementInput(t, sessionID, 2, counterID)
+	if value := incrementTestCounter(t, ctx, partition, increment); value != 1 {
+		t.Fatalf("expected counter value 1, got %d", value)
+	}
+	// A retried proposal returns the result of the original proposal
+	if value := incrementTestCounter(t, ctx, partition, increment); value != 1 {
+		t.Fatalf("expected retried increment to return counter value 1, got %d", value)
+	}
+
+	// The client retries the proposal through the new leader after a failover. Dragonboat skips the election
+	// of a member with committed entries it has yet to apply, so the transfer is retried until the member has
+	// caught up.
+	target := (leader + 1) % len(cluster.nodes)
+	err := cluster.nodes[leader].TransferLeadership(ctx, 1, MemberID(target+1))
+	for i := 0; errors.IsTimeout(err) && i < 10; i++ {
+		err = cluster.nodes[leader].TransferLeadership(ctx, 1, MemberID(target+1))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster.awaitLeader(t, 1, 0, 1, 2)
+	partition = cluster.partition(t, target, 1)
+	if value := incrementTestCounter(t, ctx, partition, increment); value != 1 {
+		t.Fatalf("expected increment retried after failover to return counter value 1, got %d", value)
+	}
+	if value, err := getTestCounter(ctx, partition, sessionID, counterID); err != nil {
+		t.Fatal(err)
+	} else if value != 1 {
+		t.Fatalf("expected retried increments to be applied once, got counter value %d", value)
+	}
+	if value := incrementTestCounter(t, ctx, partition, newTestIncrementInput(t, sessionID, 3, counterID)); value != 2 {
+		t.Fatalf("expected counter value 2, got %d", value)
+	}
+}
+
+func TestSessionKeepAlive(t *testing.T) {
+	manager := newSessionManager(newTestMemberPartition(nil, 1, 1), nil)
+	openInputs := bloom.NewWithEstimates(100, .01)
+	sequenceNumBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(sequenceNumBytes, 3)
+	openInputs.Add(sequenceNumBytes)
+	inputFilter, err := json.Marshal(openInputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Responses may only be discarded up to the first input that is still open
+	manager.keepAlive(&protocol.KeepAliveInput{
+		SessionID:            1,
+		InputFilter:          inputFilter,
+		LastInputSequenceNum: 5,
+	})
+	if respondedTo := manager.get(1).respondedTo; respondedTo != 2 {
+		t.Fatalf("expected session to have responded to sequence number 2, got %d", respondedTo)
+	}
+
+	inputFilter, err = json.Marshal(bloom.NewWithEstimates(100, .01))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.keepAlive(&protocol.KeepAliveInput{
+		SessionID:            1,
+		InputFilter:          inputFilter,
+		LastInputSequenceNum: 5,
+	})
+	if respondedTo := manager.get(1).respondedTo; respondedTo != 5 {
+		t.Fatalf("expected session to have responded to sequence number 5, got %d", respondedTo)
+	}
+}
+
+func TestSessionSweep(t *testing.T) {
+	manager := newSessionManager(newTestMemberPartition(nil, 1, 1), nil)
+	manager.get(1)
+	manager.get(2)
+	manager.sessions[1].lastUsed = time.Now().Add(-sessionIdleTimeout)
+
+	manager.lastSweep = time.Now().Add(-sessionSweepInterval)
+	manager.get(2)
+	if _, ok := manager.sessions[1]; ok {
+		t.Error("expected idle session to be released")
+	}
+	if _, ok := manager.sessions[2]; !ok {
+		t.Error("expected session in use to be retained")
+	}
+}
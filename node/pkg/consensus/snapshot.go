@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/atomix/runtime/sdk/pkg/logging"
+	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	importsDir = "imports"
+	exportsDir = "exports"
+)
+
+// getImportPath returns the path of the snapshot staged to seed the given member of a group
+func getImportPath(dataDir string, groupID GroupID, memberID MemberID) string {
+	return filepath.Join(dataDir, importsDir, fmt.Sprintf("%d-%d.snapshot", groupID, memberID))
+}
+
+// getSeedPath returns the path of the snapshot staged to seed the given member, or an empty string if no
+// snapshot is staged for the member
+func getSeedPath(dataDir string, groupID GroupID, memberID MemberID) string {
+	path := getImportPath(dataDir, groupID, memberID)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// stageSnapshot writes the snapshot read from the given reader to a temporary file in the imports directory,
+// verifying the snapshot can be recovered by a state machine of the given types. The caller is responsible
+// for renaming the file into place or removing it.
+func stageSnapshot(dataDir string, reader io.Reader, types *statemachine.PrimitiveTypeRegistry) (string, error) {
+	dir := filepath.Join(dataDir, importsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return "", err
+	}
+	path := file.Name()
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	if err := recoverSnapshotFile(statemachine.NewStateMachine(types), path); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("invalid snapshot: %v", err)
+	}
+	return path, nil
+}
+
+// newExportFile creates a temporary file in the exports directory to which to write an exported snapshot
+func newExportFile(dataDir string) (*exportFile, error) {
+	dir := filepath.Join(dataDir, exportsDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp(dir, "*.snapshot")
+	if err != nil {
+		return nil, err
+	}
+	return &exportFile{
+		File:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+// exportFile is a temporary file holding an exported snapshot, which is removed when the file is closed
+type exportFile struct {
+	*os.File
+	writer *bufio.Writer
+	size   int64
+}
+
+// Write buffers writes to the file, as snapshots are written in many small writes
+func (f *exportFile) Write(p []byte) (int, error) {
+	n, err := f.writer.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Size returns the size of the snapshot written to the file
+func (f *exportFile) Size() int64 {
+	return f.size
+}
+
+// rewind flushes the snapshot to the file and seeks to the start of the file to read the snapshot
+func (f *exportFile) rewind() error {
+	if err := f.writer.Flush(); err != nil {
+		return err
+	}
+	_, err := f.File.Seek(0, io.SeekStart)
+	return err
+}
+
+// Close closes and removes the file
+func (f *exportFile) Close() error {
+	err := f.File.Close()
+	if removeErr := os.Remove(f.Name()); removeErr != nil {
+		log.Warnw("Failed to remove exported snapshot",
+			logging.String("Path", f.Name()),
+			logging.Error("Error", removeErr))
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportImportSnapshot(t *testing.T) {
+	cluster := newTestCluster(t, 2)
+	cluster.bootstrap(t, 1, 0)
+	source := cluster.partition(t, 0, 1)
+	ctx := context.Background()
+	sessionID := openTestSession(t, source)
+	counterID := createTestCounter(t, source, sessionID, 1)
+	for i := 2; i <= 4; i++ {
+		incrementTestCounter(t, ctx, source, newTestIncrementInput(t, sessionID, protocol.SequenceNum(i), counterID))
+	}
+
+	snapshot, err := cluster.nodes[0].ExportSnapshot(ctx, 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(snapshot)
+	snapshot.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if int64(len(data)) != snapshot.Size || snapshot.Index == 0 {
+		t.Fatalf("expected snapshot of %d bytes at a non-zero index, got %d bytes at index %d", snapshot.Size, len(data), snapshot.Index)
+	}
+
+	// Snapshots that cannot be recovered are rejected before they're staged
+	target := cluster.nodes[1]
+	if err := target.ImportSnapshot(ctx, 2, 2, strings.NewReader("invalid")); !errors.IsInvalid(err) {
+		t.Fatalf("expected Invalid error importing an invalid snapshot, got %v", err)
+	}
+
+	// The imported snapshot seeds a new group
+	if err := target.ImportSnapshot(ctx, 2, 2, bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	cluster.bootstrap(t, 2, 1)
+	seeded := cluster.partition(t, 1, 2)
+	sessionID = openTestSession(t, seeded)
+	if id := createTestCounter(t, seeded, sessionID, 1); id != counterID {
+		t.Fatalf("expected counter %d to be restored, got counter %d", counterID, id)
+	}
+	value, err := getTestCounter(ctx, seeded, sessionID, counterID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != 3 {
+		t.Fatalf("expected seeded counter value 3, got %d", value)
+	}
+
+	// The state of a started member can never be replaced
+	if err := target.ImportSnapshot(ctx, 2, 2, bytes.NewReader(data)); !errors.IsConflict(err) {
+		t.Fatalf("expected Conflict importing a snapshot for a started member, got %v", err)
+	}
+
+	// The seed is removed once the member's log has been compacted
+	deadline := time.Now().Add(time.Minute)
+	for getSeedPath(target.config.GetDataDir(), 2, 2) != "" {
+		if time.Now().After(deadline) {
+			t.Fatal("expected imported snapshot to be removed once the member was compacted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -5,63 +5,537 @@
 package consensus
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/logging"
 	"github.com/atomix/runtime/sdk/pkg/protocol"
 	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	streams "github.com/atomix/runtime/sdk/pkg/stream"
 	"github.com/gogo/protobuf/proto"
 	dbsm "github.com/lni/dragonboat/v3/statemachine"
+	"go.opentelemetry.io/otel/trace"
 	"io"
+	"os"
+	"sync"
+	"time"
 )
 
-func newStateMachine(protocol *protocolContext, types *statemachine.PrimitiveTypeRegistry) dbsm.IStateMachine {
-	return &stateMachine{
-		protocol: protocol,
-		sm:       statemachine.NewStateMachine(types),
+const (
+	// maxPendingChanges is the number of inputs queued for the standby state machine after which the standby
+	// is brought up to date without waiting for the next snapshot
+	maxPendingChanges = 100000
+	// snapshotExportTimeout is the maximum time an export waits for an earlier snapshot to be saved
+	snapshotExportTimeout = time.Minute
+)
+
+// errSnapshotUnavailable is the error for an export cut at a time the standby state machine has been advanced past
+var errSnapshotUnavailable = errors.NewUnavailable("state at the requested time is no longer available")
+
+// newStateMachine returns a new state machine for the given partition. If a seed path is provided, the state
+// machine's initial state is recovered from the snapshot at that path.
+func newStateMachine(partition *Partition, protocol *protocolContext, types *statemachine.PrimitiveTypeRegistry, seed string) dbsm.IConcurrentStateMachine {
+	sm := &stateMachine{
+		partition:  partition,
+		protocol:   protocol,
+		sm:         statemachine.NewStateMachine(types),
+		standby:    statemachine.NewStateMachine(types),
+		maxPending: maxPendingChanges,
+		saved:      make(chan struct{}),
 	}
+	if seed != "" {
+		log.Infow("Seeding state from imported snapshot",
+			logging.String("Path", seed))
+		// Imported snapshots are verified when they're staged, so a seed that cannot be recovered is fatal
+		if err := recoverSnapshotFile(sm.sm, seed); err != nil {
+			panic(err)
+		}
+		if err := recoverSnapshotFile(sm.standby, seed); err != nil {
+			panic(err)
+		}
+	}
+	return sm
 }
 
+// stateMachine adapts the primitive state machine to dragonboat. The concurrent state machine
+// interface is implemented to get access to the Raft index of each entry and to save snapshots
+// concurrently with updates, but access to the underlying state machine is still serialized.
+//
+// The primitive state machine can only be captured by serializing it, so snapshots are taken from
+// a standby replica rather than the live state machine. Applied changes are queued for the standby,
+// PrepareSnapshot records the position in the queue, and SaveSnapshot applies the queued changes
+// to the standby and serializes it without blocking updates. The standby doubles the memory used
+// by the group's state. The queue holds the changes applied since the standby was last advanced, and is
+// bounded by advancing the standby once maxPending changes are queued.
+//
+// Exported snapshots are also saved from the standby. The standby can only move forward, so it's never
+// advanced past a snapshot prepared by dragonboat before that snapshot is saved, and an export captured
+// before a prepared snapshot is written by whichever caller advances the standby past it first. Each
+// queued change records the time at which it was proposed, so an export can be cut at any point in time
+// the standby has not yet been advanced past.
 type stateMachine struct {
-	protocol *protocolContext
-	sm       statemachine.StateMachine
+	partition *Partition
+	protocol  *protocolContext
+	sm        statemachine.StateMachine
+	mu        sync.Mutex
+	// changes is the number of inputs applied to sm, and pending the inputs not yet applied to standby.
+	// Inputs are queued encoded so the standby never shares decoded state with the live state machine.
+	changes    uint64
+	pending    []pendingChange
+	maxPending int
+	// prepared are the positions of the snapshots prepared by dragonboat that have not yet been saved, and
+	// exports the exports that have not yet been written, both in order. saved is closed when a prepared
+	// snapshot has been saved.
+	prepared []uint64
+	exports  []*snapshotExport
+	saved    chan struct{}
+	// standbyChanges is the number of inputs applied to standby, and standbyTime the time at which the last
+	// input applied to it was proposed. The standby is locked before mu.
+	standby        statemachine.StateMachine
+	standbyChanges uint64
+	standbyTime    time.Time
+	standbyMu      sync.Mutex
+}
+
+// pendingChange is an encoded input queued for the standby state machine
+type pendingChange struct {
+	data      []byte
+	index     Index
+	timestamp time.Time
+}
+
+// snapshotContext is the point-in-time state captured by PrepareSnapshot
+type snapshotContext struct {
+	index   Index
+	changes uint64
 }
 
-func (s *stateMachine) Update(bytes []byte) (dbsm.Result, error) {
-	proposal := &RaftProposal{}
-	if err := proto.Unmarshal(bytes, proposal); err != nil {
-		return dbsm.Result{}, err
+// snapshotExport is an export of the state captured at a position in the queue of inputs to the standby
+type snapshotExport struct {
+	snapshotContext
+	writer io.Writer
+	done   chan struct{}
+	err    error
+}
+
+// entryChange is a proposal decoded from an entry. An entry of batched proposals is decoded into a change per
+// proposal.
+type entryChange struct {
+	entry       int
+	proposal    *RaftProposal
+	input       *protocol.ProposalInput
+	spanContext trace.SpanContext
+	batched     bool
+}
+
+// Update applies a batch of entries. Entries are decoded before the state machine is locked, and
+// the batch is applied in a single critical section.
+func (s *stateMachine) Update(entries []dbsm.Entry) ([]dbsm.Entry, error) {
+	if len(entries) == 0 {
+		return entries, nil
+	}
+	changes := make([]entryChange, 0, len(entries))
+	var links []trace.Link
+	for i, entry := range entries {
+		proposal := &RaftProposal{}
+		if err := proto.Unmarshal(entry.Cmd, proposal); err != nil {
+			return nil, err
+		}
+		proposals := []*RaftProposal{proposal}
+		batched := len(proposal.Batch) > 0
+		if batched {
+			proposals = proposal.Batch
+		}
+		for _, proposal := range proposals {
+			change := entryChange{
+				entry:    i,
+				proposal: proposal,
+				batched:  batched,
+			}
+			if spanContext := getProposalSpanContext(proposal); spanContext.IsValid() {
+				change.spanContext = spanContext
+				links = append(links, trace.Link{SpanContext: spanContext})
+			}
+			input := &protocol.ProposalInput{}
+			if err := proto.Unmarshal(proposal.Data, input); err != nil {
+				return nil, err
+			}
+			change.input = input
+			changes = append(changes, change)
+		}
+	}
+
+	// Batches containing traced proposals are traced, linking the batch to the spans that made the proposals
+	ctx := context.Background()
+	if len(links) > 0 {
+		var span trace.Span
+		ctx, span = tracer.Start(ctx, "StateMachine.Update",
+			trace.WithLinks(links...),
+			trace.WithAttributes(
+				partitionAttribute.Int64(int64(s.partition.ID())),
+				entriesAttribute.Int(len(entries))))
+		defer span.End()
+	}
+
+	entries, full, err := s.apply(ctx, entries, changes)
+	if err != nil {
+		return nil, err
 	}
-	var input protocol.ProposalInput
-	if err := proto.Unmarshal(proposal.Data, &input); err != nil {
-		return dbsm.Result{}, err
+	if full {
+		s.catchUp()
 	}
-	stream := s.protocol.getStream(proposal.Term, proposal.SequenceNum)
-	s.sm.Propose(&input, stream)
-	return dbsm.Result{}, nil
+	return entries, nil
+}
+
+// apply applies the changes decoded from a batch of entries to the live state machine, returning whether the
+// queue of inputs pending for the standby state machine is full
+func (s *stateMachine) apply(ctx context.Context, entries []dbsm.Entry, changes []entryChange) ([]dbsm.Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	trace.SpanFromContext(ctx).AddEvent("Acquired state machine lock")
+	for _, change := range changes {
+		entry := entries[change.entry]
+		proposal := change.proposal
+		input := change.input
+		s.pending = append(s.pending, pendingChange{
+			data:      proposal.Data,
+			index:     Index(entry.Index),
+			timestamp: input.Timestamp,
+		})
+		s.changes++
+		// The applied index is updated before the entry is applied to report the index in the proposal's
+		// response. Queries are serialized with updates, so the state is never read before the entry is applied.
+		s.partition.setAppliedIndex(Index(entry.Index))
+		stream := s.protocol.getStream(proposal.Term, proposal.SequenceNum)
+		var span trace.Span
+		if change.spanContext.IsValid() {
+			_, span = tracer.Start(ctx, "StateMachine.Apply",
+				trace.WithLinks(trace.Link{SpanContext: change.spanContext}),
+				trace.WithAttributes(
+					indexAttribute.Int64(int64(entry.Index)),
+					termAttribute.Int64(int64(proposal.Term)),
+					sequenceNumAttribute.Int64(int64(proposal.SequenceNum))))
+		}
+		if _, ok := input.Input.(*protocol.ProposalInput_Proposal); ok && !change.batched {
+			// Session proposals may be deduplicated by their Raft client session when retried,
+			// so the outputs are recorded in the entry result for the retries
+			resultStream := newResultStream(stream)
+			s.sm.Propose(input, resultStream)
+			result, err := newProposalResult(proposal, resultStream)
+			if err != nil {
+				if span != nil {
+					endSpan(span, err)
+				}
+				return nil, false, err
+			}
+			entries[change.entry].Result = dbsm.Result{
+				Data: result,
+			}
+		} else {
+			s.sm.Propose(input, stream)
+		}
+		if span != nil {
+			span.End()
+		}
+	}
+	return entries, len(s.pending) >= s.maxPending, nil
+}
+
+// catchUp advances the standby state machine to bound the queue of pending inputs when snapshots are
+// infrequent or have been aborted. The standby is not advanced past a snapshot prepared but not yet saved.
+// If the standby is already being advanced by a snapshot or export, the queue is drained by that caller.
+func (s *stateMachine) catchUp() {
+	if !s.standbyMu.TryLock() {
+		return
+	}
+	defer s.standbyMu.Unlock()
+	s.mu.Lock()
+	changes := s.changes
+	if len(s.prepared) > 0 && s.prepared[0] < changes {
+		changes = s.prepared[0]
+	}
+	s.mu.Unlock()
+	if err := s.advance(changes); err != nil {
+		log.Error(err)
+	}
+}
+
+func newProposalResult(proposal *RaftProposal, stream *resultStream) ([]byte, error) {
+	result := &RaftProposalResult{
+		Term:        proposal.Term,
+		SequenceNum: proposal.SequenceNum,
+		Complete:    stream.closed,
+	}
+	for _, output := range stream.outputs {
+		outputBytes, err := proto.Marshal(output)
+		if err != nil {
+			return nil, err
+		}
+		result.Outputs = append(result.Outputs, outputBytes)
+	}
+	return proto.Marshal(result)
 }
 
 func (s *stateMachine) Lookup(value interface{}) (interface{}, error) {
-	query := value.(*protocolQuery)
-	s.sm.Query(query.input, query.stream)
+	switch query := value.(type) {
+	case *protocolQuery:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.sm.Query(query.input, query.stream)
+	case *snapshotQuery:
+		export, err := s.capture(query.writer, query.time)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.export(export); err != nil {
+			return nil, err
+		}
+		return export.index, nil
+	}
 	return nil, nil
 }
 
-func (s *stateMachine) SaveSnapshot(w io.Writer, collection dbsm.ISnapshotFileCollection, i <-chan struct{}) error {
-	log.Infow("Persisting state to snapshot")
-	if err := s.sm.Snapshot(statemachine.NewSnapshotWriter(w)); err != nil {
+// capture captures the state of the live state machine to be exported to the given writer. If a time is
+// provided, the state is captured at the last change proposed at or before the time. The changes preceding
+// the time must still be queued for the standby, otherwise the state at the time is no longer available.
+func (s *stateMachine) capture(writer io.Writer, cut *time.Time) (*snapshotExport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot := snapshotContext{
+		index:   s.partition.getAppliedIndex(),
+		changes: s.changes,
+	}
+	if cut != nil {
+		if s.standbyTime.After(*cut) {
+			return nil, errSnapshotUnavailable
+		}
+		// Entries that don't reach the state machine don't change its state, so the state before the first
+		// change after the cut is the state as of the preceding index. If the cut falls within an entry of
+		// batched proposals, the state is reported at the partially applied entry's index.
+		for i, change := range s.pending {
+			if change.timestamp.After(*cut) {
+				snapshot.index = change.index - 1
+				if i > 0 && s.pending[i-1].index == change.index {
+					snapshot.index = change.index
+				}
+				snapshot.changes = s.standbyChanges + uint64(i)
+				break
+			}
+		}
+	}
+	export := &snapshotExport{
+		snapshotContext: snapshot,
+		writer:          writer,
+		done:            make(chan struct{}),
+	}
+	s.exports = append(s.exports, export)
+	return export, nil
+}
+
+// export writes the captured state to the export's writer from the standby state machine, so the export does
+// not block updates. If a snapshot prepared before the export was captured has not yet been saved, the export
+// waits for the snapshot to be saved rather than advancing the standby past it.
+func (s *stateMachine) export(export *snapshotExport) error {
+	timeout := time.NewTimer(snapshotExportTimeout)
+	defer timeout.Stop()
+	s.standbyMu.Lock()
+	defer s.standbyMu.Unlock()
+	for {
+		select {
+		case <-export.done:
+			return export.err
+		default:
+		}
+		s.mu.Lock()
+		blocked := len(s.prepared) > 0 && s.prepared[0] < export.changes
+		saved := s.saved
+		s.mu.Unlock()
+		if !blocked {
+			break
+		}
+
+		s.standbyMu.Unlock()
+		select {
+		case <-saved:
+			s.standbyMu.Lock()
+		case <-timeout.C:
+			s.standbyMu.Lock()
+			select {
+			case <-export.done:
+				return export.err
+			default:
+			}
+			s.removeExport(export)
+			return dbsm.ErrSnapshotAborted
+		}
+	}
+	if err := s.advance(export.changes); err != nil {
+		return err
+	}
+	<-export.done
+	return export.err
+}
+
+// removeExport removes an export that has not been written
+func (s *stateMachine) removeExport(export *snapshotExport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.exports {
+		if e == export {
+			s.exports = append(s.exports[:i], s.exports[i+1:]...)
+			return
+		}
+	}
+}
+
+// PrepareSnapshot captures the position of the snapshot in the queue of inputs to be applied to the standby
+// state machine. No state is copied, so updates are only blocked for the capture itself.
+func (s *stateMachine) PrepareSnapshot() (interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prepared = append(s.prepared, s.changes)
+	return &snapshotContext{
+		index:   s.partition.getAppliedIndex(),
+		changes: s.changes,
+	}, nil
+}
+
+// SaveSnapshot brings the standby state machine up to the captured position and writes it to the snapshot.
+// The live state machine is not locked, so updates continue to be applied while the snapshot is written.
+func (s *stateMachine) SaveSnapshot(ctx interface{}, w io.Writer, collection dbsm.ISnapshotFileCollection, done <-chan struct{}) error {
+	snapshot := ctx.(*snapshotContext)
+	log.Infow("Persisting state to snapshot",
+		logging.Uint64("Index", uint64(snapshot.index)))
+	if err := s.saveSnapshot(snapshot, w); err != nil {
 		log.Error(err)
 		return err
 	}
 	return nil
 }
 
+// saveSnapshot advances the standby state machine to the captured position, writing any exports captured
+// along the way, and writes the standby to the given writer. If a later snapshot has already advanced the
+// standby past the captured position, the snapshot is aborted.
+func (s *stateMachine) saveSnapshot(snapshot *snapshotContext, w io.Writer) error {
+	s.standbyMu.Lock()
+	defer s.standbyMu.Unlock()
+	defer s.release(snapshot.changes)
+	if s.standbyChanges > snapshot.changes {
+		return dbsm.ErrSnapshotAborted
+	}
+	if err := s.advance(snapshot.changes); err != nil {
+		return err
+	}
+	return s.standby.Snapshot(statemachine.NewSnapshotWriter(w))
+}
+
+// release releases the prepared snapshots up to the given position once the snapshot has been saved,
+// allowing exports waiting for the snapshot to proceed
+func (s *stateMachine) release(changes uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := 0
+	for i < len(s.prepared) && s.prepared[i] <= changes {
+		i++
+	}
+	s.prepared = s.prepared[i:]
+	close(s.saved)
+	s.saved = make(chan struct{})
+}
+
+// advance applies the queued inputs up to the given position to the standby state machine, writing each
+// export captured at or before the position when the standby reaches it. Must be called with the standby
+// locked.
+func (s *stateMachine) advance(changes uint64) error {
+	for {
+		s.mu.Lock()
+		position := changes
+		var export *snapshotExport
+		if len(s.exports) > 0 && s.exports[0].changes <= changes {
+			export = s.exports[0]
+			s.exports = s.exports[1:]
+			position = export.changes
+		}
+		if position < s.standbyChanges {
+			s.mu.Unlock()
+			if export != nil {
+				export.err = dbsm.ErrSnapshotAborted
+				close(export.done)
+				continue
+			}
+			return dbsm.ErrSnapshotAborted
+		}
+		n := int(position - s.standbyChanges)
+		queued := s.pending[:n:n]
+		s.pending = s.pending[n:]
+		s.mu.Unlock()
+
+		for _, change := range queued {
+			input := &protocol.ProposalInput{}
+			if err := proto.Unmarshal(change.data, input); err != nil {
+				if export != nil {
+					export.err = err
+					close(export.done)
+				}
+				return err
+			}
+			s.standby.Propose(input, streams.NewNilStream[*protocol.ProposalOutput]())
+			s.standbyTime = change.timestamp
+		}
+		s.standbyChanges = position
+		if export == nil {
+			return nil
+		}
+		export.err = s.standby.Snapshot(statemachine.NewSnapshotWriter(export.writer))
+		close(export.done)
+	}
+}
+
+// RecoverFromSnapshot recovers both the live and standby state machines from the snapshot. The snapshot
+// can only be read once, so it's read into memory to recover the two state machines.
 func (s *stateMachine) RecoverFromSnapshot(r io.Reader, files []dbsm.SnapshotFile, i <-chan struct{}) error {
 	log.Infow("Recovering state from snapshot")
-	if err := s.sm.Recover(statemachine.NewSnapshotReader(r)); err != nil {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	s.standbyMu.Lock()
+	defer s.standbyMu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.sm.Recover(statemachine.NewSnapshotReader(bytes.NewReader(data))); err != nil {
 		log.Error(err)
 		return err
 	}
+	if err := s.standby.Recover(statemachine.NewSnapshotReader(bytes.NewReader(data))); err != nil {
+		log.Error(err)
+		return err
+	}
+	s.pending = nil
+	s.standbyChanges = s.changes
+	// The time of the changes in the snapshot is not known, so exports cannot be cut before the recovery
+	s.standbyTime = time.Now()
+	for _, export := range s.exports {
+		export.err = dbsm.ErrSnapshotAborted
+		close(export.done)
+	}
+	s.exports = nil
 	return nil
 }
 
 func (s *stateMachine) Close() error {
 	return nil
 }
+
+// recoverSnapshotFile recovers the given state machine from the snapshot file at the given path
+func recoverSnapshotFile(sm statemachine.StateMachine, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return sm.Recover(statemachine.NewSnapshotReader(bufio.NewReader(file)))
+}
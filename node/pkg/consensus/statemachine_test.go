@@ -0,0 +1,345 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"bytes"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	"github.com/gogo/protobuf/proto"
+	dbsm "github.com/lni/dragonboat/v3/statemachine"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+const benchmarkSessions = 100000
+
+// BenchmarkUpdateDuringSnapshot measures the latency of updates to a state machine holding
+// benchmarkSessions sessions, both idle and while snapshots are continuously saved.
+func BenchmarkUpdateDuringSnapshot(b *testing.B) {
+	b.Run("Idle", func(b *testing.B) {
+		benchmarkUpdate(b, false)
+	})
+	b.Run("Snapshotting", func(b *testing.B) {
+		benchmarkUpdate(b, true)
+	})
+}
+
+func benchmarkUpdate(b *testing.B, snapshot bool) {
+	sm := newStateMachine(&Partition{}, newContext(), statemachine.NewPrimitiveTypeRegistry(), "")
+	var index uint64
+	for i := 0; i < benchmarkSessions; i++ {
+		index++
+		if _, err := sm.Update([]dbsm.Entry{newOpenSessionEntry(b, index)}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	stopCh := make(chan struct{})
+	wg := &sync.WaitGroup{}
+	var snapshots int
+	if snapshot {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stopCh:
+					return
+				default:
+				}
+				ctx, err := sm.PrepareSnapshot()
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				if err := sm.SaveSnapshot(ctx, io.Discard, nil, stopCh); err != nil {
+					b.Error(err)
+					return
+				}
+				snapshots++
+			}
+		}()
+	}
+
+	entries := make([]dbsm.Entry, b.N)
+	for i := range entries {
+		index++
+		entries[i] = newOpenSessionEntry(b, index)
+	}
+	latencies := make([]time.Duration, b.N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := time.Now()
+		if _, err := sm.Update(entries[i : i+1]); err != nil {
+			b.Fatal(err)
+		}
+		latencies[i] = time.Since(start)
+	}
+	b.StopTimer()
+
+	close(stopCh)
+	wg.Wait()
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns/op")
+	b.ReportMetric(float64(latencies[len(latencies)-1].Nanoseconds()), "max-ns/op")
+	if snapshot {
+		b.ReportMetric(float64(snapshots), "snapshots")
+	}
+}
+
+func TestExportDuringPreparedSnapshot(t *testing.T) {
+	sm := newStateMachine(&Partition{}, newContext(), statemachine.NewPrimitiveTypeRegistry(), "").(*stateMachine)
+	var index uint64
+	update := func(n int) {
+		for i := 0; i < n; i++ {
+			index++
+			if _, err := sm.Update([]dbsm.Entry{newOpenSessionEntry(t, index)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	update(3)
+	ctx, err := sm.PrepareSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	update(2)
+
+	// The export is captured after the prepared snapshot, so it must wait for the snapshot to be saved
+	// rather than advancing the standby past the snapshot's position
+	type exportResult struct {
+		index interface{}
+		err   error
+	}
+	buf := &bytes.Buffer{}
+	resultCh := make(chan exportResult, 1)
+	go func() {
+		result, err := sm.Lookup(&snapshotQuery{writer: buf})
+		resultCh <- exportResult{result, err}
+	}()
+	for {
+		sm.mu.Lock()
+		exports := len(sm.exports)
+		sm.mu.Unlock()
+		if exports > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := sm.SaveSnapshot(ctx, io.Discard, nil, nil); err != nil {
+		t.Fatalf("expected snapshot to be saved, got %v", err)
+	}
+	result := <-resultCh
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+	if result.index != Index(5) {
+		t.Errorf("expected export at index 5, got %v", result.index)
+	}
+	if buf.Len() == 0 {
+		t.Error("expected exported snapshot")
+	}
+	if sm.standbyChanges != 5 {
+		t.Errorf("expected standby at 5 changes, got %d", sm.standbyChanges)
+	}
+}
+
+func TestPendingChangesBounded(t *testing.T) {
+	tests := []struct {
+		name     string
+		updates  int
+		prepared bool
+		pending  int
+	}{
+		{
+			name:    "below limit",
+			updates: 9,
+			pending: 9,
+		},
+		{
+			name:    "at limit",
+			updates: 10,
+		},
+		{
+			name:    "above limit",
+			updates: 25,
+			pending: 5,
+		},
+		{
+			name:     "not advanced past prepared snapshot",
+			updates:  25,
+			prepared: true,
+			pending:  23,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sm := newStateMachine(&Partition{}, newContext(), statemachine.NewPrimitiveTypeRegistry(), "").(*stateMachine)
+			sm.maxPending = 10
+			for index := uint64(1); index <= uint64(test.updates); index++ {
+				if _, err := sm.Update([]dbsm.Entry{newOpenSessionEntry(t, index)}); err != nil {
+					t.Fatal(err)
+				}
+				if test.prepared && index == 2 {
+					if _, err := sm.PrepareSnapshot(); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			if len(sm.pending) != test.pending {
+				t.Errorf("expected %d pending changes, got %d", test.pending, len(sm.pending))
+			}
+			if sm.standbyChanges+uint64(len(sm.pending)) != sm.changes {
+				t.Errorf("expected %d changes, got %d applied to standby and %d pending", sm.changes, sm.standbyChanges, len(sm.pending))
+			}
+		})
+	}
+}
+
+func TestExportAtTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		time    time.Time
+		advance uint64
+		index   Index
+		err     error
+	}{
+		{
+			name:  "before first change",
+			time:  time.Unix(0, 0),
+			index: 0,
+		},
+		{
+			name:  "between changes",
+			time:  time.Unix(3, 0),
+			index: 3,
+		},
+		{
+			name:  "after last change",
+			time:  time.Unix(10, 0),
+			index: 5,
+		},
+		{
+			name:    "at standby position",
+			time:    time.Unix(2, 0),
+			advance: 2,
+			index:   2,
+		},
+		{
+			name:    "before standby position",
+			time:    time.Unix(2, 0),
+			advance: 3,
+			err:     errSnapshotUnavailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sm := newStateMachine(&Partition{}, newContext(), statemachine.NewPrimitiveTypeRegistry(), "").(*stateMachine)
+			for index := uint64(1); index <= 5; index++ {
+				entry := newTimedOpenSessionEntry(t, index, time.Unix(int64(index), 0))
+				if _, err := sm.Update([]dbsm.Entry{entry}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if test.advance > 0 {
+				sm.standbyMu.Lock()
+				err := sm.advance(test.advance)
+				sm.standbyMu.Unlock()
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			buf := &bytes.Buffer{}
+			index, err := sm.Lookup(&snapshotQuery{writer: buf, time: &test.time})
+			if err != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if index != test.index {
+				t.Errorf("expected export at index %d, got %v", test.index, index)
+			}
+			if sm.standbyChanges != uint64(test.index) {
+				t.Errorf("expected standby at %d changes, got %d", test.index, sm.standbyChanges)
+			}
+			if buf.Len() == 0 {
+				t.Error("expected exported snapshot")
+			}
+		})
+	}
+}
+
+func TestUpdateBatchedProposals(t *testing.T) {
+	sm := newStateMachine(&Partition{}, newContext(), statemachine.NewPrimitiveTypeRegistry(), "").(*stateMachine)
+	batch := &RaftProposal{}
+	for i := uint64(1); i <= 3; i++ {
+		proposal := &RaftProposal{}
+		if err := proto.Unmarshal(newOpenSessionEntry(t, i).Cmd, proposal); err != nil {
+			t.Fatal(err)
+		}
+		batch.Batch = append(batch.Batch, proposal)
+	}
+	cmd, err := proto.Marshal(batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := sm.Update([]dbsm.Entry{{Index: 1, Cmd: cmd}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries[0].Result.Data != nil {
+		t.Error("expected no result for a batch of proposals")
+	}
+	if sm.changes != 3 {
+		t.Errorf("expected 3 changes, got %d", sm.changes)
+	}
+	for i, change := range sm.pending {
+		if change.index != 1 {
+			t.Errorf("expected change %d at index 1, got %d", i, change.index)
+		}
+	}
+}
+
+func newOpenSessionEntry(b testing.TB, index uint64) dbsm.Entry {
+	return newTimedOpenSessionEntry(b, index, time.Unix(0, 0))
+}
+
+func newTimedOpenSessionEntry(b testing.TB, index uint64, timestamp time.Time) dbsm.Entry {
+	input, err := proto.Marshal(&protocol.ProposalInput{
+		Timestamp: timestamp,
+		Input: &protocol.ProposalInput_OpenSession{
+			OpenSession: &protocol.OpenSessionInput{
+				Timeout: time.Hour,
+			},
+		},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	cmd, err := proto.Marshal(&RaftProposal{
+		Term:        1,
+		SequenceNum: SequenceNum(index),
+		Data:        input,
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	return dbsm.Entry{
+		Index: index,
+		Cmd:   cmd,
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NewCertificates loads the CA, certificate and key configured for mutual TLS
+func NewCertificates(config TLSConfig) (*Certificates, error) {
+	certs := &Certificates{
+		config: config,
+	}
+	if err := certs.load(); err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+// Certificates provides TLS configurations for the API server and the clients of other nodes. When
+// reloading is enabled, the files are checked for changes on each handshake and reloaded when modified.
+// The Raft transport does not use Certificates and only loads the files when the node starts.
+type Certificates struct {
+	config  TLSConfig
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time
+	mu      sync.RWMutex
+}
+
+// load loads the CA, certificate and key from disk
+func (c *Certificates) load() error {
+	caBytes, err := os.ReadFile(c.config.CAFile)
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBytes) {
+		return fmt.Errorf("failed to load CA certificate from %s", c.config.CAFile)
+	}
+	cert, err := tls.LoadX509KeyPair(c.config.CertFile, c.config.KeyFile)
+	if err != nil {
+		return err
+	}
+	modTime, err := c.getModTime()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.cert = &cert
+	c.pool = pool
+	c.modTime = modTime
+	c.mu.Unlock()
+	return nil
+}
+
+// getModTime returns the latest modification time of the configured files
+func (c *Certificates) getModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{c.config.CAFile, c.config.CertFile, c.config.KeyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return modTime, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+// get returns the current certificate and CA pool, reloading them if they've changed
+func (c *Certificates) get() (*tls.Certificate, *x509.CertPool) {
+	if c.config.GetReload() {
+		c.mu.RLock()
+		current := c.modTime
+		c.mu.RUnlock()
+		if modTime, err := c.getModTime(); err == nil && modTime.After(current) {
+			log.Infow("Reloading TLS certificates",
+				logging.String("CertFile", c.config.CertFile))
+			if err := c.load(); err != nil {
+				log.Warnw("Failed to reload TLS certificates",
+					logging.String("CertFile", c.config.CertFile),
+					logging.Error("Error", err))
+			}
+		}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cert, c.pool
+}
+
+// ServerConfig returns the TLS configuration for the API server. Client certificates are verified when
+// presented, and required by the node's administrative services.
+func (c *Certificates) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, pool := c.get()
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientCAs:    pool,
+				ClientAuth:   tls.VerifyClientCertIfGiven,
+			}, nil
+		},
+	}
+}
+
+// ClientConfig returns the TLS configuration for connections to the API servers of other nodes
+func (c *Certificates) ClientConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The server certificate is verified against the current CA pool by VerifyConnection
+		InsecureSkipVerify: true,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, _ := c.get()
+			return cert, nil
+		},
+		VerifyConnection: func(state tls.ConnectionState) error {
+			_, pool := c.get()
+			return verifyPeer(state, pool, x509.ExtKeyUsageServerAuth)
+		},
+	}
+}
+
+// verifyPeer verifies the peer certificate of the given connection against the given CA pool
+func verifyPeer(state tls.ConnectionState, pool *x509.CertPool, usage x509.ExtKeyUsage) error {
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no peer certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	})
+	return err
+}
+
+// adminServices are the services that require clients to present a verified certificate
+var adminServices = []string{
+	"/atomix.consensus.node.v1.Node/",
+	"/atomix.consensus.node.v1.Forwarder/",
+}
+
+func isAdminMethod(method string) bool {
+	for _, service := range adminServices {
+		if strings.HasPrefix(method, service) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkClientCert returns an error if the caller did not present a verified client certificate
+func checkClientCert(ctx context.Context) error {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return errors.ToProto(errors.NewUnauthorized("client certificate required"))
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+		return errors.ToProto(errors.NewUnauthorized("client certificate required"))
+	}
+	return nil
+}
+
+// NewClientCertUnaryInterceptor returns a server interceptor requiring a verified client certificate
+// for calls to the node's administrative services
+func NewClientCertUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isAdminMethod(info.FullMethod) {
+			if err := checkClientCert(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewClientCertStreamInterceptor returns a server interceptor requiring a verified client certificate
+// for streams to the node's administrative services
+func NewClientCertStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isAdminMethod(info.FullMethod) {
+			if err := checkClientCert(stream.Context()); err != nil {
+				return err
+			}
+		}
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,234 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testCA issues certificates for the loopback addresses on which the nodes of a test cluster are hosted
+type testCA struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	serial  int64
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		serial:  1,
+	}
+}
+
+// issue writes the CA and a newly issued node certificate and key to the given directory
+func (ca *testCA) issue(t *testing.T, dir string) TLSConfig {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca.serial++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(ca.serial),
+		Subject:      pkix.Name{CommonName: "test-node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1"), net.ParseIP("127.0.0.2"), net.ParseIP("127.0.0.3")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := TLSConfig{
+		CAFile:   filepath.Join(dir, "ca.crt"),
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+	}
+	files := map[string][]byte{
+		config.CAFile:   ca.certPEM,
+		config.CertFile: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		config.KeyFile:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+	for file, data := range files {
+		if err := os.WriteFile(file, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return config
+}
+
+func newTestCertificates(t *testing.T, ca *testCA) *Certificates {
+	certs, err := NewCertificates(ca.issue(t, t.TempDir()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certs
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	cluster := newTestCluster(t, 3, WithCertificates(newTestCertificates(t, ca)))
+	leader := cluster.bootstrap(t, 1)
+	partition := cluster.partition(t, leader, 1)
+	ctx := context.Background()
+	sessionID := openTestSession(t, partition)
+	counterID := createTestCounter(t, partition, sessionID, 1)
+	if value := incrementTestCounter(t, ctx, partition, newTestIncrementInput(t, sessionID, protocol.SequenceNum(2), counterID)); value != 1 {
+		t.Fatalf("expected counter value 1, got %d", value)
+	}
+}
+
+func TestClientCertInterceptors(t *testing.T) {
+	ca := newTestCA(t)
+	certs := newTestCertificates(t, ca)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(certs.ServerConfig())),
+		grpc.UnaryInterceptor(NewClientCertUnaryInterceptor()),
+		grpc.StreamInterceptor(NewClientCertStreamInterceptor()))
+	RegisterNodeServer(server, &UnimplementedNodeServer{})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca.certPEM)
+	tests := []struct {
+		name       string
+		config     *tls.Config
+		connected  bool
+		authorized bool
+	}{
+		{
+			name:       "client certificate",
+			config:     certs.ClientConfig(),
+			connected:  true,
+			authorized: true,
+		},
+		{
+			name: "no client certificate",
+			config: &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				RootCAs:    pool,
+			},
+			connected: true,
+		},
+		{
+			name:   "client certificate from another CA",
+			config: newTestCertificates(t, newTestCA(t)).ClientConfig(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(credentials.NewTLS(test.config)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			// Data plane services are available to any client that trusts the node
+			_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+			if test.connected && err != nil {
+				t.Fatalf("expected health check to succeed, got %v", err)
+			} else if !test.connected {
+				if !errors.IsUnavailable(errors.FromProto(err)) {
+					t.Fatalf("expected Unavailable error, got %v", err)
+				}
+				return
+			}
+
+			// The unimplemented server returns Unimplemented errors once a call has been authorized
+			_, err = NewNodeClient(conn).GetNodeInfo(ctx, &GetNodeInfoRequest{})
+			if test.authorized {
+				if !errors.IsNotSupported(errors.FromProto(err)) {
+					t.Fatalf("expected call to be authorized, got %v", err)
+				}
+			} else if !errors.IsUnauthorized(errors.FromProto(err)) {
+				t.Fatalf("expected Unauthorized error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCertificatesReload(t *testing.T) {
+	for _, reload := range []bool{true, false} {
+		ca := newTestCA(t)
+		dir := t.TempDir()
+		config := ca.issue(t, dir)
+		config.Reload = &reload
+		certs, err := NewCertificates(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, _ := certs.get()
+		original := cert.Certificate[0]
+
+		ca.issue(t, dir)
+		modTime := time.Now().Add(time.Minute)
+		for _, file := range []string{config.CAFile, config.CertFile, config.KeyFile} {
+			if err := os.Chtimes(file, modTime, modTime); err != nil {
+				t.Fatal(err)
+			}
+		}
+		cert, _ = certs.get()
+		if reloaded := string(cert.Certificate[0]) != string(original); reloaded != reload {
+			t.Errorf("expected reloaded %t, got %t", reload, reloaded)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName  = "github.com/atomix/consensus-storage/node/pkg/consensus"
+	serviceName = "atomix-consensus-node"
+)
+
+const (
+	partitionAttribute   = attribute.Key("atomix.consensus.partition")
+	termAttribute        = attribute.Key("atomix.consensus.term")
+	sequenceNumAttribute = attribute.Key("atomix.consensus.sequence_num")
+	indexAttribute       = attribute.Key("atomix.consensus.index")
+	entriesAttribute     = attribute.Key("atomix.consensus.entries")
+)
+
+// tracer creates the node's spans. Spans are not recorded unless a tracer provider is registered with otel.
+var tracer = otel.Tracer(tracerName)
+
+// traceContextPropagator propagates trace context through the Raft log. The W3C format is always used for log
+// entries so that entries written by one node can be read by others regardless of the global propagator.
+var traceContextPropagator = propagation.TraceContext{}
+
+// NewTracerProvider returns a tracer provider exporting the node's spans to an OTLP collector
+func NewTracerProvider(ctx context.Context, config TracingConfig) (*sdktrace.TracerProvider, error) {
+	options := []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(config.Endpoint),
+	}
+	if config.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.GetSampleRatio())))), nil
+}
+
+// newTraceContext returns the trace context to record in a proposal made in the given context, or nil if the
+// proposal is not sampled
+func newTraceContext(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	traceContextPropagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// getProposalSpanContext returns the context of the span that made the given proposal
+func getProposalSpanContext(proposal *RaftProposal) trace.SpanContext {
+	if len(proposal.TraceContext) == 0 {
+		return trace.SpanContext{}
+	}
+	ctx := traceContextPropagator.Extract(context.Background(), propagation.MapCarrier(proposal.TraceContext))
+	return trace.SpanContextFromContext(ctx)
+}
+
+// endSpan ends the given span, recording the error if the traced operation failed
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/protocol/node"
+	"github.com/atomix/runtime/sdk/pkg/protocol/statemachine"
+	"github.com/gogo/protobuf/proto"
+	dbsm "github.com/lni/dragonboat/v3/statemachine"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"sync"
+	"testing"
+)
+
+var (
+	testSpanRecorder     *tracetest.SpanRecorder
+	testSpanRecorderOnce sync.Once
+)
+
+// getTestSpanRecorder registers a tracer provider recording the spans of the package's tracer. The package's
+// tracer is bound to the first provider registered with otel, so the provider is registered once per test run.
+func getTestSpanRecorder() *tracetest.SpanRecorder {
+	testSpanRecorderOnce.Do(func() {
+		testSpanRecorder = tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(testSpanRecorder)))
+	})
+	return testSpanRecorder
+}
+
+func TestTraceContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		sampler sdktrace.Sampler
+		traced  bool
+	}{
+		{
+			name:    "sampled",
+			sampler: sdktrace.AlwaysSample(),
+			traced:  true,
+		},
+		{
+			name:    "not sampled",
+			sampler: sdktrace.NeverSample(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(test.sampler))
+			defer provider.Shutdown(context.Background())
+			ctx, span := provider.Tracer(tracerName).Start(context.Background(), "test")
+			defer span.End()
+
+			proposal := &RaftProposal{
+				TraceContext: newTraceContext(ctx),
+			}
+			// The trace context must survive the proposal's trip through the Raft log
+			bytes, err := proto.Marshal(proposal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			proposal = &RaftProposal{}
+			if err := proto.Unmarshal(bytes, proposal); err != nil {
+				t.Fatal(err)
+			}
+
+			spanContext := getProposalSpanContext(proposal)
+			if !test.traced {
+				if proposal.TraceContext != nil || spanContext.IsValid() {
+					t.Fatal("expected unsampled proposal not to carry a trace context")
+				}
+				return
+			}
+			if !spanContext.IsValid() || !spanContext.IsRemote() {
+				t.Fatal("expected proposal to carry a remote span context")
+			}
+			if spanContext.TraceID() != span.SpanContext().TraceID() || spanContext.SpanID() != span.SpanContext().SpanID() {
+				t.Errorf("expected span context %v, got %v", span.SpanContext(), spanContext)
+			}
+		})
+	}
+}
+
+func TestUpdateLinksProposalSpans(t *testing.T) {
+	recorder := getTestSpanRecorder()
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+
+	partition := &Partition{
+		Partition: node.NewPartition(1, nil),
+	}
+	sm := newStateMachine(partition, newContext(), statemachine.NewPrimitiveTypeRegistry(), "")
+	ctx, proposeSpan := provider.Tracer(tracerName).Start(context.Background(), "Executor.Propose")
+	proposeSpan.End()
+
+	traced := newOpenSessionEntry(t, 1)
+	proposal := &RaftProposal{}
+	if err := proto.Unmarshal(traced.Cmd, proposal); err != nil {
+		t.Fatal(err)
+	}
+	proposal.TraceContext = newTraceContext(ctx)
+	cmd, err := proto.Marshal(proposal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	traced.Cmd = cmd
+	untraced := newOpenSessionEntry(t, 2)
+
+	if _, err := sm.Update([]dbsm.Entry{traced, untraced}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	for _, name := range []string{"StateMachine.Update", "StateMachine.Apply"} {
+		span, ok := spans[name]
+		if !ok {
+			t.Fatalf("expected %s span", name)
+		}
+		links := span.Links()
+		if len(links) != 1 {
+			t.Fatalf("expected %s span to link to 1 proposal, got %d", name, len(links))
+		}
+		if !links[0].SpanContext.Equal(trace.SpanContextFromContext(ctx).WithRemote(true)) {
+			t.Errorf("expected %s span to link to the proposal span", name)
+		}
+	}
+	if apply := spans["StateMachine.Apply"]; apply.Parent().SpanID() != spans["StateMachine.Update"].SpanContext().SpanID() {
+		t.Error("expected StateMachine.Apply span to be a child of the StateMachine.Update span")
+	}
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"github.com/atomix/runtime/sdk/pkg/errors"
+	"time"
+)
+
+const eventBufferSize = 1024
+
+// eventEpochShift is the number of low bits of a sequence number holding the event counter. The remaining
+// bits hold the epoch of the buffer that assigned the sequence number.
+const eventEpochShift = 40
+
+func newEventBuffer(size int) *eventBuffer {
+	return &eventBuffer{
+		events:   make([]Event, size),
+		sequence: newEventEpoch() << eventEpochShift,
+	}
+}
+
+// newEventEpoch returns a random nonzero epoch for the sequence numbers assigned by a new event buffer
+func newEventEpoch() uint64 {
+	var bytes [8]byte
+	if _, err := rand.Read(bytes[:]); err != nil {
+		panic(err)
+	}
+	epoch := binary.BigEndian.Uint64(bytes[:]) >> eventEpochShift
+	if epoch == 0 {
+		epoch = 1
+	}
+	return epoch
+}
+
+// eventBuffer assigns sequence numbers to the node's events and retains the most recent events in a ring
+// buffer, allowing a watcher to resume following the last event it received. Sequence numbers are assigned
+// by a counter in the low bits, prefixed with a random epoch chosen when the node starts so that a watcher
+// resuming from an event published before the node was restarted is never mistaken for one resuming from
+// an event published since.
+type eventBuffer struct {
+	events   []Event
+	start    int
+	len      int
+	sequence uint64
+}
+
+// append assigns the next sequence number to the given event and adds it to the buffer, evicting the oldest
+// event if the buffer is full
+func (b *eventBuffer) append(event *Event) {
+	b.sequence++
+	event.Sequence = b.sequence
+	if b.len < len(b.events) {
+		b.events[(b.start+b.len)%len(b.events)] = *event
+		b.len++
+	} else {
+		b.events[b.start] = *event
+		b.start = (b.start + 1) % len(b.events)
+	}
+}
+
+// since returns the buffered events following the given sequence number. If any of the events following
+// the sequence number have been evicted, or the sequence number was not assigned by this buffer, ok is false.
+func (b *eventBuffer) since(sequence uint64) (events []Event, ok bool) {
+	if sequence>>eventEpochShift != b.sequence>>eventEpochShift || sequence > b.sequence || b.sequence-sequence > uint64(b.len) {
+		return nil, false
+	}
+	n := int(b.sequence - sequence)
+	events = make([]Event, 0, n)
+	for i := b.len - n; i < b.len; i++ {
+		events = append(events, b.events[(b.start+i)%len(b.events)])
+	}
+	return events, true
+}
+
+func newWatcher(filter eventFilter, size int, policy WatchOverflowPolicy, events []Event) *Watcher {
+	// The queue is sized to hold the initial events in addition to the queue size, and reserves a slot for a
+	// resync event so the watcher can always be notified of dropped events
+	queue := make(chan Event, len(events)+size+1)
+	watcher := &Watcher{
+		filter: filter,
+		policy: policy,
+		queue:  queue,
+	}
+	for _, event := range events {
+		queue <- event
+		watcher.sequence = event.Sequence
+	}
+	return watcher
+}
+
+// Watcher queues the node's events for a client, which reads them from the queue at its own pace. Events are
+// only ever added to the queue by the publisher, so that sending an event never blocks the Raft engine.
+type Watcher struct {
+	filter   eventFilter
+	policy   WatchOverflowPolicy
+	queue    chan Event
+	sequence uint64
+	dropping bool
+	err      error
+}
+
+// send adds the given event to the queue if the watcher requested it, returning whether the event was dropped
+// and whether the watcher remains connected. A watcher that is disconnected must be closed by the caller.
+func (w *Watcher) send(event *Event) (dropped bool, ok bool) {
+	if !w.filter.matches(event) {
+		return false, true
+	}
+	if len(w.queue) < cap(w.queue)-1 {
+		w.queue <- *event
+		w.sequence = event.Sequence
+		w.dropping = false
+		return false, true
+	}
+	switch w.policy {
+	case DisconnectWatchOverflow:
+		w.err = errors.NewUnavailable("watcher fell behind at event %d", w.sequence)
+		return true, false
+	default:
+		if !w.dropping {
+			w.queue <- Event{
+				Timestamp: time.Now(),
+				Sequence:  w.sequence,
+				HostID:    event.HostID,
+				Event: &Event_Resync{
+					Resync: &ResyncEvent{},
+				},
+			}
+			w.dropping = true
+		}
+		return true, true
+	}
+}
+
+// Events returns the watcher's queue of events, which is closed when the watcher is closed
+func (w *Watcher) Events() <-chan Event {
+	return w.queue
+}
+
+// Err returns the error with which the watcher was disconnected, if any, once its queue has been closed
+func (w *Watcher) Err() error {
+	return w.err
+}
+
+// close closes the watcher's queue, after which the watcher reads the remaining events
+func (w *Watcher) close() {
+	close(w.queue)
+}
+
+func newEventFilter(request *WatchRequest) eventFilter {
+	var filter eventFilter
+	if len(request.GroupIDs) > 0 {
+		filter.groups = make(map[GroupID]bool)
+		for _, groupID := range request.GroupIDs {
+			filter.groups[groupID] = true
+		}
+	}
+	if len(request.EventTypes) > 0 {
+		filter.types = make(map[EventType]bool)
+		for _, eventType := range request.EventTypes {
+			filter.types[eventType] = true
+		}
+	}
+	return filter
+}
+
+// eventFilter matches the events requested by a watcher
+type eventFilter struct {
+	groups map[GroupID]bool
+	types  map[EventType]bool
+}
+
+func (f eventFilter) matches(event *Event) bool {
+	if f.types != nil && !f.types[getEventType(event)] {
+		return false
+	}
+	if f.groups != nil {
+		if member, ok := getMemberEvent(event); ok && !f.groups[member.GroupID] {
+			return false
+		}
+	}
+	return true
+}
+
+// getEventType returns the type of the given event
+func getEventType(event *Event) EventType {
+	switch event.Event.(type) {
+	case *Event_MemberReady:
+		return EventType_MEMBER_READY
+	case *Event_LeaderUpdated:
+		return EventType_LEADER_UPDATED
+	case *Event_MembershipChanged:
+		return EventType_MEMBERSHIP_CHANGED
+	case *Event_SendSnapshotStarted:
+		return EventType_SEND_SNAPSHOT_STARTED
+	case *Event_SendSnapshotCompleted:
+		return EventType_SEND_SNAPSHOT_COMPLETED
+	case *Event_SendSnapshotAborted:
+		return EventType_SEND_SNAPSHOT_ABORTED
+	case *Event_SnapshotReceived:
+		return EventType_SNAPSHOT_RECEIVED
+	case *Event_SnapshotRecovered:
+		return EventType_SNAPSHOT_RECOVERED
+	case *Event_SnapshotCreated:
+		return EventType_SNAPSHOT_CREATED
+	case *Event_SnapshotCompacted:
+		return EventType_SNAPSHOT_COMPACTED
+	case *Event_LogCompacted:
+		return EventType_LOG_COMPACTED
+	case *Event_LogdbCompacted:
+		return EventType_LOGDB_COMPACTED
+	case *Event_ConnectionEstablished:
+		return EventType_CONNECTION_ESTABLISHED
+	case *Event_ConnectionFailed:
+		return EventType_CONNECTION_FAILED
+	case *Event_Resync:
+		return EventType_RESYNC
+	case *Event_MemberUnloaded:
+		return EventType_MEMBER_UNLOADED
+	case *Event_HostShuttingDown:
+		return EventType_HOST_SHUTTING_DOWN
+	default:
+		return EventType_UNKNOWN_EVENT
+	}
+}
+
+// getMemberEvent returns the member to which the given event relates, if any
+func getMemberEvent(event *Event) (MemberEvent, bool) {
+	switch e := event.Event.(type) {
+	case *Event_MemberReady:
+		return e.MemberReady.MemberEvent, true
+	case *Event_LeaderUpdated:
+		return e.LeaderUpdated.MemberEvent, true
+	case *Event_MembershipChanged:
+		return e.MembershipChanged.MemberEvent, true
+	case *Event_SendSnapshotStarted:
+		return e.SendSnapshotStarted.MemberEvent, true
+	case *Event_SendSnapshotCompleted:
+		return e.SendSnapshotCompleted.MemberEvent, true
+	case *Event_SendSnapshotAborted:
+		return e.SendSnapshotAborted.MemberEvent, true
+	case *Event_SnapshotReceived:
+		return e.SnapshotReceived.MemberEvent, true
+	case *Event_SnapshotRecovered:
+		return e.SnapshotRecovered.MemberEvent, true
+	case *Event_SnapshotCreated:
+		return e.SnapshotCreated.MemberEvent, true
+	case *Event_SnapshotCompacted:
+		return e.SnapshotCompacted.MemberEvent, true
+	case *Event_LogCompacted:
+		return e.LogCompacted.MemberEvent, true
+	case *Event_LogdbCompacted:
+		return e.LogdbCompacted.MemberEvent, true
+	case *Event_MemberUnloaded:
+		return e.MemberUnloaded.MemberEvent, true
+	default:
+		return MemberEvent{}, false
+	}
+}
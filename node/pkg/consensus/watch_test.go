@@ -0,0 +1,461 @@
+// SPDX-FileCopyrightText: 2022-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package consensus
+
+import (
+	"context"
+	"github.com/atomix/runtime/sdk/pkg/protocol"
+	"testing"
+	"time"
+)
+
+func newTestEvent(sequence uint64) Event {
+	return Event{
+		Sequence: sequence,
+		Event: &Event_LeaderUpdated{
+			LeaderUpdated: &LeaderUpdatedEvent{
+				MemberEvent: MemberEvent{
+					GroupID:  1,
+					MemberID: 1,
+				},
+				Term:   1,
+				Leader: 1,
+			},
+		},
+	}
+}
+
+func TestWatcherOverflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    WatchOverflowPolicy
+		size      int
+		initial   int
+		sends     int
+		dropped   int
+		queued    []uint64
+		resync    bool
+		connected bool
+	}{
+		{
+			name:      "drop within queue",
+			policy:    DropWatchOverflow,
+			size:      2,
+			sends:     2,
+			queued:    []uint64{1, 2},
+			connected: true,
+		},
+		{
+			name:      "drop overflow",
+			policy:    DropWatchOverflow,
+			size:      2,
+			sends:     5,
+			dropped:   3,
+			queued:    []uint64{1, 2},
+			resync:    true,
+			connected: true,
+		},
+		{
+			name:      "drop overflow with initial events",
+			policy:    DropWatchOverflow,
+			size:      1,
+			initial:   2,
+			sends:     2,
+			dropped:   1,
+			queued:    []uint64{1, 2, 3},
+			resync:    true,
+			connected: true,
+		},
+		{
+			name:      "disconnect within queue",
+			policy:    DisconnectWatchOverflow,
+			size:      2,
+			sends:     2,
+			queued:    []uint64{1, 2},
+			connected: true,
+		},
+		{
+			name:    "disconnect overflow",
+			policy:  DisconnectWatchOverflow,
+			size:    2,
+			sends:   3,
+			dropped: 1,
+			queued:  []uint64{1, 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var initial []Event
+			for i := 0; i < test.initial; i++ {
+				initial = append(initial, newTestEvent(uint64(len(initial)+1)))
+			}
+			watcher := newWatcher(eventFilter{}, test.size, test.policy, initial)
+
+			dropped := 0
+			connected := true
+			for i := 0; i < test.sends && connected; i++ {
+				event := newTestEvent(uint64(test.initial + i + 1))
+				var isDropped bool
+				isDropped, connected = watcher.send(&event)
+				if isDropped {
+					dropped++
+				}
+			}
+			if connected != test.connected {
+				t.Fatalf("expected connected %t, got %t", test.connected, connected)
+			}
+			if !connected && watcher.Err() == nil {
+				t.Error("expected disconnected watcher to report an error")
+			}
+			if dropped != test.dropped {
+				t.Errorf("expected %d dropped events, got %d", test.dropped, dropped)
+			}
+
+			watcher.close()
+			var events []Event
+			for event := range watcher.Events() {
+				events = append(events, event)
+			}
+			if test.resync {
+				if len(events) == 0 {
+					t.Fatal("expected resync event")
+				}
+				resync := events[len(events)-1]
+				if _, ok := resync.Event.(*Event_Resync); !ok {
+					t.Fatalf("expected resync event, got %s", getEventType(&resync))
+				}
+				if last := test.queued[len(test.queued)-1]; resync.Sequence != last {
+					t.Errorf("expected resync at sequence %d, got %d", last, resync.Sequence)
+				}
+				events = events[:len(events)-1]
+			}
+			if len(events) != len(test.queued) {
+				t.Fatalf("expected %d queued events, got %d", len(test.queued), len(events))
+			}
+			for i, event := range events {
+				if _, ok := event.Event.(*Event_Resync); ok {
+					t.Fatalf("unexpected resync event at %d", i)
+				}
+				if event.Sequence != test.queued[i] {
+					t.Errorf("expected sequence %d, got %d", test.queued[i], event.Sequence)
+				}
+			}
+		})
+	}
+}
+
+func TestWatcherResumesAfterDrop(t *testing.T) {
+	watcher := newWatcher(eventFilter{}, 1, DropWatchOverflow, nil)
+	for i := uint64(1); i <= 3; i++ {
+		event := newTestEvent(i)
+		watcher.send(&event)
+	}
+	if event := <-watcher.Events(); event.Sequence != 1 {
+		t.Fatalf("expected sequence 1, got %d", event.Sequence)
+	}
+	if event := <-watcher.Events(); getEventType(&event) != EventType_RESYNC {
+		t.Fatalf("expected resync event, got %s", getEventType(&event))
+	}
+
+	event := newTestEvent(4)
+	if dropped, ok := watcher.send(&event); dropped || !ok {
+		t.Fatalf("expected event to be queued after the queue was drained")
+	}
+	if event := <-watcher.Events(); event.Sequence != 4 {
+		t.Fatalf("expected sequence 4, got %d", event.Sequence)
+	}
+}
+
+func TestEventBufferSince(t *testing.T) {
+	buffer := newEventBuffer(4)
+	base := buffer.sequence
+	for i := 0; i < 6; i++ {
+		event := newTestEvent(0)
+		buffer.append(&event)
+	}
+
+	tests := []struct {
+		name     string
+		sequence uint64
+		events   []uint64
+		ok       bool
+	}{
+		{
+			name:     "latest event",
+			sequence: base + 6,
+			events:   []uint64{},
+			ok:       true,
+		},
+		{
+			name:     "buffered events",
+			sequence: base + 4,
+			events:   []uint64{base + 5, base + 6},
+			ok:       true,
+		},
+		{
+			name:     "oldest buffered event",
+			sequence: base + 2,
+			events:   []uint64{base + 3, base + 4, base + 5, base + 6},
+			ok:       true,
+		},
+		{
+			name:     "evicted event",
+			sequence: base + 1,
+		},
+		{
+			name:     "future event",
+			sequence: base + 7,
+		},
+		{
+			name:     "other epoch",
+			sequence: base + 4 + (1 << eventEpochShift),
+		},
+		{
+			name:     "no epoch",
+			sequence: 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			events, ok := buffer.since(test.sequence)
+			if ok != test.ok {
+				t.Fatalf("expected ok %t, got %t", test.ok, ok)
+			}
+			if len(events) != len(test.events) {
+				t.Fatalf("expected %d events, got %d", len(test.events), len(events))
+			}
+			for i, event := range events {
+				if event.Sequence != test.events[i] {
+					t.Errorf("expected sequence %d, got %d", test.events[i], event.Sequence)
+				}
+			}
+		})
+	}
+}
+
+// newTestWatchProtocol returns a protocol without a node host, to which events can be published
+func newTestWatchProtocol(partitions ...*Partition) *Protocol {
+	var options Options
+	options.apply()
+	p := &Protocol{
+		options:    options,
+		partitions: make(map[protocol.PartitionID]*Partition),
+		events:     newEventBuffer(eventBufferSize),
+		watchers:   make(map[int]*Watcher),
+		hostID:     "test",
+	}
+	for _, partition := range partitions {
+		p.partitions[partition.ID()] = partition
+	}
+	return p
+}
+
+func newTestGroupEvent(groupID GroupID, event isEvent_Event) *Event {
+	switch e := event.(type) {
+	case *Event_LeaderUpdated:
+		e.LeaderUpdated.MemberEvent = MemberEvent{GroupID: groupID, MemberID: 1}
+	case *Event_SnapshotCreated:
+		e.SnapshotCreated.MemberEvent = MemberEvent{GroupID: groupID, MemberID: 1}
+	}
+	return &Event{
+		Timestamp: time.Now(),
+		Event:     event,
+	}
+}
+
+func readTestEvents(t *testing.T, watcher *Watcher, n int) []Event {
+	events := make([]Event, 0, n)
+	for len(events) < n {
+		select {
+		case event, ok := <-watcher.Events():
+			if !ok {
+				t.Fatalf("expected %d events, got %d", n, len(events))
+			}
+			events = append(events, event)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d events, got %d", n, len(events))
+		}
+	}
+	select {
+	case event, ok := <-watcher.Events():
+		if ok {
+			t.Fatalf("unexpected %s event", getEventType(&event))
+		}
+	default:
+	}
+	return events
+}
+
+func TestWatchFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *WatchRequest
+		events  []EventType
+		groups  []GroupID
+	}{
+		{
+			name:    "all events",
+			request: &WatchRequest{},
+			events: []EventType{
+				EventType_LEADER_UPDATED,
+				EventType_LEADER_UPDATED,
+				EventType_SNAPSHOT_CREATED,
+				EventType_CONNECTION_FAILED,
+			},
+			groups: []GroupID{1, 2, 1, 0},
+		},
+		{
+			name: "group",
+			request: &WatchRequest{
+				GroupIDs: []GroupID{1},
+			},
+			events: []EventType{
+				EventType_LEADER_UPDATED,
+				EventType_SNAPSHOT_CREATED,
+				EventType_CONNECTION_FAILED,
+			},
+			groups: []GroupID{1, 1, 0},
+		},
+		{
+			name: "event type",
+			request: &WatchRequest{
+				EventTypes: []EventType{EventType_LEADER_UPDATED},
+			},
+			events: []EventType{
+				EventType_LEADER_UPDATED,
+				EventType_LEADER_UPDATED,
+			},
+			groups: []GroupID{1, 2},
+		},
+		{
+			name: "group and event type",
+			request: &WatchRequest{
+				GroupIDs:   []GroupID{2},
+				EventTypes: []EventType{EventType_LEADER_UPDATED, EventType_SNAPSHOT_CREATED},
+			},
+			events: []EventType{
+				EventType_LEADER_UPDATED,
+			},
+			groups: []GroupID{2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newTestWatchProtocol()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			watcher := p.Watch(ctx, test.request)
+			p.publish(newTestGroupEvent(1, &Event_LeaderUpdated{LeaderUpdated: &LeaderUpdatedEvent{Term: 1, Leader: 1}}))
+			p.publish(newTestGroupEvent(2, &Event_LeaderUpdated{LeaderUpdated: &LeaderUpdatedEvent{Term: 1, Leader: 1}}))
+			p.publish(newTestGroupEvent(1, &Event_SnapshotCreated{SnapshotCreated: &SnapshotCreatedEvent{Index: 10}}))
+			p.publish(newTestGroupEvent(0, &Event_ConnectionFailed{ConnectionFailed: &ConnectionFailedEvent{}}))
+
+			events := readTestEvents(t, watcher, len(test.events))
+			var sequence uint64
+			for i, event := range events {
+				if eventType := getEventType(&event); eventType != test.events[i] {
+					t.Errorf("expected %s event, got %s", test.events[i], eventType)
+				}
+				member, _ := getMemberEvent(&event)
+				if member.GroupID != test.groups[i] {
+					t.Errorf("expected event for group %d, got %d", test.groups[i], member.GroupID)
+				}
+				if event.HostID != "test" {
+					t.Errorf("expected event from host test, got %s", event.HostID)
+				}
+				if event.Sequence <= sequence {
+					t.Errorf("expected increasing sequence numbers, got %d after %d", event.Sequence, sequence)
+				}
+				sequence = event.Sequence
+			}
+		})
+	}
+}
+
+func TestWatchResume(t *testing.T) {
+	partition := newTestMemberPartition(nil, 1, 1)
+	partition.setLeader(1, 1)
+	p := newTestWatchProtocol(partition)
+	var sequences []uint64
+	for i := 0; i < 3; i++ {
+		event := newTestGroupEvent(1, &Event_SnapshotCreated{SnapshotCreated: &SnapshotCreatedEvent{Index: Index(i + 1)}})
+		p.publish(event)
+		sequences = append(sequences, event.Sequence)
+	}
+
+	tests := []struct {
+		name     string
+		sequence uint64
+		events   []EventType
+		indexes  []Index
+	}{
+		{
+			name:     "buffered events",
+			sequence: sequences[0],
+			events:   []EventType{EventType_SNAPSHOT_CREATED, EventType_SNAPSHOT_CREATED},
+			indexes:  []Index{2, 3},
+		},
+		{
+			name:     "latest event",
+			sequence: sequences[2],
+		},
+		{
+			name:   "new watch",
+			events: []EventType{EventType_LEADER_UPDATED},
+		},
+		{
+			name:     "unknown sequence",
+			sequence: 1,
+			events:   []EventType{EventType_RESYNC, EventType_LEADER_UPDATED},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			watcher := p.Watch(ctx, &WatchRequest{FromSequence: test.sequence})
+			events := readTestEvents(t, watcher, len(test.events))
+			for i, event := range events {
+				if eventType := getEventType(&event); eventType != test.events[i] {
+					t.Fatalf("expected %s event, got %s", test.events[i], eventType)
+				}
+				switch e := event.Event.(type) {
+				case *Event_SnapshotCreated:
+					if e.SnapshotCreated.Index != test.indexes[i] {
+						t.Errorf("expected snapshot at index %d, got %d", test.indexes[i], e.SnapshotCreated.Index)
+					}
+				case *Event_Resync:
+					if !e.Resync.FullState {
+						t.Error("expected full state resync")
+					}
+				}
+				// State events carry the last sequence number, from which the watcher can resume
+				if event.Sequence > sequences[2] {
+					t.Errorf("unexpected sequence %d", event.Sequence)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchCanceled(t *testing.T) {
+	p := newTestWatchProtocol()
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher := p.Watch(ctx, &WatchRequest{})
+	cancel()
+	select {
+	case _, ok := <-watcher.Events():
+		if ok {
+			t.Fatal("expected no events")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected watcher to be closed when the watch is canceled")
+	}
+	p.eventsMu.Lock()
+	defer p.eventsMu.Unlock()
+	if len(p.watchers) != 0 {
+		t.Errorf("expected watcher to be removed, got %d watchers", len(p.watchers))
+	}
+}